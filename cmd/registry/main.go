@@ -4,7 +4,8 @@ import (
 	"context"
 	"errors"
 	"flag"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,9 +13,14 @@ import (
 	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/api"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+	"github.com/modelcontextprotocol/registry/internal/mirror"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/policy"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
@@ -26,14 +32,12 @@ func main() {
 
 	// Show version information if requested
 	if *showVersion {
-		log.Printf("MCP Registry v%s\n", Version)
-		log.Printf("Git commit: %s\n", GitCommit)
-		log.Printf("Build time: %s\n", BuildTime)
+		fmt.Printf("MCP Registry v%s\n", Version)
+		fmt.Printf("Git commit: %s\n", GitCommit)
+		fmt.Printf("Build time: %s\n", BuildTime)
 		return
 	}
 
-	log.Printf("Starting MCP Registry Application v%s (commit: %s)", Version, GitCommit)
-
 	var (
 		registryService service.RegistryService
 		db              database.Database
@@ -43,11 +47,27 @@ func main() {
 	// Initialize configuration
 	cfg := config.NewConfig()
 
+	// Initialize structured logging
+	logger, err := logging.New(os.Stderr, cfg)
+	if err != nil {
+		slog.Error("Failed to initialize logging", slog.Any("error", err))
+		os.Exit(1)
+	}
+	slog.SetDefault(logger)
+
+	slog.Info("Starting MCP Registry Application", slog.String("version", Version), slog.String("commit", GitCommit))
+
+	// Compile publish policies up front, so that invalid ones stop the registry from starting
+	policies, err := policy.NewEngine(cfg)
+	if err != nil {
+		slog.Error("Invalid publish policies", slog.Any("error", err))
+		return
+	}
+
 	// Initialize services based on environment
 	switch cfg.DatabaseType {
 	case config.DatabaseTypeMemory:
 		db = database.NewMemoryDB(map[string]*model.ServerDetail{})
-		registryService = service.NewRegistryServiceWithDB(db)
 	case config.DatabaseTypePostgreSQL:
 		// Use PostgreSQL for real registry service
 		// Create a context with timeout for PostgreSQL connection
@@ -55,61 +75,116 @@ func main() {
 		defer cancel()
 
 		// Connect to PostgreSQL
-		db, err = database.NewPostgreSQL(ctx, cfg.DatabaseURL)
+		pg, err := database.NewPostgreSQL(ctx, cfg.DatabaseURL)
 		if err != nil {
-			log.Printf("Failed to connect to PostgreSQL: %v", err)
+			slog.Error("Failed to connect to PostgreSQL", slog.Any("error", err))
 			return
 		}
-
-		// Create registry service with PostgreSQL
-		registryService = service.NewRegistryServiceWithDB(db)
-		log.Printf("PostgreSQL database URL: %s", cfg.DatabaseURL)
+		db = pg
+		slog.Info("Connected to PostgreSQL", slog.String("url", cfg.DatabaseURL))
 
 		// Store the PostgreSQL instance for later cleanup
 		defer func() {
 			if err := db.Close(); err != nil {
-				log.Printf("Error closing PostgreSQL connection: %v", err)
+				slog.Error("Error closing PostgreSQL connection", slog.Any("error", err))
 			} else {
-				log.Println("PostgreSQL connection closed successfully")
+				slog.Info("PostgreSQL connection closed successfully")
 			}
 		}()
 	default:
-		log.Printf("Invalid database type: %s; supported types: %s, %s", cfg.DatabaseType, config.DatabaseTypeMemory, config.DatabaseTypePostgreSQL)
+		slog.Error("Invalid database type",
+			slog.String("database_type", string(cfg.DatabaseType)),
+			slog.Any("supported", []config.DatabaseType{config.DatabaseTypeMemory, config.DatabaseTypePostgreSQL}),
+		)
 		return
 	}
 
 	// Import seed data if seed source is provided
+	var seedErr error
 	if cfg.SeedFrom != "" {
-		log.Printf("Importing data from %s...", cfg.SeedFrom)
+		slog.Info("Importing seed data", slog.String("source", cfg.SeedFrom))
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 		defer cancel()
 
-		if err := db.ImportSeed(ctx, cfg.SeedFrom); err != nil {
-			log.Printf("Failed to import seed data: %v", err)
+		if seedErr = db.ImportSeed(ctx, database.NewImportClient(cfg), cfg.SeedFrom); seedErr != nil {
+			slog.Error("Failed to import seed data", slog.Any("error", seedErr))
 		} else {
-			log.Println("Data import completed successfully")
+			slog.Info("Data import completed successfully")
 		}
 	}
 
+	checks := readinessChecks(cfg, db, seedErr)
+
 	shutdownTelemetry, metrics, err := telemetry.InitMetrics(cfg.Version)
 	if err != nil {
-		log.Printf("Failed to initialize metrics: %v", err)
+		slog.Error("Failed to initialize metrics", slog.Any("error", err))
 		return
 	}
 
 	defer func() {
 		if err := shutdownTelemetry(context.Background()); err != nil {
-			log.Printf("Failed to shutdown telemetry: %v", err)
+			slog.Error("Failed to shutdown telemetry", slog.Any("error", err))
 		}
 	}()
 
+	// Report catalog size and, for PostgreSQL, connection pool usage
+	if err := metrics.ObserveCatalog(func(ctx context.Context) (*telemetry.CatalogStats, error) {
+		stats, err := db.Stats(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &telemetry.CatalogStats{Servers: int64(stats.Servers), Versions: int64(stats.Versions)}, nil
+	}); err != nil {
+		slog.Error("Failed to register catalog metrics", slog.Any("error", err))
+		return
+	}
+	if pg, ok := db.(*database.PostgreSQL); ok {
+		if err := metrics.ObserveDBPool(pg.PoolStats); err != nil {
+			slog.Error("Failed to register database pool metrics", slog.Any("error", err))
+			return
+		}
+	}
+
+	// Record latency of every database operation
+	db = database.NewInstrumentedDB(db, metrics)
+	registryService = service.NewRegistryServiceWithDB(db, cfg)
+
+	shutdownTracing, err := telemetry.InitTracing(context.Background(), cfg)
+	if err != nil {
+		slog.Error("Failed to initialize tracing", slog.Any("error", err))
+		return
+	}
+
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("Failed to shutdown tracing", slog.Any("error", err))
+		}
+	}()
+
+	// Start the upstream mirror worker if any upstreams are configured
+	mirrorCtx, stopMirror := context.WithCancel(context.Background())
+	defer stopMirror()
+	mirrorWorker := mirror.NewWorker(cfg, db, metrics)
+	var freshness v0.FreshnessFunc
+	if mirrorWorker.Enabled() {
+		go mirrorWorker.Run(mirrorCtx)
+		freshness = mirrorWorker.LastSuccess
+	}
+
+	if cfg.ReadOnly {
+		slog.Info("Running as a read-only replica", slog.String("primary_url", cfg.PrimaryURL))
+	} else if cfg.InstallHashSalt == "" {
+		slog.Warn("MCP_REGISTRY_INSTALL_HASH_SALT is not set, so installs are de-duplicated with a random salt per process " +
+			"and clients may be counted again after a restart or by another replica")
+	}
+
 	// Initialize HTTP server
-	server := api.NewServer(cfg, registryService, metrics)
+	server := api.NewServer(cfg, registryService, metrics, freshness, checks, policies)
 
 	// Start server in a goroutine so it doesn't block signal handling
 	go func() {
 		if err := server.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Printf("Failed to start server: %v", err)
+			slog.Error("Failed to start server", slog.Any("error", err))
 			os.Exit(1)
 		}
 	}()
@@ -119,7 +194,7 @@ func main() {
 
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
-	log.Println("Shutting down server...")
+	slog.Info("Shutting down server...")
 
 	// Create context with timeout for shutdown
 	sctx, scancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -127,8 +202,65 @@ func main() {
 
 	// Gracefully shutdown the server
 	if err := server.Shutdown(sctx); err != nil {
-		log.Printf("Server forced to shutdown: %v", err)
+		slog.Error("Server forced to shutdown", slog.Any("error", err))
+	}
+
+	slog.Info("Server exiting")
+}
+
+// readinessChecks returns the dependency checks reported by the readiness endpoint
+func readinessChecks(cfg *config.Config, db database.Database, seedErr error) []v0.ReadinessCheck {
+	checks := []v0.ReadinessCheck{
+		{
+			Name: "database",
+			Check: func(_ context.Context) error {
+				if !db.Connection().IsConnected {
+					return errors.New("database is not reachable")
+				}
+				return nil
+			},
+		},
+	}
+
+	if pg, ok := db.(*database.PostgreSQL); ok {
+		checks = append(checks, v0.ReadinessCheck{
+			Name: "migrations",
+			Check: func(ctx context.Context) error {
+				status, err := pg.MigrationStatus(ctx)
+				if err != nil {
+					return err
+				}
+				if len(status.Pending) > 0 {
+					return fmt.Errorf("schema is at version %d, %d migrations pending up to version %d", status.Applied, len(status.Pending), status.Latest)
+				}
+				return nil
+			},
+		})
+	}
+
+	// The seed source and the import error, which quotes it, can include credentials, so they are
+	// only logged
+	if cfg.SeedFrom != "" {
+		checks = append(checks, v0.ReadinessCheck{
+			Name: "seed",
+			Check: func(_ context.Context) error {
+				if seedErr != nil {
+					return errors.New("seed import failed, see the logs")
+				}
+				return nil
+			},
+		})
+	}
+
+	// Replicas never issue tokens, so they do not need a signing key
+	if !cfg.ReadOnly {
+		checks = append(checks, v0.ReadinessCheck{
+			Name: "jwt_key",
+			Check: func(_ context.Context) error {
+				return auth.ValidatePrivateKey(cfg)
+			},
+		})
 	}
 
-	log.Println("Server exiting")
+	return checks
 }
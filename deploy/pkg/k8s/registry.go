@@ -131,7 +131,7 @@ func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, env
 							},
 							LivenessProbe: &corev1.ProbeArgs{
 								HttpGet: &corev1.HTTPGetActionArgs{
-									Path: pulumi.String("/v0/health"),
+									Path: pulumi.String("/v0/health/live"),
 									Port: pulumi.Int(8080),
 								},
 								InitialDelaySeconds: pulumi.Int(30),
@@ -139,7 +139,7 @@ func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, env
 							},
 							ReadinessProbe: &corev1.ProbeArgs{
 								HttpGet: &corev1.HTTPGetActionArgs{
-									Path: pulumi.String("/v0/health"),
+									Path: pulumi.String("/v0/health/ready"),
 									Port: pulumi.Int(8080),
 								},
 								InitialDelaySeconds: pulumi.Int(5),
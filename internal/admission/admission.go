@@ -0,0 +1,259 @@
+// Package admission calls admission webhooks, which review servers before they are published or
+// updated, in the style of Kubernetes admission controllers. Webhooks can allow or deny a
+// server, warn about it, and change it.
+package admission
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/httpclient"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+// Operation is what the caller is doing with a server
+type Operation string
+
+const (
+	// OperationPublish publishes a new server or version
+	OperationPublish Operation = "publish"
+	// OperationUpdate changes an existing server version
+	OperationUpdate Operation = "update"
+)
+
+// Caller is who is making a request, as granted by their Registry JWT
+type Caller struct {
+	AuthMethod  model.AuthMethod  `json:"auth_method"`
+	Subject     string            `json:"subject,omitempty"`
+	Permissions []auth.Permission `json:"permissions,omitempty"`
+}
+
+// CallerFromClaims returns the caller of a request authenticated with claims, or nil for
+// anonymous requests
+func CallerFromClaims(claims *auth.JWTClaims) *Caller {
+	if claims == nil {
+		return nil
+	}
+	return &Caller{
+		AuthMethod:  claims.AuthMethod,
+		Subject:     claims.AuthMethodSubject,
+		Permissions: claims.Permissions,
+	}
+}
+
+// Review is the body POSTed to admission webhooks
+type Review struct {
+	// UID identifies the review, and must be echoed in the response
+	UID       string    `json:"uid"`
+	Operation Operation `json:"operation"`
+	// ServerID is the ID of the server version being updated
+	ServerID string               `json:"server_id,omitempty"`
+	Request  model.PublishRequest `json:"request"`
+	// Caller is absent for anonymous requests
+	Caller *Caller `json:"caller,omitempty"`
+}
+
+// Response is the body admission webhooks answer with
+type Response struct {
+	UID     string `json:"uid"`
+	Allowed bool   `json:"allowed"`
+	// Message explains why the request was denied
+	Message string `json:"message,omitempty"`
+	// Warnings are returned to the caller, whether or not the request is allowed
+	Warnings []string `json:"warnings,omitempty"`
+	// Request replaces the reviewed request, if the webhook changed it. The server name can't change.
+	Request *model.PublishRequest `json:"request,omitempty"`
+}
+
+// Result is the outcome of admitting a request that all webhooks allowed
+type Result struct {
+	// Request is the request to commit, as changed by the webhooks
+	Request model.PublishRequest
+	// Warnings from all webhooks, prefixed with the webhook's name
+	Warnings []string
+}
+
+// DeniedError is returned when a webhook denies a request
+type DeniedError struct {
+	Webhook string
+	Message string
+	// Warnings from the webhooks called up to and including the one that denied the request
+	Warnings []string
+}
+
+func (e *DeniedError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("admission webhook %s denied the request", e.Webhook)
+	}
+	return fmt.Sprintf("admission webhook %s denied the request: %s", e.Webhook, e.Message)
+}
+
+// ErrWebhookFailed is returned when a webhook whose failure policy is closed can't be reached,
+// times out or answers with something other than a valid response
+var ErrWebhookFailed = errors.New("admission webhook failed")
+
+// FailedError is the ErrWebhookFailed of a webhook. Err may include the webhook's URL and
+// response, which are for the operator rather than the caller.
+type FailedError struct {
+	Webhook string
+	Err     error
+}
+
+func (e *FailedError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrWebhookFailed, e.Webhook, e.Err)
+}
+
+func (e *FailedError) Unwrap() []error {
+	return []error{ErrWebhookFailed, e.Err}
+}
+
+// reviews counts webhook calls. It is created from the global meter provider, which forwards to
+// the registry's provider once metrics are initialized.
+var reviews, _ = otel.Meter(telemetry.TracerName).Int64Counter(
+	telemetry.Namespace+".admission.reviews",
+	metric.WithDescription("Total number of admission webhook reviews by webhook, operation and outcome"),
+)
+
+// Controller sends requests to the configured admission webhooks
+type Controller struct {
+	webhooks []config.AdmissionWebhook
+	client   *http.Client
+}
+
+// NewController creates a controller for the configured webhooks
+func NewController(cfg *config.Config) *Controller {
+	opts := httpclient.FromConfig(cfg, "admission_webhook")
+	// Webhooks are configured by the operator, and usually run next to the registry
+	opts.AllowPrivateNetworks = true
+	// Reviews are bounded by each webhook's own timeout
+	for _, webhook := range cfg.AdmissionWebhooks {
+		opts.Timeout = max(opts.Timeout, webhook.Timeout)
+	}
+	return &Controller{
+		webhooks: cfg.AdmissionWebhooks,
+		client:   httpclient.New(opts),
+	}
+}
+
+// Admit has every webhook that reviews operation review req in turn, each seeing the changes
+// of the ones before it. serverID is the ID of the server version being updated, if any.
+func (c *Controller) Admit(ctx context.Context, operation Operation, serverID string, req model.PublishRequest, caller *Caller) (*Result, error) {
+	result := &Result{Request: req}
+	for _, webhook := range c.webhooks {
+		if !webhook.Reviews(string(operation)) {
+			continue
+		}
+
+		review := Review{
+			UID:       newUID(),
+			Operation: operation,
+			ServerID:  serverID,
+			Request:   result.Request,
+			Caller:    caller,
+		}
+		response, err := c.call(ctx, webhook, review)
+		if err != nil {
+			if webhook.FailurePolicy == config.AdmissionFailOpen {
+				recordReview(ctx, webhook, operation, "failed_open")
+				slog.WarnContext(ctx, "admission webhook failed, allowing request", "webhook", webhook.Name, "error", err)
+				continue
+			}
+			recordReview(ctx, webhook, operation, "failed_closed")
+			return nil, &FailedError{Webhook: webhook.Name, Err: err}
+		}
+
+		for _, warning := range response.Warnings {
+			result.Warnings = append(result.Warnings, webhook.Name+": "+warning)
+		}
+		if !response.Allowed {
+			recordReview(ctx, webhook, operation, "denied")
+			return nil, &DeniedError{Webhook: webhook.Name, Message: response.Message, Warnings: result.Warnings}
+		}
+
+		outcome := "allowed"
+		if response.Request != nil {
+			// Callers are authorized to publish the name they sent, not whatever a webhook answers
+			if response.Request.Server.Name != result.Request.Server.Name {
+				recordReview(ctx, webhook, operation, "failed_closed")
+				return nil, &FailedError{Webhook: webhook.Name, Err: fmt.Errorf("changed the server name from %s to %s", result.Request.Server.Name, response.Request.Server.Name)}
+			}
+			result.Request = *response.Request
+			outcome = "mutated"
+		}
+		recordReview(ctx, webhook, operation, outcome)
+	}
+	return result, nil
+}
+
+// call sends a review to a webhook and returns its response
+func (c *Controller) call(ctx context.Context, webhook config.AdmissionWebhook, review Review) (_ *Response, err error) {
+	ctx, cancel := context.WithTimeout(ctx, webhook.Timeout)
+	defer cancel()
+
+	ctx, span := telemetry.StartSpan(ctx, "Admission webhook",
+		attribute.String("admission.webhook", webhook.Name),
+		attribute.String("admission.operation", string(review.Operation)),
+	)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	body, err := json.Marshal(review)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode review: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("returned status %d: %s", resp.StatusCode, body)
+	}
+
+	var response Response
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	if response.UID != review.UID {
+		return nil, fmt.Errorf("response is for review %q, not %q", response.UID, review.UID)
+	}
+	return &response, nil
+}
+
+// recordReview counts a webhook call by its outcome
+func recordReview(ctx context.Context, webhook config.AdmissionWebhook, operation Operation, outcome string) {
+	reviews.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("webhook", webhook.Name),
+		attribute.String("operation", string(operation)),
+		attribute.String("outcome", outcome),
+	))
+}
+
+// newUID returns a random review ID
+func newUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,172 @@
+package admission_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/admission"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// webhookServer answers reviews with respond
+func webhookServer(t *testing.T, respond func(admission.Review) admission.Response) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var review admission.Review
+		if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		response := respond(review)
+		if response.UID == "" {
+			response.UID = review.UID
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newController(t *testing.T, webhooks string) *admission.Controller {
+	t.Helper()
+	cfg := &config.Config{}
+	require.NoError(t, cfg.AdmissionWebhooks.UnmarshalText([]byte(webhooks)))
+	return admission.NewController(cfg)
+}
+
+func publishRequest() model.PublishRequest {
+	return model.PublishRequest{Server: model.ServerDetail{
+		Name:          "io.github.example/server",
+		Description:   "An example server",
+		VersionDetail: model.VersionDetail{Version: "1.0.0"},
+	}}
+}
+
+func TestAdmit(t *testing.T) {
+	caller := &admission.Caller{AuthMethod: model.AuthMethodGitHubAT, Subject: "example"}
+
+	t.Run("without webhooks requests are allowed unchanged", func(t *testing.T) {
+		result, err := admission.NewController(&config.Config{}).Admit(context.Background(), admission.OperationPublish, "", publishRequest(), caller)
+		require.NoError(t, err)
+		assert.Equal(t, publishRequest(), result.Request)
+		assert.Empty(t, result.Warnings)
+	})
+
+	t.Run("webhooks are called in order and see earlier changes", func(t *testing.T) {
+		var seen []string
+		mutate := webhookServer(t, func(review admission.Review) admission.Response {
+			seen = append(seen, "mutate:"+review.Request.Server.Description)
+			request := review.Request
+			request.Server.Description = "Reviewed"
+			return admission.Response{Allowed: true, Request: &request, Warnings: []string{"description rewritten"}}
+		})
+		validate := webhookServer(t, func(review admission.Review) admission.Response {
+			seen = append(seen, "validate:"+review.Request.Server.Description)
+			assert.Equal(t, caller, review.Caller)
+			assert.Equal(t, admission.OperationPublish, review.Operation)
+			return admission.Response{Allowed: true, Warnings: []string{"no license"}}
+		})
+		controller := newController(t, `[
+			{"name": "mutate", "url": "`+mutate.URL+`"},
+			{"name": "validate", "url": "`+validate.URL+`"}
+		]`)
+
+		result, err := controller.Admit(context.Background(), admission.OperationPublish, "", publishRequest(), caller)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"mutate:An example server", "validate:Reviewed"}, seen)
+		assert.Equal(t, "Reviewed", result.Request.Server.Description)
+		assert.Equal(t, []string{"mutate: description rewritten", "validate: no license"}, result.Warnings)
+	})
+
+	t.Run("denials stop the request", func(t *testing.T) {
+		calledAfter := false
+		deny := webhookServer(t, func(admission.Review) admission.Response {
+			return admission.Response{Allowed: false, Message: "license is not allowed", Warnings: []string{"license GPL-3.0"}}
+		})
+		after := webhookServer(t, func(admission.Review) admission.Response {
+			calledAfter = true
+			return admission.Response{Allowed: true}
+		})
+		controller := newController(t, `[
+			{"name": "license", "url": "`+deny.URL+`"},
+			{"name": "after", "url": "`+after.URL+`"}
+		]`)
+
+		_, err := controller.Admit(context.Background(), admission.OperationPublish, "", publishRequest(), nil)
+		var denied *admission.DeniedError
+		require.ErrorAs(t, err, &denied)
+		assert.Equal(t, "license", denied.Webhook)
+		assert.Equal(t, []string{"license: license GPL-3.0"}, denied.Warnings)
+		assert.Contains(t, err.Error(), "license is not allowed")
+		assert.False(t, calledAfter)
+	})
+
+	t.Run("webhooks can't rename servers", func(t *testing.T) {
+		rename := webhookServer(t, func(review admission.Review) admission.Response {
+			request := review.Request
+			request.Server.Name = "io.github.other/server"
+			return admission.Response{Allowed: true, Request: &request}
+		})
+		controller := newController(t, `[{"name": "rename", "url": "`+rename.URL+`", "failure_policy": "open"}]`)
+
+		_, err := controller.Admit(context.Background(), admission.OperationPublish, "", publishRequest(), nil)
+		require.ErrorIs(t, err, admission.ErrWebhookFailed)
+		var failed *admission.FailedError
+		require.ErrorAs(t, err, &failed)
+		assert.Equal(t, "rename", failed.Webhook)
+	})
+
+	t.Run("webhooks only review their operations", func(t *testing.T) {
+		called := false
+		publishOnly := webhookServer(t, func(admission.Review) admission.Response {
+			called = true
+			return admission.Response{Allowed: false}
+		})
+		controller := newController(t, `[{"name": "publish-only", "url": "`+publishOnly.URL+`", "operations": ["publish"]}]`)
+
+		_, err := controller.Admit(context.Background(), admission.OperationUpdate, "id", publishRequest(), nil)
+		require.NoError(t, err)
+		assert.False(t, called)
+	})
+
+	t.Run("failure policies", func(t *testing.T) {
+		slow := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}))
+		defer slow.Close()
+		broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer broken.Close()
+		wrongUID := webhookServer(t, func(admission.Review) admission.Response {
+			return admission.Response{UID: "other", Allowed: true}
+		})
+
+		for name, url := range map[string]string{"timeout": slow.URL, "error status": broken.URL, "wrong uid": wrongUID.URL} {
+			t.Run(name, func(t *testing.T) {
+				closed := newController(t, `[{"name": "check", "url": "`+url+`", "timeout": "50ms"}]`)
+				_, err := closed.Admit(context.Background(), admission.OperationPublish, "", publishRequest(), nil)
+				require.ErrorIs(t, err, admission.ErrWebhookFailed)
+				var denied *admission.DeniedError
+				assert.False(t, errors.As(err, &denied))
+
+				open := newController(t, `[{"name": "check", "url": "`+url+`", "timeout": "50ms", "failure_policy": "open"}]`)
+				result, err := open.Admit(context.Background(), admission.OperationPublish, "", publishRequest(), nil)
+				require.NoError(t, err)
+				assert.Equal(t, publishRequest(), result.Request)
+			})
+		}
+	})
+}
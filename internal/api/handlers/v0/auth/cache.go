@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+const (
+	// identityCacheMaxEntries bounds the identity cache, which holds an entry per GitHub token
+	identityCacheMaxEntries = 10000
+	// jwksMinRefreshInterval is how long after fetching a JWKS an unknown kid is rejected
+	// without fetching it again, so tokens with made-up kids can't hammer GitHub
+	jwksMinRefreshInterval = 10 * time.Second
+)
+
+// cacheLookups counts cache hits and misses. It is created from the global meter provider,
+// which forwards to the registry's provider once metrics are initialized.
+var cacheLookups, _ = otel.Meter(telemetry.TracerName).Int64Counter(
+	telemetry.Namespace+".auth.cache.lookups",
+	metric.WithDescription("Total number of authentication cache lookups by cache and outcome"),
+)
+
+// recordCacheLookup counts a lookup in cache as a hit or a miss
+func recordCacheLookup(ctx context.Context, cache string, hit bool) {
+	outcome := "miss"
+	if hit {
+		outcome = "hit"
+	}
+	cacheLookups.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("cache", cache),
+		attribute.String("outcome", outcome),
+	))
+}
+
+// gitHubIdentity is who a GitHub token belongs to
+type gitHubIdentity struct {
+	user *GitHubUserOrOrg
+	orgs []GitHubUserOrOrg
+}
+
+// identityCache remembers the identity of GitHub tokens for a while, keyed by a hash of the
+// host and token so tokens aren't kept in memory. A disabled cache has a zero ttl.
+type identityCache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]identityEntry
+}
+
+type identityEntry struct {
+	identity  gitHubIdentity
+	expiresAt time.Time
+}
+
+func newIdentityCache(ttl time.Duration) *identityCache {
+	return &identityCache{ttl: ttl, now: time.Now, entries: make(map[string]identityEntry)}
+}
+
+// identityCacheKey identifies a token of the GitHub instance at host
+func identityCacheKey(host, token string) string {
+	sum := sha256.Sum256([]byte(host + "\x00" + token))
+	return hex.EncodeToString(sum[:])
+}
+
+// get returns the cached identity of a token, if it hasn't expired
+func (c *identityCache) get(ctx context.Context, key string) (gitHubIdentity, bool) {
+	if c.ttl <= 0 {
+		return gitHubIdentity{}, false
+	}
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	hit := ok && c.now().Before(entry.expiresAt)
+	recordCacheLookup(ctx, "github_identity", hit)
+	return entry.identity, hit
+}
+
+// put caches the identity of a token, unless the cache is full of unexpired entries
+func (c *identityCache) put(key string, identity gitHubIdentity) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.entries) >= identityCacheMaxEntries {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= identityCacheMaxEntries {
+			return
+		}
+	}
+	c.entries[key] = identityEntry{identity: identity, expiresAt: now.Add(c.ttl)}
+}
+
+// jwksCache keeps the signing keys of each JWKS URL until their Cache-Control max-age, or the
+// default ttl, runs out. A kid that isn't cached fetches the keys again, since GitHub may
+// have rotated them, but at most once per jwksMinRefreshInterval.
+type jwksCache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]*jwksEntry // by JWKS URL
+}
+
+type jwksEntry struct {
+	// mu is held while fetching, so concurrent lookups wait for one fetch
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+	expiresAt time.Time
+}
+
+// jwksFetcher fetches the keys at a JWKS URL by kid, with the response's headers
+type jwksFetcher func(ctx context.Context, jwksURL string) (map[string]*rsa.PublicKey, http.Header, error)
+
+func newJWKSCache(ttl time.Duration) *jwksCache {
+	return &jwksCache{ttl: ttl, now: time.Now, entries: make(map[string]*jwksEntry)}
+}
+
+// key returns the public key with kid at jwksURL, fetching the keys if they aren't cached,
+// have expired, or don't include kid
+func (c *jwksCache) key(ctx context.Context, jwksURL, kid string, fetch jwksFetcher) (*rsa.PublicKey, bool, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[jwksURL]
+	if !ok {
+		entry = &jwksEntry{}
+		c.entries[jwksURL] = entry
+	}
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	now := c.now()
+	key, known := entry.keys[kid]
+	fresh := now.Before(entry.expiresAt)
+	recordCacheLookup(ctx, "github_jwks", known && fresh)
+	if known && fresh {
+		return key, true, nil
+	}
+	// Unknown kids only trigger a fetch if the keys weren't just fetched
+	if fresh && now.Sub(entry.fetchedAt) < jwksMinRefreshInterval {
+		return nil, false, nil
+	}
+
+	keys, header, err := fetch(ctx, jwksURL)
+	if err != nil {
+		return nil, false, err
+	}
+	entry.keys = keys
+	entry.fetchedAt = now
+	entry.expiresAt = now.Add(cacheLifetime(header, c.ttl))
+
+	key, known = keys[kid]
+	return key, known, nil
+}
+
+// cacheLifetime returns how long a response may be cached according to its Cache-Control
+// header, or fallback without one
+func cacheLifetime(header http.Header, fallback time.Duration) time.Duration {
+	cacheControl := header.Get("Cache-Control")
+	if cacheControl == "" {
+		return fallback
+	}
+	lifetime := fallback
+	for _, directive := range strings.Split(cacheControl, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-store", "no-cache":
+			return 0
+		case "max-age":
+			if seconds, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil && seconds >= 0 {
+				lifetime = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return lifetime
+}
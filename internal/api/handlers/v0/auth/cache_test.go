@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityCache(t *testing.T) {
+	now := time.Now()
+	cache := newIdentityCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	key := identityCacheKey("github.com", "token")
+	assert.NotContains(t, key, "token")
+	assert.NotEqual(t, key, identityCacheKey("github.example.com", "token"))
+
+	_, ok := cache.get(context.Background(), key)
+	assert.False(t, ok)
+
+	identity := gitHubIdentity{user: &GitHubUserOrOrg{Login: "octocat"}}
+	cache.put(key, identity)
+	got, ok := cache.get(context.Background(), key)
+	require.True(t, ok)
+	assert.Equal(t, identity, got)
+
+	now = now.Add(time.Minute)
+	_, ok = cache.get(context.Background(), key)
+	assert.False(t, ok)
+
+	// A zero TTL disables the cache
+	disabled := newIdentityCache(0)
+	disabled.put(key, identity)
+	_, ok = disabled.get(context.Background(), key)
+	assert.False(t, ok)
+}
+
+func TestJWKSCache(t *testing.T) {
+	now := time.Now()
+	cache := newJWKSCache(time.Hour)
+	cache.now = func() time.Time { return now }
+
+	keys := map[string]*rsa.PublicKey{"k1": {E: 1}}
+	header := http.Header{"Cache-Control": {"public, max-age=300"}}
+	var fetchErr error
+	fetches := 0
+	fetch := func(_ context.Context, _ string) (map[string]*rsa.PublicKey, http.Header, error) {
+		fetches++
+		return keys, header, fetchErr
+	}
+	lookup := func(kid string) (*rsa.PublicKey, bool, error) {
+		return cache.key(context.Background(), "https://example.com/jwks", kid, fetch)
+	}
+
+	key, ok, err := lookup("k1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Same(t, keys["k1"], key)
+	_, ok, _ = lookup("k1")
+	assert.True(t, ok)
+	assert.Equal(t, 1, fetches)
+
+	// GitHub rotates its keys: an unknown kid right after a fetch isn't fetched again...
+	keys = map[string]*rsa.PublicKey{"k1": {E: 1}, "k2": {E: 2}}
+	_, ok, err = lookup("k2")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 1, fetches)
+
+	// ...but is a little later, before the cached keys expire
+	now = now.Add(jwksMinRefreshInterval)
+	key, ok, err = lookup("k2")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Same(t, keys["k2"], key)
+	assert.Equal(t, 2, fetches)
+
+	// Expired keys are fetched again, and fetch errors aren't cached
+	now = now.Add(5 * time.Minute)
+	fetchErr = errors.New("unavailable")
+	_, _, err = lookup("k1")
+	require.Error(t, err)
+	fetchErr = nil
+	_, ok, err = lookup("k1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 4, fetches)
+}
+
+func TestCacheLifetime(t *testing.T) {
+	tests := []struct {
+		cacheControl string
+		expected     time.Duration
+	}{
+		{"", time.Hour},
+		{"public, max-age=300", 5 * time.Minute},
+		{`max-age="60"`, time.Minute},
+		{"max-age=0", 0},
+		{"max-age=300, no-cache", 0},
+		{"no-store", 0},
+		{"max-age=soon", time.Hour},
+		{"private", time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cacheControl, func(t *testing.T) {
+			header := http.Header{}
+			if tt.cacheControl != "" {
+				header.Set("Cache-Control", tt.cacheControl)
+			}
+			assert.Equal(t, tt.expected, cacheLifetime(header, time.Hour))
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+const (
+	// defaultChallengeTTL is used when no challenge TTL is configured
+	defaultChallengeTTL = 2 * time.Minute
+	nonceRandomSize     = 16
+	nonceExpirySize     = 8
+	nonceMACSize        = 16
+)
+
+// ChallengeInput represents the input for requesting an authentication challenge
+type ChallengeInput struct {
+	Body struct {
+		Domain string `json:"domain" doc:"Domain name the challenge is for" example:"example.com" required:"true"`
+	}
+}
+
+// ChallengeResponse is a nonce to sign for DNS or HTTP authentication
+type ChallengeResponse struct {
+	Nonce     string    `json:"nonce" doc:"Nonce to sign, together with the domain, as <nonce>:<domain>"`
+	ExpiresAt time.Time `json:"expires_at" doc:"When the nonce stops being accepted"`
+}
+
+// NonceStore records used challenge nonces, so each one is accepted once across all replicas
+type NonceStore interface {
+	ConsumeAuthNonce(ctx context.Context, nonce string, expiresAt time.Time) (bool, error)
+}
+
+// Challenges issues and checks the nonces signed for DNS and HTTP authentication. Nonces are
+// stateless until used: each one carries its expiry and domain binding under an HMAC, so any
+// replica can check a nonce issued by another, and only used nonces are stored.
+type Challenges struct {
+	key   []byte
+	ttl   time.Duration
+	store NonceStore
+	now   func() time.Time
+}
+
+// NewChallenges creates a challenge issuer keyed from the registry's JWT signing key
+func NewChallenges(cfg *config.Config, store NonceStore) *Challenges {
+	key := sha256.Sum256([]byte("mcp-registry auth challenge:" + cfg.JWTPrivateKey))
+	ttl := cfg.AuthChallengeTTL
+	if ttl <= 0 {
+		ttl = defaultChallengeTTL
+	}
+	return &Challenges{
+		key:   key[:],
+		ttl:   ttl,
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// SetClock sets the clock used for issuing and expiring nonces (used for testing)
+func (c *Challenges) SetClock(now func() time.Time) {
+	c.now = now
+}
+
+// RegisterChallengeEndpoint registers the endpoint that issues DNS and HTTP authentication challenges
+func RegisterChallengeEndpoint(api huma.API, challenges *Challenges) {
+	huma.Register(api, huma.Operation{
+		OperationID: "create-auth-challenge",
+		Method:      http.MethodPost,
+		Path:        "/v0/auth/challenge",
+		Summary:     "Request a DNS or HTTP authentication challenge",
+		Description: "Issue a short-lived nonce bound to the domain. Sign <nonce>:<domain> with the domain's key and send it to /v0/auth/dns or /v0/auth/http.",
+		Tags:        []string{"auth"},
+	}, func(_ context.Context, input *ChallengeInput) (*v0.Response[ChallengeResponse], error) {
+		if !isValidDomain(input.Body.Domain) {
+			return nil, huma.Error400BadRequest("invalid domain format")
+		}
+
+		response, err := challenges.Issue(input.Body.Domain)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to issue challenge", err)
+		}
+
+		return &v0.Response[ChallengeResponse]{
+			Body: *response,
+		}, nil
+	})
+}
+
+// Issue creates a nonce for domain that expires after the challenge TTL
+func (c *Challenges) Issue(domain string) (*ChallengeResponse, error) {
+	expiresAt := c.now().Add(c.ttl).Truncate(time.Second)
+
+	payload := make([]byte, nonceRandomSize+nonceExpirySize, nonceRandomSize+nonceExpirySize+nonceMACSize)
+	if _, err := rand.Read(payload[:nonceRandomSize]); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	binary.BigEndian.PutUint64(payload[nonceRandomSize:], uint64(expiresAt.Unix())) // #nosec G115 -- expiry is always after 1970
+
+	payload = append(payload, c.mac(domain, payload)...)
+	return &ChallengeResponse{
+		Nonce:     base64.RawURLEncoding.EncodeToString(payload),
+		ExpiresAt: expiresAt.UTC(),
+	}, nil
+}
+
+// Verify checks that nonce was issued by this registry for domain and hasn't expired, and
+// returns when it expires. It doesn't mark the nonce used; see Consume.
+func (c *Challenges) Verify(domain, nonce string) (time.Time, error) {
+	data, err := base64.RawURLEncoding.DecodeString(nonce)
+	if err != nil || len(data) != nonceRandomSize+nonceExpirySize+nonceMACSize {
+		return time.Time{}, withReason(ReasonInvalidNonce, errors.New("invalid nonce format"))
+	}
+
+	payload, mac := data[:nonceRandomSize+nonceExpirySize], data[nonceRandomSize+nonceExpirySize:]
+	if !hmac.Equal(mac, c.mac(domain, payload)) {
+		return time.Time{}, withReason(ReasonInvalidNonce, errors.New("nonce was not issued for this domain"))
+	}
+
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[nonceRandomSize:])), 0) // #nosec G115 -- authenticated by the MAC
+	if !c.now().Before(expiresAt) {
+		return time.Time{}, withReason(ReasonInvalidNonce, errors.New("nonce has expired, request a new challenge"))
+	}
+	return expiresAt, nil
+}
+
+// Consume marks a verified nonce used, failing if it was used before
+func (c *Challenges) Consume(ctx context.Context, nonce string, expiresAt time.Time) error {
+	fresh, err := c.store.ConsumeAuthNonce(ctx, nonce, expiresAt)
+	if err != nil {
+		return withReason(ReasonUpstreamError, fmt.Errorf("failed to record nonce: %w", err))
+	}
+	if !fresh {
+		return withReason(ReasonReplayedNonce, errors.New("nonce has already been used"))
+	}
+	return nil
+}
+
+// mac authenticates a nonce payload and the domain it is bound to
+func (c *Challenges) mac(domain string, payload []byte) []byte {
+	h := hmac.New(sha256.New, c.key)
+	h.Write(payload)
+	h.Write([]byte(strings.ToLower(domain)))
+	return h.Sum(nil)[:nonceMACSize]
+}
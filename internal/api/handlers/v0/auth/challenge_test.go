@@ -0,0 +1,171 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth/keyalg"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// fakeNonceStore records used nonces in memory
+type fakeNonceStore struct {
+	mu   sync.Mutex
+	used map[string]time.Time
+}
+
+func newFakeNonceStore() *fakeNonceStore {
+	return &fakeNonceStore{used: map[string]time.Time{}}
+}
+
+func (s *fakeNonceStore) ConsumeAuthNonce(_ context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.used[nonce]; ok {
+		return false, nil
+	}
+	s.used[nonce] = expiresAt
+	return true, nil
+}
+
+func TestChallenges(t *testing.T) {
+	cfg := &config.Config{
+		JWTPrivateKey:    "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		AuthChallengeTTL: time.Minute,
+	}
+	challenges := auth.NewChallenges(cfg, newFakeNonceStore())
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	challenges.SetClock(func() time.Time { return now })
+
+	challenge, err := challenges.Issue("example.com")
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Minute), challenge.ExpiresAt)
+
+	expiresAt, err := challenges.Verify("example.com", challenge.Nonce)
+	require.NoError(t, err)
+	assert.True(t, expiresAt.Equal(challenge.ExpiresAt))
+
+	// Domains are case-insensitive
+	_, err = challenges.Verify("EXAMPLE.com", challenge.Nonce)
+	assert.NoError(t, err)
+
+	// The nonce is bound to its domain
+	_, err = challenges.Verify("other.com", challenge.Nonce)
+	assert.ErrorContains(t, err, "not issued for this domain")
+	assert.Equal(t, auth.ReasonInvalidNonce, auth.FailureReason(err))
+
+	// Tampering with the nonce, such as extending its expiry, breaks the MAC
+	data, err := base64.RawURLEncoding.DecodeString(challenge.Nonce)
+	require.NoError(t, err)
+	data[20]++
+	_, err = challenges.Verify("example.com", base64.RawURLEncoding.EncodeToString(data))
+	assert.ErrorContains(t, err, "not issued for this domain")
+
+	_, err = challenges.Verify("example.com", "not-a-nonce")
+	assert.ErrorContains(t, err, "invalid nonce format")
+
+	// Nonces from a registry with a different key are rejected
+	otherChallenges := auth.NewChallenges(&config.Config{JWTPrivateKey: "ff" + cfg.JWTPrivateKey[2:]}, newFakeNonceStore())
+	_, err = otherChallenges.Verify("example.com", challenge.Nonce)
+	assert.ErrorContains(t, err, "not issued for this domain")
+
+	// Nonces expire after the TTL
+	now = now.Add(time.Minute)
+	_, err = challenges.Verify("example.com", challenge.Nonce)
+	assert.ErrorContains(t, err, "nonce has expired")
+	assert.Equal(t, auth.ReasonInvalidNonce, auth.FailureReason(err))
+}
+
+func TestChallenges_Consume(t *testing.T) {
+	cfg := &config.Config{
+		JWTPrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	}
+	challenges := auth.NewChallenges(cfg, newFakeNonceStore())
+
+	challenge, err := challenges.Issue("example.com")
+	require.NoError(t, err)
+
+	require.NoError(t, challenges.Consume(context.Background(), challenge.Nonce, challenge.ExpiresAt))
+	err = challenges.Consume(context.Background(), challenge.Nonce, challenge.ExpiresAt)
+	assert.ErrorContains(t, err, "nonce has already been used")
+	assert.Equal(t, auth.ReasonReplayedNonce, auth.FailureReason(err))
+}
+
+func TestDNSAuthHandler_ExchangeChallenge(t *testing.T) {
+	cfg := &config.Config{
+		JWTPrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	}
+	challenges := auth.NewChallenges(cfg, newFakeNonceStore())
+	handler := auth.NewDNSAuthHandler(cfg, challenges)
+	signer := testSigners(t)["ecdsap256"]
+	record, err := keyalg.FormatRecord(signer.Public())
+	require.NoError(t, err)
+	handler.SetResolver(&MockDNSResolver{txtRecords: map[string][]string{"example.com": {record}}})
+
+	sign := func(domain, nonce string) string {
+		signature, err := keyalg.Sign(signer, keyalg.ChallengeMessage(domain, nonce))
+		require.NoError(t, err)
+		return hex.EncodeToString(signature)
+	}
+
+	challenge, err := challenges.Issue("example.com")
+	require.NoError(t, err)
+
+	// A signature over another domain doesn't answer the challenge
+	_, err = handler.ExchangeChallenge(context.Background(), "example.com", challenge.Nonce, sign("other.com", challenge.Nonce))
+	assert.Equal(t, auth.ReasonInvalidSignature, auth.FailureReason(err))
+
+	// A failed attempt doesn't use up the nonce
+	result, err := handler.ExchangeChallenge(context.Background(), "example.com", challenge.Nonce, sign("example.com", challenge.Nonce))
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.RegistryToken)
+
+	// The same signed nonce can't be replayed
+	_, err = handler.ExchangeChallenge(context.Background(), "example.com", challenge.Nonce, sign("example.com", challenge.Nonce))
+	assert.ErrorContains(t, err, "nonce has already been used")
+	assert.Equal(t, auth.ReasonReplayedNonce, auth.FailureReason(err))
+
+	// Timestamp signatures are rejected unless explicitly allowed
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+	signature, err := keyalg.Sign(signer, []byte(timestamp))
+	require.NoError(t, err)
+	_, err = handler.ExchangeToken(context.Background(), "example.com", timestamp, hex.EncodeToString(signature))
+	assert.ErrorContains(t, err, "/v0/auth/challenge")
+	assert.Equal(t, auth.ReasonInvalidRequest, auth.FailureReason(err))
+}
+
+func TestHTTPAuthHandler_ExchangeChallenge(t *testing.T) {
+	cfg := &config.Config{
+		JWTPrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	}
+	challenges := auth.NewChallenges(cfg, newFakeNonceStore())
+	handler := auth.NewHTTPAuthHandler(cfg, challenges)
+	signer := testSigners(t)["ed25519"]
+	record, err := keyalg.FormatRecord(signer.Public())
+	require.NoError(t, err)
+	handler.SetFetcher(&MockHTTPKeyFetcher{keyResponses: map[string]string{"example.com": record, "other.com": record}})
+
+	challenge, err := challenges.Issue("example.com")
+	require.NoError(t, err)
+	signature, err := keyalg.Sign(signer, keyalg.ChallengeMessage("example.com", challenge.Nonce))
+	require.NoError(t, err)
+
+	// A nonce issued for one domain can't be used for another with the same key
+	_, err = handler.ExchangeChallenge(context.Background(), "other.com", challenge.Nonce, hex.EncodeToString(signature))
+	assert.Equal(t, auth.ReasonInvalidNonce, auth.FailureReason(err))
+
+	result, err := handler.ExchangeChallenge(context.Background(), "example.com", challenge.Nonce, hex.EncodeToString(signature))
+	require.NoError(t, err)
+	assert.NotEmpty(t, result.RegistryToken)
+
+	_, err = handler.ExchangeChallenge(context.Background(), "example.com", challenge.Nonce, hex.EncodeToString(signature))
+	assert.Equal(t, auth.ReasonReplayedNonce, auth.FailureReason(err))
+}
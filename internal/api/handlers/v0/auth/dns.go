@@ -2,9 +2,8 @@ package auth
 
 import (
 	"context"
-	"crypto/ed25519"
-	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -15,16 +14,21 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth/keyalg"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // DNSTokenExchangeInput represents the input for DNS-based authentication
 type DNSTokenExchangeInput struct {
 	Body struct {
 		Domain          string `json:"domain" doc:"Domain name" example:"example.com" required:"true"`
-		Timestamp       string `json:"timestamp" doc:"RFC3339 timestamp" example:"2023-01-01T00:00:00Z" required:"true"`
-		SignedTimestamp string `json:"signed_timestamp" doc:"Hex-encoded Ed25519 signature of timestamp" example:"abcdef1234567890" required:"true"`
+		Nonce           string `json:"nonce,omitempty" doc:"Nonce from /v0/auth/challenge" required:"false"`
+		Signature       string `json:"signature,omitempty" doc:"Hex-encoded signature of <nonce>:<domain>, using the algorithm of the domain's public key" example:"abcdef1234567890" required:"false"`
+		Timestamp       string `json:"timestamp,omitempty" doc:"RFC3339 timestamp (deprecated, use nonce)" example:"2023-01-01T00:00:00Z" required:"false"`
+		SignedTimestamp string `json:"signed_timestamp,omitempty" doc:"Hex-encoded signature of timestamp (deprecated, use signature)" example:"abcdef1234567890" required:"false"`
 	}
 }
 
@@ -46,14 +50,16 @@ type DNSAuthHandler struct {
 	config     *config.Config
 	jwtManager *auth.JWTManager
 	resolver   DNSResolver
+	challenges *Challenges
 }
 
 // NewDNSAuthHandler creates a new DNS authentication handler
-func NewDNSAuthHandler(cfg *config.Config) *DNSAuthHandler {
+func NewDNSAuthHandler(cfg *config.Config, challenges *Challenges) *DNSAuthHandler {
 	return &DNSAuthHandler{
 		config:     cfg,
 		jwtManager: auth.NewJWTManager(cfg),
 		resolver:   &DefaultDNSResolver{},
+		challenges: challenges,
 	}
 }
 
@@ -63,8 +69,8 @@ func (h *DNSAuthHandler) SetResolver(resolver DNSResolver) {
 }
 
 // RegisterDNSEndpoint registers the DNS authentication endpoint
-func RegisterDNSEndpoint(api huma.API, cfg *config.Config) {
-	handler := NewDNSAuthHandler(cfg)
+func RegisterDNSEndpoint(api huma.API, cfg *config.Config, metrics *telemetry.Metrics, challenges *Challenges) {
+	handler := NewDNSAuthHandler(cfg, challenges)
 
 	// DNS authentication endpoint
 	huma.Register(api, huma.Operation{
@@ -72,10 +78,17 @@ func RegisterDNSEndpoint(api huma.API, cfg *config.Config) {
 		Method:      http.MethodPost,
 		Path:        "/v0/auth/dns",
 		Summary:     "Exchange DNS signature for Registry JWT",
-		Description: "Authenticate using DNS TXT record public key and signed timestamp",
+		Description: "Authenticate using DNS TXT record public key and a signed challenge from /v0/auth/challenge",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, input *DNSTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
-		response, err := handler.ExchangeToken(ctx, input.Body.Domain, input.Body.Timestamp, input.Body.SignedTimestamp)
+		var response *auth.TokenResponse
+		var err error
+		if input.Body.Nonce != "" {
+			response, err = handler.ExchangeChallenge(ctx, input.Body.Domain, input.Body.Nonce, input.Body.Signature)
+		} else {
+			response, err = handler.ExchangeToken(ctx, input.Body.Domain, input.Body.Timestamp, input.Body.SignedTimestamp)
+		}
+		recordExchange(ctx, metrics, model.AuthMethodDNS, err)
 		if err != nil {
 			return nil, huma.Error401Unauthorized("DNS authentication failed", err)
 		}
@@ -86,62 +99,91 @@ func RegisterDNSEndpoint(api huma.API, cfg *config.Config) {
 	})
 }
 
-// ExchangeToken exchanges DNS signature for a Registry JWT token
-func (h *DNSAuthHandler) ExchangeToken(ctx context.Context, domain, timestamp, signedTimestamp string) (*auth.TokenResponse, error) {
+// ExchangeChallenge exchanges a DNS signature of a challenge nonce for a Registry JWT token
+func (h *DNSAuthHandler) ExchangeChallenge(ctx context.Context, domain, nonce, signedNonce string) (*auth.TokenResponse, error) {
 	// Validate domain format
 	if !isValidDomain(domain) {
-		return nil, fmt.Errorf("invalid domain format")
+		return nil, withReason(ReasonInvalidRequest, fmt.Errorf("invalid domain format"))
 	}
 
-	// Parse and validate timestamp
-	ts, err := time.Parse(time.RFC3339, timestamp)
+	// Check the nonce was issued for this domain and is still valid
+	expiresAt, err := h.challenges.Verify(domain, nonce)
 	if err != nil {
-		return nil, fmt.Errorf("invalid timestamp format: %w", err)
+		return nil, err
 	}
 
-	// Check timestamp is within 15 seconds
-	now := time.Now()
-	if ts.Before(now.Add(-15*time.Second)) || ts.After(now.Add(15*time.Second)) {
-		return nil, fmt.Errorf("timestamp outside valid window (±15 seconds)")
+	if err := h.verifySignature(ctx, domain, keyalg.ChallengeMessage(domain, nonce), signedNonce); err != nil {
+		return nil, err
 	}
 
-	// Decode signature
-	signature, err := hex.DecodeString(signedTimestamp)
-	if err != nil {
-		return nil, fmt.Errorf("invalid signature format, must be hex: %w", err)
+	// Only mark the nonce used once the signature is valid, so others can't burn it
+	if err := h.challenges.Consume(ctx, nonce, expiresAt); err != nil {
+		return nil, err
 	}
 
-	if len(signature) != ed25519.SignatureSize {
-		return nil, fmt.Errorf("invalid signature length: expected %d, got %d", ed25519.SignatureSize, len(signature))
+	return h.issueToken(ctx, domain)
+}
+
+// ExchangeToken exchanges a DNS signature of a timestamp for a Registry JWT token. Timestamp
+// signatures can be replayed within their window, so they are only accepted when
+// AuthAllowTimestampSignatures is set.
+func (h *DNSAuthHandler) ExchangeToken(ctx context.Context, domain, timestamp, signedTimestamp string) (*auth.TokenResponse, error) {
+	// Validate domain format
+	if !isValidDomain(domain) {
+		return nil, withReason(ReasonInvalidRequest, fmt.Errorf("invalid domain format"))
+	}
+
+	if err := checkTimestamp(h.config, timestamp); err != nil {
+		return nil, err
+	}
+
+	if err := h.verifySignature(ctx, domain, []byte(timestamp), signedTimestamp); err != nil {
+		return nil, err
+	}
+
+	return h.issueToken(ctx, domain)
+}
+
+// verifySignature checks that signedMessage is a signature of message by one of the keys in
+// the domain's DNS TXT records
+func (h *DNSAuthHandler) verifySignature(ctx context.Context, domain string, message []byte, signedMessage string) error {
+	// Decode signature
+	signature, err := hex.DecodeString(signedMessage)
+	if err != nil {
+		return withReason(ReasonInvalidRequest, fmt.Errorf("invalid signature format, must be hex: %w", err))
 	}
 
 	// Lookup DNS TXT records
-	txtRecords, err := h.resolver.LookupTXT(ctx, domain)
+	lookupCtx, span := telemetry.StartSpan(ctx, "DNS TXT lookup", attribute.String("dns.domain", domain))
+	txtRecords, err := h.resolver.LookupTXT(lookupCtx, domain)
+	telemetry.EndSpan(span, err)
 	if err != nil {
-		return nil, fmt.Errorf("failed to lookup DNS TXT records: %w", err)
+		return withReason(ReasonUpstreamError, fmt.Errorf("failed to lookup DNS TXT records: %w", err))
 	}
 
 	// Parse public keys from TXT records
 	publicKeys := h.parsePublicKeysFromTXT(txtRecords)
 
 	if len(publicKeys) == 0 {
-		return nil, fmt.Errorf("no valid MCP public keys found in DNS TXT records")
+		return withReason(ReasonKeyNotFound, fmt.Errorf("no valid MCP public keys found in DNS TXT records"))
 	}
 
 	// Verify signature with any of the public keys
-	messageBytes := []byte(timestamp)
-	signatureValid := false
+	var verifyErr error
 	for _, publicKey := range publicKeys {
-		if ed25519.Verify(publicKey, messageBytes, signature) {
-			signatureValid = true
+		if verifyErr = publicKey.Verify(message, signature); verifyErr == nil {
 			break
 		}
 	}
 
-	if !signatureValid {
-		return nil, fmt.Errorf("signature verification failed")
+	if verifyErr != nil {
+		return withReason(ReasonInvalidSignature, verificationError(verifyErr))
 	}
+	return nil
+}
 
+// issueToken issues a Registry JWT token for a verified domain
+func (h *DNSAuthHandler) issueToken(ctx context.Context, domain string) (*auth.TokenResponse, error) {
 	// Build permissions for domain and subdomains
 	permissions := h.buildPermissions(domain)
 
@@ -155,35 +197,45 @@ func (h *DNSAuthHandler) ExchangeToken(ctx context.Context, domain, timestamp, s
 	// Generate Registry JWT token
 	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, jwtClaims)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
+		return nil, withReason(ReasonTokenGeneration, fmt.Errorf("failed to generate JWT token: %w", err))
 	}
 
 	return tokenResponse, nil
 }
 
-// parsePublicKeysFromTXT parses Ed25519 public keys from DNS TXT records
-func (h *DNSAuthHandler) parsePublicKeysFromTXT(txtRecords []string) []ed25519.PublicKey {
-	var publicKeys []ed25519.PublicKey
-	mcpPattern := regexp.MustCompile(`v=MCPv1;\s*k=ed25519;\s*p=([A-Za-z0-9+/=]+)`)
+// parsePublicKeysFromTXT parses MCP public keys from DNS TXT records, skipping other records
+// and keys with unsupported algorithms
+func (h *DNSAuthHandler) parsePublicKeysFromTXT(txtRecords []string) []keyalg.PublicKey {
+	return keyalg.ParseRecords(txtRecords)
+}
 
-	for _, record := range txtRecords {
-		matches := mcpPattern.FindStringSubmatch(record)
-		if len(matches) == 2 {
-			// Decode base64 public key
-			publicKeyBytes, err := base64.StdEncoding.DecodeString(matches[1])
-			if err != nil {
-				continue // Skip invalid keys
-			}
+// checkTimestamp checks that timestamp signatures are allowed and timestamp is within 15
+// seconds of now
+func checkTimestamp(cfg *config.Config, timestamp string) error {
+	if !cfg.AuthAllowTimestampSignatures {
+		return withReason(ReasonInvalidRequest, fmt.Errorf("timestamp signatures are no longer accepted, sign a nonce from POST /v0/auth/challenge instead"))
+	}
 
-			if len(publicKeyBytes) != ed25519.PublicKeySize {
-				continue // Skip invalid key sizes
-			}
+	// Parse and validate timestamp
+	ts, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return withReason(ReasonInvalidRequest, fmt.Errorf("invalid timestamp format: %w", err))
+	}
 
-			publicKeys = append(publicKeys, ed25519.PublicKey(publicKeyBytes))
-		}
+	// Check timestamp is within 15 seconds
+	now := time.Now()
+	if ts.Before(now.Add(-15*time.Second)) || ts.After(now.Add(15*time.Second)) {
+		return withReason(ReasonExpiredTimestamp, fmt.Errorf("timestamp outside valid window (±15 seconds)"))
 	}
+	return nil
+}
 
-	return publicKeys
+// verificationError describes why no public key verified the signature
+func verificationError(err error) error {
+	if errors.Is(err, keyalg.ErrInvalidSignature) {
+		return keyalg.ErrInvalidSignature
+	}
+	return fmt.Errorf("signature verification failed: %w", err)
 }
 
 // buildPermissions builds permissions for a domain and its subdomains using reverse DNS notation
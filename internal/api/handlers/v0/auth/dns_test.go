@@ -2,7 +2,12 @@ package auth_test
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
@@ -15,6 +20,7 @@ import (
 
 	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
 	intauth "github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth/keyalg"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/model"
 )
@@ -34,9 +40,10 @@ func (m *MockDNSResolver) LookupTXT(_ context.Context, name string) ([]string, e
 
 func TestDNSAuthHandler_ExchangeToken(t *testing.T) {
 	cfg := &config.Config{
-		JWTPrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		JWTPrivateKey:                "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		AuthAllowTimestampSignatures: true,
 	}
-	handler := auth.NewDNSAuthHandler(cfg)
+	handler := auth.NewDNSAuthHandler(cfg, auth.NewChallenges(cfg, newFakeNonceStore()))
 
 	// Generate a test key pair
 	publicKey, privateKey, err := ed25519.GenerateKey(nil)
@@ -61,6 +68,7 @@ func TestDNSAuthHandler_ExchangeToken(t *testing.T) {
 		setupMock       func(*MockDNSResolver)
 		expectError     bool
 		errorContains   string
+		errorReason     string
 	}{
 		{
 			name:      "successful authentication",
@@ -94,6 +102,7 @@ func TestDNSAuthHandler_ExchangeToken(t *testing.T) {
 			timestamp:     time.Now().UTC().Format(time.RFC3339),
 			expectError:   true,
 			errorContains: "invalid domain format",
+			errorReason:   auth.ReasonInvalidRequest,
 		},
 		{
 			name:          "timestamp too old",
@@ -101,6 +110,7 @@ func TestDNSAuthHandler_ExchangeToken(t *testing.T) {
 			timestamp:     time.Now().Add(-30 * time.Second).UTC().Format(time.RFC3339),
 			expectError:   true,
 			errorContains: "timestamp outside valid window",
+			errorReason:   auth.ReasonExpiredTimestamp,
 		},
 		{
 			name:          "timestamp too far in the future",
@@ -108,6 +118,7 @@ func TestDNSAuthHandler_ExchangeToken(t *testing.T) {
 			timestamp:     time.Now().Add(30 * time.Second).UTC().Format(time.RFC3339),
 			expectError:   true,
 			errorContains: "timestamp outside valid window",
+			errorReason:   auth.ReasonExpiredTimestamp,
 		},
 		{
 			name:      "DNS lookup failure",
@@ -118,6 +129,7 @@ func TestDNSAuthHandler_ExchangeToken(t *testing.T) {
 			},
 			expectError:   true,
 			errorContains: "failed to lookup DNS TXT records",
+			errorReason:   auth.ReasonUpstreamError,
 		},
 		{
 			name:      "no MCP TXT records",
@@ -129,6 +141,7 @@ func TestDNSAuthHandler_ExchangeToken(t *testing.T) {
 			},
 			expectError:   true,
 			errorContains: "no valid MCP public keys found",
+			errorReason:   auth.ReasonKeyNotFound,
 		},
 	}
 
@@ -163,6 +176,9 @@ func TestDNSAuthHandler_ExchangeToken(t *testing.T) {
 				if tt.errorContains != "" {
 					assert.Contains(t, err.Error(), tt.errorContains)
 				}
+				if tt.errorReason != "" {
+					assert.Equal(t, tt.errorReason, auth.FailureReason(err))
+				}
 				assert.Nil(t, result)
 			} else {
 				assert.NoError(t, err)
@@ -195,3 +211,47 @@ func TestDNSAuthHandler_ExchangeToken(t *testing.T) {
 		})
 	}
 }
+
+// testSigners returns a private key for each supported key algorithm
+func testSigners(t *testing.T) map[string]crypto.Signer {
+	t.Helper()
+	_, ed25519Key, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return map[string]crypto.Signer{"ed25519": ed25519Key, "ecdsap256": ecdsaKey, "rsa": rsaKey}
+}
+
+func TestDNSAuthHandler_KeyAlgorithms(t *testing.T) {
+	cfg := &config.Config{
+		JWTPrivateKey:                "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		AuthAllowTimestampSignatures: true,
+	}
+	handler := auth.NewDNSAuthHandler(cfg, auth.NewChallenges(cfg, newFakeNonceStore()))
+
+	for name, signer := range testSigners(t) {
+		t.Run(name, func(t *testing.T) {
+			record, err := keyalg.FormatRecord(signer.Public())
+			require.NoError(t, err)
+			assert.Contains(t, record, "k="+name+";")
+			handler.SetResolver(&MockDNSResolver{txtRecords: map[string][]string{"example.com": {record}}})
+
+			timestamp := time.Now().UTC().Format(time.RFC3339)
+			signature, err := keyalg.Sign(signer, []byte(timestamp))
+			require.NoError(t, err)
+
+			result, err := handler.ExchangeToken(context.Background(), "example.com", timestamp, hex.EncodeToString(signature))
+			require.NoError(t, err)
+			assert.NotEmpty(t, result.RegistryToken)
+
+			// A signature of a different message is rejected
+			signature, err = keyalg.Sign(signer, []byte("other message"))
+			require.NoError(t, err)
+			_, err = handler.ExchangeToken(context.Background(), "example.com", timestamp, hex.EncodeToString(signature))
+			assert.ErrorContains(t, err, "signature verification failed")
+			assert.Equal(t, auth.ReasonInvalidSignature, auth.FailureReason(err))
+		})
+	}
+}
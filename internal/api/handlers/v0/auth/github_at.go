@@ -7,18 +7,23 @@ import (
 	"io"
 	"net/http"
 	"regexp"
+	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/httpclient"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 )
 
 // GitHubTokenExchangeInput represents the input for GitHub token exchange
 type GitHubTokenExchangeInput struct {
 	Body struct {
 		GitHubToken string `json:"github_token" doc:"GitHub OAuth token" required:"true"`
+		GitHubHost  string `json:"github_host,omitempty" doc:"GitHub instance that issued the token, github.com by default" example:"github.com"`
 	}
 }
 
@@ -26,25 +31,42 @@ type GitHubTokenExchangeInput struct {
 type GitHubHandler struct {
 	config     *config.Config
 	jwtManager *auth.JWTManager
-	baseURL    string // Configurable for testing
+	instances  map[string]config.GitHubInstance // by host
+	client     *http.Client
+	identities *identityCache
 }
 
 // NewGitHubHandler creates a new GitHub handler
 func NewGitHubHandler(cfg *config.Config) *GitHubHandler {
+	instances := make(map[string]config.GitHubInstance)
+	for _, instance := range cfg.GitHubInstances() {
+		instances[instance.Host] = instance
+	}
 	return &GitHubHandler{
 		config:     cfg,
 		jwtManager: auth.NewJWTManager(cfg),
-		baseURL:    "https://api.github.com",
+		instances:  instances,
+		client:     newGitHubClient(httpclient.FromConfig(cfg, "github")),
+		identities: newIdentityCache(cfg.GitHubIdentityCacheTTL),
 	}
 }
 
-// SetBaseURL sets the base URL for GitHub API (used for testing)
+// newGitHubClient creates a client for GitHub, whose URLs are set by the registry rather than
+// by publishers, so they may be on a private network
+func newGitHubClient(opts httpclient.Options) *http.Client {
+	opts.AllowPrivateNetworks = true
+	return httpclient.New(opts)
+}
+
+// SetBaseURL sets the base URL for the github.com API (used for testing)
 func (h *GitHubHandler) SetBaseURL(url string) {
-	h.baseURL = url
+	instance := h.instances[config.GitHubDotComHost]
+	instance.APIURL = url
+	h.instances[config.GitHubDotComHost] = instance
 }
 
 // RegisterGitHubATEndpoint registers the GitHub access token authentication endpoint
-func RegisterGitHubATEndpoint(api huma.API, cfg *config.Config) {
+func RegisterGitHubATEndpoint(api huma.API, cfg *config.Config, metrics *telemetry.Metrics) {
 	handler := NewGitHubHandler(cfg)
 
 	// GitHub token exchange endpoint
@@ -56,7 +78,8 @@ func RegisterGitHubATEndpoint(api huma.API, cfg *config.Config) {
 		Description: "Exchange a GitHub OAuth access token for a short-lived Registry JWT token",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, input *GitHubTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
-		response, err := handler.ExchangeToken(ctx, input.Body.GitHubToken)
+		response, err := handler.ExchangeTokenForHost(ctx, input.Body.GitHubHost, input.Body.GitHubToken)
+		recordExchange(ctx, metrics, model.AuthMethodGitHubAT, err)
 		if err != nil {
 			return nil, huma.Error401Unauthorized("Token exchange failed", err)
 		}
@@ -67,22 +90,30 @@ func RegisterGitHubATEndpoint(api huma.API, cfg *config.Config) {
 	})
 }
 
-// ExchangeToken exchanges a GitHub OAuth token for a Registry JWT token
+// ExchangeToken exchanges a github.com OAuth token for a Registry JWT token
 func (h *GitHubHandler) ExchangeToken(ctx context.Context, githubToken string) (*auth.TokenResponse, error) {
-	// Get GitHub user information
-	user, err := h.getGitHubUser(ctx, githubToken)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get GitHub user: %w", err)
+	return h.ExchangeTokenForHost(ctx, config.GitHubDotComHost, githubToken)
+}
+
+// ExchangeTokenForHost exchanges an OAuth token of the GitHub instance at host for a Registry
+// JWT token, granting the user's and organizations' names in the instance's namespace
+func (h *GitHubHandler) ExchangeTokenForHost(ctx context.Context, host, githubToken string) (*auth.TokenResponse, error) {
+	if host == "" {
+		host = config.GitHubDotComHost
+	}
+	instance, ok := h.instances[strings.ToLower(host)]
+	if !ok {
+		return nil, withReason(ReasonInvalidToken, fmt.Errorf("GitHub instance %s is not trusted by this registry", host))
 	}
 
-	// Get user's organizations
-	orgs, err := h.getGitHubUserOrgs(ctx, user.Login, githubToken)
+	identity, err := h.getIdentity(ctx, instance, githubToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get GitHub organizations: %w", err)
+		return nil, err
 	}
+	user := identity.user
 
 	// Build permissions based on user and organizations
-	permissions := h.buildPermissions(user.Login, orgs)
+	permissions := h.buildPermissions(instance.Namespace, user.Login, identity.orgs)
 
 	// Create JWT claims with GitHub user info
 	claims := auth.JWTClaims{
@@ -94,20 +125,48 @@ func (h *GitHubHandler) ExchangeToken(ctx context.Context, githubToken string) (
 	// Generate Registry JWT token
 	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, claims)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
+		return nil, withReason(ReasonTokenGeneration, fmt.Errorf("failed to generate JWT token: %w", err))
 	}
 
 	return tokenResponse, nil
 }
 
+// getIdentity returns the user and organizations of a token, from the cache if it was
+// looked up recently
+func (h *GitHubHandler) getIdentity(ctx context.Context, instance config.GitHubInstance, githubToken string) (gitHubIdentity, error) {
+	key := identityCacheKey(instance.Host, githubToken)
+	if identity, ok := h.identities.get(ctx, key); ok {
+		return identity, nil
+	}
+
+	// Get GitHub user information
+	user, err := h.getGitHubUser(ctx, instance.APIURL, githubToken)
+	if err != nil {
+		return gitHubIdentity{}, withReason(ReasonUpstreamError, fmt.Errorf("failed to get GitHub user: %w", err))
+	}
+
+	// Get user's organizations
+	orgs, err := h.getGitHubUserOrgs(ctx, instance.APIURL, user.Login, githubToken)
+	if err != nil {
+		return gitHubIdentity{}, withReason(ReasonUpstreamError, fmt.Errorf("failed to get GitHub organizations: %w", err))
+	}
+
+	identity := gitHubIdentity{user: user, orgs: orgs}
+	h.identities.put(key, identity)
+	return identity, nil
+}
+
 type GitHubUserOrOrg struct {
 	Login string `json:"login"`
 	ID    int    `json:"id"`
 }
 
 // getGitHubUser gets the authenticated user's information
-func (h *GitHubHandler) getGitHubUser(ctx context.Context, token string) (*GitHubUserOrOrg, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.baseURL+"/user", nil)
+func (h *GitHubHandler) getGitHubUser(ctx context.Context, apiURL, token string) (_ *GitHubUserOrOrg, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "GitHub GET /user", semconv.HTTPMethod(http.MethodGet))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"/user", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -116,7 +175,7 @@ func (h *GitHubHandler) getGitHubUser(ctx context.Context, token string) (*GitHu
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := h.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user info: %w", err)
 	}
@@ -135,8 +194,11 @@ func (h *GitHubHandler) getGitHubUser(ctx context.Context, token string) (*GitHu
 	return &user, nil
 }
 
-func (h *GitHubHandler) getGitHubUserOrgs(ctx context.Context, username string, token string) ([]GitHubUserOrOrg, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.baseURL+"/users/"+username+"/orgs", nil)
+func (h *GitHubHandler) getGitHubUserOrgs(ctx context.Context, apiURL, username string, token string) (_ []GitHubUserOrOrg, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "GitHub GET /users/{username}/orgs", semconv.HTTPMethod(http.MethodGet))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL+"/users/"+username+"/orgs", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -145,7 +207,7 @@ func (h *GitHubHandler) getGitHubUserOrgs(ctx context.Context, username string,
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := h.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user organizations: %w", err)
 	}
@@ -164,8 +226,8 @@ func (h *GitHubHandler) getGitHubUserOrgs(ctx context.Context, username string,
 	return orgs, nil
 }
 
-// buildPermissions builds permissions based on GitHub user and their organizations
-func (h *GitHubHandler) buildPermissions(username string, orgs []GitHubUserOrOrg) []auth.Permission {
+// buildPermissions builds permissions in namespace based on GitHub user and their organizations
+func (h *GitHubHandler) buildPermissions(namespace, username string, orgs []GitHubUserOrOrg) []auth.Permission {
 	permissions := []auth.Permission{}
 
 	// Assert user and org names match expected regex, to harden against people doing weird things in names
@@ -181,14 +243,14 @@ func (h *GitHubHandler) buildPermissions(username string, orgs []GitHubUserOrOrg
 	// Add permission for user's own namespace
 	permissions = append(permissions, auth.Permission{
 		Action:          auth.PermissionActionPublish,
-		ResourcePattern: fmt.Sprintf("io.github.%s/*", username),
+		ResourcePattern: fmt.Sprintf("%s.%s/*", namespace, username),
 	})
 
 	// Add permissions for each organization
 	for _, org := range orgs {
 		permissions = append(permissions, auth.Permission{
 			Action:          auth.PermissionActionPublish,
-			ResourcePattern: fmt.Sprintf("io.github.%s/*", org.Login),
+			ResourcePattern: fmt.Sprintf("%s.%s/*", namespace, org.Login),
 		})
 	}
 
@@ -625,3 +625,47 @@ func TestConcurrentTokenExchange(t *testing.T) {
 		assert.NoError(t, err)
 	}
 }
+
+func TestGitHubHandler_IdentityCache(t *testing.T) {
+	var userCalls, orgCalls int
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case githubUserEndpoint:
+			userCalls++
+			if r.Header.Get("Authorization") != "Bearer valid-token" && r.Header.Get("Authorization") != "Bearer other-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(v0auth.GitHubUserOrOrg{Login: "testuser", ID: 12345}) //nolint:errcheck
+		case githubOrgsEndpoint:
+			orgCalls++
+			json.NewEncoder(w).Encode([]v0auth.GitHubUserOrOrg{{Login: "testorg", ID: 1}}) //nolint:errcheck
+		}
+	}))
+	defer mockServer.Close()
+
+	cfg := &config.Config{JWTPrivateKey: testJWTKey, GitHubIdentityCacheTTL: time.Minute}
+	handler := v0auth.NewGitHubHandler(cfg)
+	handler.SetBaseURL(mockServer.URL)
+
+	// Repeated exchanges of a token look it up once
+	for range 3 {
+		_, err := handler.ExchangeToken(context.Background(), "valid-token")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, userCalls)
+	assert.Equal(t, 1, orgCalls)
+
+	// Other tokens are looked up separately
+	_, err := handler.ExchangeToken(context.Background(), "other-token")
+	require.NoError(t, err)
+	assert.Equal(t, 2, userCalls)
+
+	// Failed lookups aren't cached
+	for range 2 {
+		_, err := handler.ExchangeToken(context.Background(), "invalid-token")
+		require.Error(t, err)
+	}
+	assert.Equal(t, 4, userCalls)
+}
@@ -0,0 +1,150 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	internalauth "github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// enterpriseConfig trusts a GitHub Enterprise Server instance whose API is served by apiURL
+func enterpriseConfig(t *testing.T, apiURL string) *config.Config {
+	t.Helper()
+	var instances config.GitHubEnterpriseInstances
+	require.NoError(t, instances.UnmarshalText([]byte(`[{
+		"host": "github.example.com",
+		"namespace": "com.example.github",
+		"api_url": "`+apiURL+`"
+	}]`)))
+	return &config.Config{JWTPrivateKey: testJWTKey, GitHubEnterpriseInstances: instances}
+}
+
+func permissionPatterns(t *testing.T, cfg *config.Config, registryToken string) []string {
+	t.Helper()
+	claims, err := internalauth.NewJWTManager(cfg).ValidateToken(context.Background(), registryToken)
+	require.NoError(t, err)
+	var patterns []string
+	for _, perm := range claims.Permissions {
+		patterns = append(patterns, perm.ResourcePattern)
+	}
+	return patterns
+}
+
+func TestGitHubHandler_Enterprise(t *testing.T) {
+	var publicCalled bool
+	public := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		publicCalled = true
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer public.Close()
+
+	enterprise := http.NewServeMux()
+	enterprise.HandleFunc("/api/v3/user", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(auth.GitHubUserOrOrg{Login: "octocat", ID: 1})
+	})
+	enterprise.HandleFunc("/api/v3/users/octocat/orgs", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode([]auth.GitHubUserOrOrg{{Login: "platform", ID: 2}})
+	})
+	server := httptest.NewServer(enterprise)
+	defer server.Close()
+
+	cfg := enterpriseConfig(t, server.URL+"/api/v3")
+	handler := auth.NewGitHubHandler(cfg)
+	handler.SetBaseURL(public.URL)
+
+	t.Run("grants names in the instance's namespace", func(t *testing.T) {
+		response, err := handler.ExchangeTokenForHost(context.Background(), "GitHub.example.com", "enterprise-token")
+		require.NoError(t, err)
+		assert.False(t, publicCalled, "enterprise tokens must not be sent to github.com")
+		assert.Equal(t, []string{"com.example.github.octocat/*", "com.example.github.platform/*"}, permissionPatterns(t, cfg, response.RegistryToken))
+	})
+
+	t.Run("rejects untrusted hosts", func(t *testing.T) {
+		_, err := handler.ExchangeTokenForHost(context.Background(), "github.evil.com", "enterprise-token")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not trusted")
+	})
+}
+
+func TestGitHubOIDCHandler_Enterprise(t *testing.T) {
+	cfg := enterpriseConfig(t, "https://github.example.com/api/v3")
+	db := database.NewMemoryDB(nil)
+	handler := auth.NewGitHubOIDCHandler(cfg, db)
+	claims := releaseClaims()
+	handler.SetValidator(&MockOIDCValidator{
+		validateFunc: func(_ context.Context, _ string, _ string) (*auth.GitHubOIDCClaims, error) {
+			return claims, nil
+		},
+	})
+
+	// The issuer decides the namespace, so an enterprise owner can't claim io.github names
+	claims.Issuer = "https://github.example.com/_services/token"
+	response, err := handler.ExchangeToken(context.Background(), "test-token")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"com.example.github.octo-org/*"}, permissionPatterns(t, cfg, response.RegistryToken))
+
+	// Policies of the same owner name on github.com don't apply
+	require.NoError(t, db.SetGitHubOIDCPolicies(context.Background(), "github.com", "octo-org", []model.GitHubOIDCPolicy{
+		{Resource: "io.github.octo-org/server", Repository: "octo-org/other-repo"},
+	}))
+	_, err = handler.ExchangeToken(context.Background(), "test-token")
+	require.NoError(t, err)
+
+	claims.Issuer = "https://token.example.org"
+	_, err = handler.ExchangeToken(context.Background(), "test-token")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a trusted GitHub instance")
+}
+
+func TestGitHubOIDCPolicyEndpoints_Enterprise(t *testing.T) {
+	cfg := enterpriseConfig(t, "https://github.example.com/api/v3")
+	jwtManager := internalauth.NewJWTManager(cfg)
+	db := database.NewMemoryDB(nil)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	auth.RegisterGitHubOIDCPolicyEndpoints(api, cfg, db)
+
+	put := func(pattern string, policy model.GitHubOIDCPolicy) int {
+		response, err := jwtManager.GenerateTokenResponse(context.Background(), internalauth.JWTClaims{
+			AuthMethod:  model.AuthMethodGitHubAT,
+			Permissions: []internalauth.Permission{{Action: internalauth.PermissionActionPublish, ResourcePattern: pattern}},
+		})
+		require.NoError(t, err)
+		var body bytes.Buffer
+		require.NoError(t, json.NewEncoder(&body).Encode(map[string]any{"policies": []model.GitHubOIDCPolicy{policy}}))
+		req := httptest.NewRequest(http.MethodPut, "/v0/auth/github-oidc/policies/octo-org?github_host=github.example.com", &body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+response.RegistryToken)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w.Code
+	}
+	policy := model.GitHubOIDCPolicy{Resource: "com.example.github.octo-org/server", Repository: "octo-org/octo-repo"}
+
+	// A github.com login for the same owner name doesn't cover the enterprise owner
+	assert.Equal(t, http.StatusForbidden, put("io.github.octo-org/*", policy))
+
+	assert.Equal(t, http.StatusOK, put("com.example.github.octo-org/*", policy))
+	stored, err := db.GitHubOIDCPolicies(context.Background(), "github.example.com", "octo-org")
+	require.NoError(t, err)
+	assert.Equal(t, []model.GitHubOIDCPolicy{policy}, stored)
+
+	// Policies must stay within the instance's namespace
+	assert.Equal(t, http.StatusBadRequest, put("com.example.github.octo-org/*", model.GitHubOIDCPolicy{
+		Resource: "io.github.octo-org/server", Repository: "octo-org/octo-repo",
+	}))
+}
@@ -9,13 +9,18 @@ import (
 	"io"
 	"math/big"
 	"net/http"
+	"path"
+	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/golang-jwt/jwt/v5"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/httpclient"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 )
 
 // GitHubOIDCTokenExchangeInput represents the input for GitHub OIDC token exchange
@@ -29,6 +34,17 @@ type GitHubOIDCTokenExchangeInput struct {
 type GitHubOIDCClaims struct {
 	jwt.RegisteredClaims
 	RepositoryOwner string `json:"repository_owner"` // e.g., "octo-org"
+	Repository      string `json:"repository"`       // e.g., "octo-org/octo-repo"
+	Ref             string `json:"ref"`              // e.g., "refs/tags/v1.0.0"
+	WorkflowRef     string `json:"workflow_ref"`     // e.g., "octo-org/octo-repo/.github/workflows/release.yml@refs/tags/v1.0.0"
+	JobWorkflowRef  string `json:"job_workflow_ref"` // the reusable workflow the job runs, or WorkflowRef
+	Environment     string `json:"environment"`      // e.g., "production", empty without a deployment environment
+}
+
+// OIDCPolicyStore holds the GitHub OIDC policies namespace owners have configured
+type OIDCPolicyStore interface {
+	GitHubOIDCPolicies(ctx context.Context, host, owner string) ([]model.GitHubOIDCPolicy, error)
+	SetGitHubOIDCPolicies(ctx context.Context, host, owner string, policies []model.GitHubOIDCPolicy) error
 }
 
 // JWKS represents a JSON Web Key Set
@@ -50,25 +66,32 @@ type OIDCValidator interface {
 	ValidateToken(ctx context.Context, token string, audience string) (*GitHubOIDCClaims, error)
 }
 
-// GitHubOIDCValidator validates GitHub OIDC tokens
+// GitHubOIDCValidator validates GitHub OIDC tokens from any trusted GitHub instance
 type GitHubOIDCValidator struct {
-	jwksURL string
-	issuer  string
+	jwksURLs map[string]string // by issuer
+	client   *http.Client
+	keys     *jwksCache
 }
 
 // NewGitHubOIDCValidator creates a new GitHub OIDC validator
-func NewGitHubOIDCValidator() *GitHubOIDCValidator {
+func NewGitHubOIDCValidator(cfg *config.Config) *GitHubOIDCValidator {
+	jwksURLs := make(map[string]string)
+	for _, instance := range cfg.GitHubInstances() {
+		jwksURLs[instance.OIDCIssuer] = instance.JWKSURL()
+	}
 	return &GitHubOIDCValidator{
-		jwksURL: "https://token.actions.githubusercontent.com/.well-known/jwks",
-		issuer:  "https://token.actions.githubusercontent.com",
+		jwksURLs: jwksURLs,
+		client:   newGitHubClient(httpclient.FromConfig(cfg, "github_oidc_jwks")),
+		keys:     newJWKSCache(cfg.GitHubJWKSCacheTTL),
 	}
 }
 
 // NewMockOIDCValidator creates a mock validator for testing
 func NewMockOIDCValidator(jwksURL, issuer string) *GitHubOIDCValidator {
 	return &GitHubOIDCValidator{
-		jwksURL: jwksURL,
-		issuer:  issuer,
+		jwksURLs: map[string]string{issuer: jwksURL},
+		client:   newGitHubClient(httpclient.Options{Destination: "github_oidc_jwks"}),
+		keys:     newJWKSCache(0),
 	}
 }
 
@@ -79,6 +102,13 @@ func (v *GitHubOIDCValidator) ValidateToken(ctx context.Context, tokenString str
 		tokenString,
 		&GitHubOIDCClaims{},
 		func(token *jwt.Token) (any, error) {
+			// Only fetch keys from trusted issuers, which the claims are checked against below
+			issuer, _ := token.Claims.GetIssuer()
+			jwksURL, ok := v.jwksURLs[issuer]
+			if !ok {
+				return nil, fmt.Errorf("untrusted issuer %q", issuer)
+			}
+
 			// Get key ID from header
 			kid, ok := token.Header["kid"].(string)
 			if !ok {
@@ -86,7 +116,7 @@ func (v *GitHubOIDCValidator) ValidateToken(ctx context.Context, tokenString str
 			}
 
 			// Find matching public key
-			publicKey, err := v.getPublicKey(ctx, kid)
+			publicKey, err := v.getPublicKey(ctx, jwksURL, kid)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get public key: %w", err)
 			}
@@ -111,8 +141,8 @@ func (v *GitHubOIDCValidator) ValidateToken(ctx context.Context, tokenString str
 	}
 
 	// Validate issuer
-	if claims.Issuer != v.issuer {
-		return nil, fmt.Errorf("invalid issuer: expected %s, got %s", v.issuer, claims.Issuer)
+	if _, ok := v.jwksURLs[claims.Issuer]; !ok {
+		return nil, fmt.Errorf("invalid issuer: %s is not a trusted GitHub instance", claims.Issuer)
 	}
 
 	// Validate audience
@@ -135,46 +165,68 @@ func (v *GitHubOIDCValidator) ValidateToken(ctx context.Context, tokenString str
 	return claims, nil
 }
 
-// fetchJWKS fetches the JSON Web Key Set from GitHub
-func (v *GitHubOIDCValidator) fetchJWKS(ctx context.Context) (*JWKS, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.jwksURL, nil)
+// fetchJWKS fetches the JSON Web Key Set from GitHub, with the response's headers
+func (v *GitHubOIDCValidator) fetchJWKS(ctx context.Context, jwksURL string) (_ *JWKS, _ http.Header, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "GitHub OIDC GET JWKS",
+		semconv.HTTPMethod(http.MethodGet),
+		semconv.HTTPURL(jwksURL),
+	)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := v.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch JWKS: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("JWKS endpoint returned status %d: %s", resp.StatusCode, body)
+		return nil, nil, fmt.Errorf("JWKS endpoint returned status %d: %s", resp.StatusCode, body)
 	}
 
 	var jwks JWKS
 	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
-		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+		return nil, nil, fmt.Errorf("failed to decode JWKS: %w", err)
 	}
 
-	return &jwks, nil
+	return &jwks, resp.Header, nil
 }
 
-// getPublicKey extracts the RSA public key for the given key ID
-func (v *GitHubOIDCValidator) getPublicKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
-	// Fetch JWKS from GitHub
-	jwks, err := v.fetchJWKS(ctx)
+// fetchPublicKeys fetches the RSA public keys at jwksURL by key ID
+func (v *GitHubOIDCValidator) fetchPublicKeys(ctx context.Context, jwksURL string) (map[string]*rsa.PublicKey, http.Header, error) {
+	jwks, header, err := v.fetchJWKS(ctx, jwksURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+		return nil, nil, err
 	}
 
-	for _, key := range jwks.Keys {
-		if key.KID == kid {
-			return v.parseRSAPublicKey(key)
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range jwks.Keys {
+		key, err := v.parseRSAPublicKey(jwk)
+		if err != nil {
+			// Keys of other types can't sign tokens we accept
+			continue
 		}
+		keys[jwk.KID] = key
 	}
-	return nil, fmt.Errorf("key with ID %s not found", kid)
+	return keys, header, nil
+}
+
+// getPublicKey returns the RSA public key for the given key ID, fetching the JWKS from GitHub
+// if it isn't cached
+func (v *GitHubOIDCValidator) getPublicKey(ctx context.Context, jwksURL, kid string) (*rsa.PublicKey, error) {
+	key, ok, err := v.keys.key(ctx, jwksURL, kid, v.fetchPublicKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("key with ID %s not found", kid)
+	}
+	return key, nil
 }
 
 // parseRSAPublicKey converts JWK to RSA public key
@@ -210,14 +262,22 @@ type GitHubOIDCHandler struct {
 	config     *config.Config
 	jwtManager *auth.JWTManager
 	validator  OIDCValidator
+	policies   OIDCPolicyStore
+	instances  map[string]config.GitHubInstance // by OIDC issuer
 }
 
 // NewGitHubOIDCHandler creates a new GitHub OIDC handler
-func NewGitHubOIDCHandler(cfg *config.Config) *GitHubOIDCHandler {
+func NewGitHubOIDCHandler(cfg *config.Config, policies OIDCPolicyStore) *GitHubOIDCHandler {
+	instances := make(map[string]config.GitHubInstance)
+	for _, instance := range cfg.GitHubInstances() {
+		instances[instance.OIDCIssuer] = instance
+	}
 	return &GitHubOIDCHandler{
 		config:     cfg,
 		jwtManager: auth.NewJWTManager(cfg),
-		validator:  NewGitHubOIDCValidator(),
+		validator:  NewGitHubOIDCValidator(cfg),
+		policies:   policies,
+		instances:  instances,
 	}
 }
 
@@ -227,8 +287,8 @@ func (h *GitHubOIDCHandler) SetValidator(validator OIDCValidator) {
 }
 
 // RegisterGitHubOIDCEndpoint registers the GitHub OIDC authentication endpoint
-func RegisterGitHubOIDCEndpoint(api huma.API, cfg *config.Config) {
-	handler := NewGitHubOIDCHandler(cfg)
+func RegisterGitHubOIDCEndpoint(api huma.API, cfg *config.Config, metrics *telemetry.Metrics, policies OIDCPolicyStore) {
+	handler := NewGitHubOIDCHandler(cfg, policies)
 
 	// GitHub OIDC token exchange endpoint
 	huma.Register(api, huma.Operation{
@@ -240,6 +300,7 @@ func RegisterGitHubOIDCEndpoint(api huma.API, cfg *config.Config) {
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, input *GitHubOIDCTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
 		response, err := handler.ExchangeToken(ctx, input.Body.OIDCToken)
+		recordExchange(ctx, metrics, model.AuthMethodGitHubOIDC, err)
 		if err != nil {
 			return nil, huma.Error401Unauthorized("Token exchange failed", err)
 		}
@@ -255,11 +316,14 @@ func (h *GitHubOIDCHandler) ExchangeToken(ctx context.Context, oidcToken string)
 	// Validate OIDC token with audience "mcp-registry"
 	claims, err := h.validator.ValidateToken(ctx, oidcToken, "mcp-registry")
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate OIDC token: %w", err)
+		return nil, withReason(ReasonInvalidToken, fmt.Errorf("failed to validate OIDC token: %w", err))
 	}
 
 	// Extract repository information and build permissions
-	permissions := h.buildPermissions(claims)
+	permissions, err := h.buildPermissions(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create JWT claims with GitHub OIDC info
 	jwtClaims := auth.JWTClaims{
@@ -271,18 +335,47 @@ func (h *GitHubOIDCHandler) ExchangeToken(ctx context.Context, oidcToken string)
 	// Generate Registry JWT token
 	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, jwtClaims)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
+		return nil, withReason(ReasonTokenGeneration, fmt.Errorf("failed to generate JWT token: %w", err))
 	}
 
 	return tokenResponse, nil
 }
 
-func (h *GitHubOIDCHandler) buildPermissions(claims *GitHubOIDCClaims) []auth.Permission {
+func (h *GitHubOIDCHandler) buildPermissions(ctx context.Context, claims *GitHubOIDCClaims) ([]auth.Permission, error) {
 	permissions := []auth.Permission{}
 
+	// The issuer decides which GitHub instance, and so which namespace, the token is for
+	instance, ok := h.instances[claims.Issuer]
+	if !ok {
+		return nil, withReason(ReasonInvalidToken, fmt.Errorf("OIDC token issuer %s is not a trusted GitHub instance", claims.Issuer))
+	}
+
 	// Validate repository owner name
 	if !isValidGitHubName(claims.RepositoryOwner) {
-		return nil
+		return nil, nil
+	}
+
+	// Owners with policies only let the workflows their policies match publish, and only the
+	// resources of those policies
+	policies, err := h.policies.GitHubOIDCPolicies(ctx, instance.Host, claims.RepositoryOwner)
+	if err != nil {
+		return nil, withReason(ReasonUpstreamError, fmt.Errorf("failed to get GitHub OIDC policies: %w", err))
+	}
+	if len(policies) > 0 {
+		for _, policy := range policies {
+			if policyMatches(policy, claims) {
+				permissions = append(permissions, auth.Permission{
+					Action:          auth.PermissionActionPublish,
+					ResourcePattern: policy.Resource,
+				})
+			}
+		}
+		if len(permissions) == 0 {
+			return nil, withReason(ReasonPolicyDenied, fmt.Errorf(
+				"no GitHub OIDC policy of %s allows this workflow run (repository %s, ref %s, workflow %s, environment %q)",
+				claims.RepositoryOwner, claims.Repository, claims.Ref, claims.WorkflowRef, claims.Environment))
+		}
+		return permissions, nil
 	}
 
 	// Grant publish permissions for the repository owner's namespace
@@ -290,8 +383,32 @@ func (h *GitHubOIDCHandler) buildPermissions(claims *GitHubOIDCClaims) []auth.Pe
 	// This also reflects GitHub's permission model, in that GitHub Actions can push to any GitHub package in the repository owner's namespace (e.g. for GHCR)
 	permissions = append(permissions, auth.Permission{
 		Action:          auth.PermissionActionPublish,
-		ResourcePattern: fmt.Sprintf("io.github.%s/*", claims.RepositoryOwner),
+		ResourcePattern: fmt.Sprintf("%s.%s/*", instance.Namespace, claims.RepositoryOwner),
 	})
 
-	return permissions
+	return permissions, nil
+}
+
+// policyMatches reports whether a workflow run satisfies every condition of a policy
+func policyMatches(policy model.GitHubOIDCPolicy, claims *GitHubOIDCClaims) bool {
+	workflow, _, _ := strings.Cut(claims.WorkflowRef, "@")
+	jobWorkflow, _, _ := strings.Cut(claims.JobWorkflowRef, "@")
+	return strings.EqualFold(policy.Repository, claims.Repository) &&
+		matchesAny(policy.Refs, claims.Ref) &&
+		matchesAny(policy.Workflows, workflow) &&
+		matchesAny(policy.JobWorkflows, jobWorkflow) &&
+		matchesAny(policy.Environments, claims.Environment)
+}
+
+// matchesAny reports whether value matches one of patterns, or patterns is empty
+func matchesAny(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched && value != "" {
+			return true
+		}
+	}
+	return false
 }
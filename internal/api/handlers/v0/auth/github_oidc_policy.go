@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+// GitHubOIDCPoliciesInput identifies the GitHub owner whose policies are read
+type GitHubOIDCPoliciesInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permission for io.github.<owner>/*" required:"false"`
+	Owner         string `path:"owner" doc:"GitHub user or organization" example:"octo-org"`
+	GitHubHost    string `query:"github_host" doc:"GitHub instance of the owner, github.com by default" example:"github.com"`
+}
+
+// SetGitHubOIDCPoliciesInput replaces the policies of a GitHub owner
+type SetGitHubOIDCPoliciesInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permission for io.github.<owner>/*" required:"false"`
+	Owner         string `path:"owner" doc:"GitHub user or organization" example:"octo-org"`
+	GitHubHost    string `query:"github_host" doc:"GitHub instance of the owner, github.com by default" example:"github.com"`
+	Body          GitHubOIDCPolicies
+}
+
+// GitHubOIDCPolicies are the policies restricting GitHub OIDC publishing for an owner
+type GitHubOIDCPolicies struct {
+	Policies []model.GitHubOIDCPolicy `json:"policies" doc:"Policies a workflow run must match to publish. An empty list lets any workflow of the owner publish anything in its namespace." nullable:"false"`
+}
+
+// RegisterGitHubOIDCPolicyEndpoints registers the endpoints for reading and replacing an
+// owner's GitHub OIDC policies
+func RegisterGitHubOIDCPolicyEndpoints(api huma.API, cfg *config.Config, store OIDCPolicyStore) {
+	jwtManager := auth.NewJWTManager(cfg)
+	instances := make(map[string]config.GitHubInstance)
+	for _, instance := range cfg.GitHubInstances() {
+		instances[instance.Host] = instance
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-github-oidc-policies",
+		Method:      http.MethodGet,
+		Path:        "/v0/auth/github-oidc/policies/{owner}",
+		Summary:     "Get GitHub OIDC policies",
+		Description: "Get the policies that restrict which GitHub Actions workflows can publish in an owner's namespace",
+		Tags:        []string{"auth"},
+	}, func(ctx context.Context, input *GitHubOIDCPoliciesInput) (*v0.Response[GitHubOIDCPolicies], error) {
+		instance, err := authorizePolicyChange(ctx, jwtManager, instances, input.Authorization, input.GitHubHost, input.Owner)
+		if err != nil {
+			return nil, err
+		}
+
+		policies, err := store.GitHubOIDCPolicies(ctx, instance.Host, input.Owner)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get GitHub OIDC policies", err)
+		}
+
+		return &v0.Response[GitHubOIDCPolicies]{
+			Body: GitHubOIDCPolicies{Policies: policies},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-github-oidc-policies",
+		Method:      http.MethodPut,
+		Path:        "/v0/auth/github-oidc/policies/{owner}",
+		Summary:     "Set GitHub OIDC policies",
+		Description: "Replace the policies that restrict which GitHub Actions workflows can publish in an owner's namespace. " +
+			"GitHub OIDC tokens can't change policies, so a workflow can't lift its own restrictions.",
+		Tags: []string{"auth"},
+	}, func(ctx context.Context, input *SetGitHubOIDCPoliciesInput) (*v0.Response[GitHubOIDCPolicies], error) {
+		instance, err := authorizePolicyChange(ctx, jwtManager, instances, input.Authorization, input.GitHubHost, input.Owner)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, policy := range input.Body.Policies {
+			if err := model.ValidateGitHubOIDCPolicy(instance.Namespace, input.Owner, policy); err != nil {
+				return nil, huma.Error400BadRequest(fmt.Sprintf("Invalid policy %d", i), err)
+			}
+		}
+
+		if err := store.SetGitHubOIDCPolicies(ctx, instance.Host, input.Owner, input.Body.Policies); err != nil {
+			return nil, huma.Error500InternalServerError("Failed to set GitHub OIDC policies", err)
+		}
+
+		policies := input.Body.Policies
+		if policies == nil {
+			policies = []model.GitHubOIDCPolicy{}
+		}
+		return &v0.Response[GitHubOIDCPolicies]{
+			Body: GitHubOIDCPolicies{Policies: policies},
+		}, nil
+	})
+}
+
+// authorizePolicyChange checks that the Authorization header holds a registry token that can
+// publish all of owner's namespace on the GitHub instance at host, and wasn't itself obtained
+// through GitHub OIDC. It returns the instance.
+func authorizePolicyChange(
+	ctx context.Context, jwtManager *auth.JWTManager, instances map[string]config.GitHubInstance, authHeader, host, owner string,
+) (config.GitHubInstance, error) {
+	if host == "" {
+		host = config.GitHubDotComHost
+	}
+	instance, ok := instances[strings.ToLower(host)]
+	if !ok {
+		return instance, huma.Error400BadRequest(fmt.Sprintf("GitHub instance %s is not trusted by this registry", host))
+	}
+	if !isValidGitHubName(owner) {
+		return instance, huma.Error400BadRequest("Invalid GitHub owner name")
+	}
+
+	token := authHeader
+	const bearerPrefix = "Bearer "
+	if len(authHeader) >= len(bearerPrefix) && strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		token = authHeader[len(bearerPrefix):]
+	}
+	if token == "" {
+		return instance, huma.Error401Unauthorized("Authentication is required to manage GitHub OIDC policies")
+	}
+
+	claims, err := jwtManager.ValidateToken(ctx, token)
+	if err != nil {
+		return instance, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+	}
+
+	if claims.AuthMethod == model.AuthMethodGitHubOIDC {
+		return instance, huma.Error403Forbidden("GitHub OIDC tokens can't manage GitHub OIDC policies; log in with a GitHub access token")
+	}
+	namespace := fmt.Sprintf("%s.%s", instance.Namespace, owner)
+	if !jwtManager.HasPermission(namespace+"/*", auth.PermissionActionPublish, claims.Permissions) {
+		return instance, huma.Error403Forbidden(fmt.Sprintf("You do not have permission to manage the namespace %s", namespace))
+	}
+
+	return instance, nil
+}
@@ -0,0 +1,261 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	internalauth "github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+const testJWTKey = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func releaseClaims() *auth.GitHubOIDCClaims {
+	return &auth.GitHubOIDCClaims{
+		RepositoryOwner: "octo-org",
+		Repository:      "octo-org/octo-repo",
+		Ref:             "refs/tags/v1.0.0",
+		WorkflowRef:     "octo-org/octo-repo/.github/workflows/release.yml@refs/tags/v1.0.0",
+		JobWorkflowRef:  "octo-org/octo-repo/.github/workflows/release.yml@refs/tags/v1.0.0",
+	}
+}
+
+func TestGitHubOIDCHandler_Policies(t *testing.T) {
+	cfg := &config.Config{JWTPrivateKey: testJWTKey}
+	jwtManager := internalauth.NewJWTManager(cfg)
+
+	releasePolicy := model.GitHubOIDCPolicy{
+		Resource:   "io.github.octo-org/server",
+		Repository: "octo-org/octo-repo",
+		Refs:       []string{"refs/tags/*"},
+		Workflows:  []string{"octo-org/octo-repo/.github/workflows/release.yml"},
+	}
+
+	tests := []struct {
+		name          string
+		policies      []model.GitHubOIDCPolicy
+		claims        func(*auth.GitHubOIDCClaims)
+		expectedPerms []string
+		expectError   string
+	}{
+		{
+			name:          "no policies grant the whole namespace",
+			expectedPerms: []string{"io.github.octo-org/*"},
+		},
+		{
+			name:          "matching policy grants its resource",
+			policies:      []model.GitHubOIDCPolicy{releasePolicy},
+			expectedPerms: []string{"io.github.octo-org/server"},
+		},
+		{
+			name: "every matching policy grants its resource",
+			policies: []model.GitHubOIDCPolicy{
+				releasePolicy,
+				{Resource: "io.github.octo-org/other", Repository: "Octo-Org/Octo-Repo"},
+				{Resource: "io.github.octo-org/third", Repository: "octo-org/third-repo"},
+			},
+			expectedPerms: []string{"io.github.octo-org/server", "io.github.octo-org/other"},
+		},
+		{
+			name:     "branch ref is denied",
+			policies: []model.GitHubOIDCPolicy{releasePolicy},
+			claims: func(c *auth.GitHubOIDCClaims) {
+				c.Ref = "refs/heads/main"
+			},
+			expectError: "no GitHub OIDC policy",
+		},
+		{
+			name:     "other workflow is denied",
+			policies: []model.GitHubOIDCPolicy{releasePolicy},
+			claims: func(c *auth.GitHubOIDCClaims) {
+				c.WorkflowRef = "octo-org/octo-repo/.github/workflows/ci.yml@refs/tags/v1.0.0"
+			},
+			expectError: "no GitHub OIDC policy",
+		},
+		{
+			name:     "other repository is denied",
+			policies: []model.GitHubOIDCPolicy{releasePolicy},
+			claims: func(c *auth.GitHubOIDCClaims) {
+				c.Repository = "octo-org/fork"
+			},
+			expectError: "no GitHub OIDC policy",
+		},
+		{
+			name: "reusable workflow must match",
+			policies: []model.GitHubOIDCPolicy{{
+				Resource:     "io.github.octo-org/server",
+				Repository:   "octo-org/octo-repo",
+				JobWorkflows: []string{"octo-org/shared/.github/workflows/publish.yml"},
+			}},
+			claims: func(c *auth.GitHubOIDCClaims) {
+				c.JobWorkflowRef = "octo-org/shared/.github/workflows/publish.yml@refs/heads/main"
+			},
+			expectedPerms: []string{"io.github.octo-org/server"},
+		},
+		{
+			name: "run without an environment doesn't match environments",
+			policies: []model.GitHubOIDCPolicy{{
+				Resource:     "io.github.octo-org/server",
+				Repository:   "octo-org/octo-repo",
+				Environments: []string{"*"},
+			}},
+			expectError: "no GitHub OIDC policy",
+		},
+		{
+			name: "run in a matching environment",
+			policies: []model.GitHubOIDCPolicy{{
+				Resource:     "io.github.octo-org/server",
+				Repository:   "octo-org/octo-repo",
+				Environments: []string{"production"},
+			}},
+			claims: func(c *auth.GitHubOIDCClaims) {
+				c.Environment = "production"
+			},
+			expectedPerms: []string{"io.github.octo-org/server"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := database.NewMemoryDB(nil)
+			require.NoError(t, db.SetGitHubOIDCPolicies(context.Background(), "github.com", "octo-org", tt.policies))
+
+			claims := releaseClaims()
+			if tt.claims != nil {
+				tt.claims(claims)
+			}
+			handler := auth.NewGitHubOIDCHandler(cfg, db)
+			handler.SetValidator(&MockOIDCValidator{
+				validateFunc: func(_ context.Context, _ string, _ string) (*auth.GitHubOIDCClaims, error) {
+					return claims, nil
+				},
+			})
+
+			response, err := handler.ExchangeToken(context.Background(), "test-token")
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+
+			tokenClaims, err := jwtManager.ValidateToken(context.Background(), response.RegistryToken)
+			require.NoError(t, err)
+			var perms []string
+			for _, perm := range tokenClaims.Permissions {
+				assert.Equal(t, internalauth.PermissionActionPublish, perm.Action)
+				perms = append(perms, perm.ResourcePattern)
+			}
+			assert.Equal(t, tt.expectedPerms, perms)
+		})
+	}
+}
+
+func TestGitHubOIDCPolicyEndpoints(t *testing.T) {
+	cfg := &config.Config{JWTPrivateKey: testJWTKey}
+	jwtManager := internalauth.NewJWTManager(cfg)
+	db := database.NewMemoryDB(nil)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	auth.RegisterGitHubOIDCPolicyEndpoints(api, cfg, db)
+
+	token := func(method model.AuthMethod, pattern string) string {
+		response, err := jwtManager.GenerateTokenResponse(context.Background(), internalauth.JWTClaims{
+			AuthMethod: method,
+			Permissions: []internalauth.Permission{
+				{Action: internalauth.PermissionActionPublish, ResourcePattern: pattern},
+			},
+		})
+		require.NoError(t, err)
+		return response.RegistryToken
+	}
+	request := func(method, owner, token string, body any) *httptest.ResponseRecorder {
+		var reader bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		}
+		req := httptest.NewRequest(method, "/v0/auth/github-oidc/policies/"+owner, &reader)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	ownerToken := token(model.AuthMethodGitHubAT, "io.github.octo-org/*")
+	policies := map[string]any{"policies": []model.GitHubOIDCPolicy{{
+		Resource:   "io.github.octo-org/server",
+		Repository: "octo-org/octo-repo",
+		Refs:       []string{"refs/tags/*"},
+	}}}
+
+	t.Run("owner sets and gets policies", func(t *testing.T) {
+		w := request(http.MethodPut, "octo-org", ownerToken, policies)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = request(http.MethodGet, "octo-org", ownerToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var got auth.GitHubOIDCPolicies
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+		require.Len(t, got.Policies, 1)
+		assert.Equal(t, "io.github.octo-org/server", got.Policies[0].Resource)
+		assert.Equal(t, []string{"refs/tags/*"}, got.Policies[0].Refs)
+	})
+
+	t.Run("requires a token", func(t *testing.T) {
+		w := request(http.MethodGet, "octo-org", "", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("GitHub OIDC tokens can't change policies", func(t *testing.T) {
+		w := request(http.MethodPut, "octo-org", token(model.AuthMethodGitHubOIDC, "io.github.octo-org/*"), map[string]any{"policies": []any{}})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("other owners can't change policies", func(t *testing.T) {
+		w := request(http.MethodPut, "octo-org", token(model.AuthMethodGitHubAT, "io.github.someone/*"), map[string]any{"policies": []any{}})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		// A token for a single server doesn't cover the namespace either
+		w = request(http.MethodPut, "octo-org", token(model.AuthMethodGitHubAT, "io.github.octo-org/server"), map[string]any{"policies": []any{}})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("rejects policies outside the namespace", func(t *testing.T) {
+		w := request(http.MethodPut, "octo-org", ownerToken, map[string]any{"policies": []model.GitHubOIDCPolicy{{
+			Resource:   "io.github.someone/server",
+			Repository: "octo-org/octo-repo",
+		}}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = request(http.MethodPut, "octo-org", ownerToken, map[string]any{"policies": []model.GitHubOIDCPolicy{{
+			Resource:   "io.github.octo-org/server",
+			Repository: "someone/octo-repo",
+		}}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("empty list removes policies", func(t *testing.T) {
+		w := request(http.MethodPut, "octo-org", ownerToken, map[string]any{"policies": []any{}})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		stored, err := db.GitHubOIDCPolicies(context.Background(), "github.com", "octo-org")
+		require.NoError(t, err)
+		assert.Empty(t, stored)
+	})
+}
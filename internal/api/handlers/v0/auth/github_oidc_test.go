@@ -11,6 +11,7 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
 	internalauth "github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,8 +22,13 @@ type MockOIDCValidator struct {
 	validateFunc func(ctx context.Context, token string, audience string) (*auth.GitHubOIDCClaims, error)
 }
 
+// ValidateToken returns the claims of validateFunc, issued by github.com unless they say otherwise
 func (m *MockOIDCValidator) ValidateToken(ctx context.Context, token string, audience string) (*auth.GitHubOIDCClaims, error) {
-	return m.validateFunc(ctx, token, audience)
+	claims, err := m.validateFunc(ctx, token, audience)
+	if claims != nil && claims.Issuer == "" {
+		claims.Issuer = "https://token.actions.githubusercontent.com"
+	}
+	return claims, err
 }
 
 func TestGitHubOIDCHandler_ExchangeToken(t *testing.T) {
@@ -30,7 +36,7 @@ func TestGitHubOIDCHandler_ExchangeToken(t *testing.T) {
 		JWTPrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", // 32 bytes hex
 	}
 
-	handler := auth.NewGitHubOIDCHandler(cfg)
+	handler := auth.NewGitHubOIDCHandler(cfg, database.NewMemoryDB(nil))
 
 	tests := []struct {
 		name            string
@@ -123,7 +129,7 @@ func TestBuildPermissionsFromOIDC(t *testing.T) {
 	cfg := &config.Config{
 		JWTPrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
 	}
-	handler := auth.NewGitHubOIDCHandler(cfg)
+	handler := auth.NewGitHubOIDCHandler(cfg, database.NewMemoryDB(nil))
 
 	tests := []struct {
 		name          string
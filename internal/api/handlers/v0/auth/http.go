@@ -2,29 +2,32 @@ package auth
 
 import (
 	"context"
-	"crypto/ed25519"
-	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
-	"regexp"
 	"strings"
-	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth/keyalg"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/httpclient"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 )
 
 // HTTPTokenExchangeInput represents the input for HTTP-based authentication
 type HTTPTokenExchangeInput struct {
 	Body struct {
 		Domain          string `json:"domain" doc:"Domain name" example:"example.com" required:"true"`
-		Timestamp       string `json:"timestamp" doc:"RFC3339 timestamp" example:"2023-01-01T00:00:00Z" required:"true"`
-		SignedTimestamp string `json:"signed_timestamp" doc:"Hex-encoded Ed25519 signature of timestamp" example:"abcdef1234567890" required:"true"`
+		Nonce           string `json:"nonce,omitempty" doc:"Nonce from /v0/auth/challenge" required:"false"`
+		Signature       string `json:"signature,omitempty" doc:"Hex-encoded signature of <nonce>:<domain>, using the algorithm of the domain's public key" example:"abcdef1234567890" required:"false"`
+		Timestamp       string `json:"timestamp,omitempty" doc:"RFC3339 timestamp (deprecated, use nonce)" example:"2023-01-01T00:00:00Z" required:"false"`
+		SignedTimestamp string `json:"signed_timestamp,omitempty" doc:"Hex-encoded signature of timestamp (deprecated, use signature)" example:"abcdef1234567890" required:"false"`
 	}
 }
 
@@ -33,22 +36,21 @@ type HTTPKeyFetcher interface {
 	FetchKey(ctx context.Context, domain string) (string, error)
 }
 
-// DefaultHTTPKeyFetcher uses Go's standard HTTP client
+// DefaultHTTPKeyFetcher fetches keys with an outbound client that can't reach internal addresses
 type DefaultHTTPKeyFetcher struct {
 	client *http.Client
 }
 
 // NewDefaultHTTPKeyFetcher creates a new HTTP key fetcher with timeout
-func NewDefaultHTTPKeyFetcher() *DefaultHTTPKeyFetcher {
+func NewDefaultHTTPKeyFetcher(cfg *config.Config) *DefaultHTTPKeyFetcher {
+	opts := httpclient.FromConfig(cfg, "http_auth_key")
+	// Disable redirects for security purposes:
+	// Prevents people doing weird things like sending us to internal endpoints at different paths
+	opts.FollowRedirects = false
+	// Key records are short, so limit the response size to prevent DoS attacks
+	opts.MaxResponseBytes = 4096
 	return &DefaultHTTPKeyFetcher{
-		client: &http.Client{
-			Timeout: 10 * time.Second,
-			// Disable redirects for security purposes:
-			// Prevents people doing weird things like sending us to internal endpoints at different paths
-			CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
-				return http.ErrUseLastResponse
-			},
-		},
+		client: httpclient.New(opts),
 	}
 }
 
@@ -74,9 +76,6 @@ func (f *DefaultHTTPKeyFetcher) FetchKey(ctx context.Context, domain string) (st
 		return "", fmt.Errorf("HTTP %d: failed to fetch key from %s", resp.StatusCode, url)
 	}
 
-	// Limit response size to prevent DoS attacks
-	resp.Body = http.MaxBytesReader(nil, resp.Body, 4096)
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return "", fmt.Errorf("failed to read response body: %w", err)
@@ -90,14 +89,16 @@ type HTTPAuthHandler struct {
 	config     *config.Config
 	jwtManager *auth.JWTManager
 	fetcher    HTTPKeyFetcher
+	challenges *Challenges
 }
 
 // NewHTTPAuthHandler creates a new HTTP authentication handler
-func NewHTTPAuthHandler(cfg *config.Config) *HTTPAuthHandler {
+func NewHTTPAuthHandler(cfg *config.Config, challenges *Challenges) *HTTPAuthHandler {
 	return &HTTPAuthHandler{
 		config:     cfg,
 		jwtManager: auth.NewJWTManager(cfg),
-		fetcher:    NewDefaultHTTPKeyFetcher(),
+		fetcher:    NewDefaultHTTPKeyFetcher(cfg),
+		challenges: challenges,
 	}
 }
 
@@ -107,8 +108,8 @@ func (h *HTTPAuthHandler) SetFetcher(fetcher HTTPKeyFetcher) {
 }
 
 // RegisterHTTPEndpoint registers the HTTP authentication endpoint
-func RegisterHTTPEndpoint(api huma.API, cfg *config.Config) {
-	handler := NewHTTPAuthHandler(cfg)
+func RegisterHTTPEndpoint(api huma.API, cfg *config.Config, metrics *telemetry.Metrics, challenges *Challenges) {
+	handler := NewHTTPAuthHandler(cfg, challenges)
 
 	// HTTP authentication endpoint
 	huma.Register(api, huma.Operation{
@@ -116,10 +117,17 @@ func RegisterHTTPEndpoint(api huma.API, cfg *config.Config) {
 		Method:      http.MethodPost,
 		Path:        "/v0/auth/http",
 		Summary:     "Exchange HTTP signature for Registry JWT",
-		Description: "Authenticate using HTTP-hosted public key and signed timestamp",
+		Description: "Authenticate using HTTP-hosted public key and a signed challenge from /v0/auth/challenge",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, input *HTTPTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
-		response, err := handler.ExchangeToken(ctx, input.Body.Domain, input.Body.Timestamp, input.Body.SignedTimestamp)
+		var response *auth.TokenResponse
+		var err error
+		if input.Body.Nonce != "" {
+			response, err = handler.ExchangeChallenge(ctx, input.Body.Domain, input.Body.Nonce, input.Body.Signature)
+		} else {
+			response, err = handler.ExchangeToken(ctx, input.Body.Domain, input.Body.Timestamp, input.Body.SignedTimestamp)
+		}
+		recordExchange(ctx, metrics, model.AuthMethodHTTP, err)
 		if err != nil {
 			return nil, huma.Error401Unauthorized("HTTP authentication failed", err)
 		}
@@ -130,53 +138,86 @@ func RegisterHTTPEndpoint(api huma.API, cfg *config.Config) {
 	})
 }
 
-// ExchangeToken exchanges HTTP signature for a Registry JWT token
-func (h *HTTPAuthHandler) ExchangeToken(ctx context.Context, domain, timestamp, signedTimestamp string) (*auth.TokenResponse, error) {
+// ExchangeChallenge exchanges an HTTP signature of a challenge nonce for a Registry JWT token
+func (h *HTTPAuthHandler) ExchangeChallenge(ctx context.Context, domain, nonce, signedNonce string) (*auth.TokenResponse, error) {
 	// Validate domain format
 	if !isValidDomain(domain) {
-		return nil, fmt.Errorf("invalid domain format")
+		return nil, withReason(ReasonInvalidRequest, fmt.Errorf("invalid domain format"))
 	}
 
-	// Parse and validate timestamp
-	ts, err := time.Parse(time.RFC3339, timestamp)
+	// Check the nonce was issued for this domain and is still valid
+	expiresAt, err := h.challenges.Verify(domain, nonce)
 	if err != nil {
-		return nil, fmt.Errorf("invalid timestamp format: %w", err)
+		return nil, err
 	}
 
-	// Check timestamp is within 15 seconds
-	now := time.Now()
-	if ts.Before(now.Add(-15*time.Second)) || ts.After(now.Add(15*time.Second)) {
-		return nil, fmt.Errorf("timestamp outside valid window (±15 seconds)")
+	if err := h.verifySignature(ctx, domain, keyalg.ChallengeMessage(domain, nonce), signedNonce); err != nil {
+		return nil, err
 	}
 
-	// Decode signature
-	signature, err := hex.DecodeString(signedTimestamp)
-	if err != nil {
-		return nil, fmt.Errorf("invalid signature format, must be hex: %w", err)
+	// Only mark the nonce used once the signature is valid, so others can't burn it
+	if err := h.challenges.Consume(ctx, nonce, expiresAt); err != nil {
+		return nil, err
+	}
+
+	return h.issueToken(ctx, domain)
+}
+
+// ExchangeToken exchanges an HTTP signature of a timestamp for a Registry JWT token. Timestamp
+// signatures can be replayed within their window, so they are only accepted when
+// AuthAllowTimestampSignatures is set.
+func (h *HTTPAuthHandler) ExchangeToken(ctx context.Context, domain, timestamp, signedTimestamp string) (*auth.TokenResponse, error) {
+	// Validate domain format
+	if !isValidDomain(domain) {
+		return nil, withReason(ReasonInvalidRequest, fmt.Errorf("invalid domain format"))
+	}
+
+	if err := checkTimestamp(h.config, timestamp); err != nil {
+		return nil, err
 	}
 
-	if len(signature) != ed25519.SignatureSize {
-		return nil, fmt.Errorf("invalid signature length: expected %d, got %d", ed25519.SignatureSize, len(signature))
+	if err := h.verifySignature(ctx, domain, []byte(timestamp), signedTimestamp); err != nil {
+		return nil, err
+	}
+
+	return h.issueToken(ctx, domain)
+}
+
+// verifySignature checks that signedMessage is a signature of message by the key published
+// at the domain's well-known URL
+func (h *HTTPAuthHandler) verifySignature(ctx context.Context, domain string, message []byte, signedMessage string) error {
+	// Decode signature
+	signature, err := hex.DecodeString(signedMessage)
+	if err != nil {
+		return withReason(ReasonInvalidRequest, fmt.Errorf("invalid signature format, must be hex: %w", err))
 	}
 
 	// Fetch public key from HTTP endpoint
-	keyResponse, err := h.fetcher.FetchKey(ctx, domain)
+	fetchCtx, span := telemetry.StartSpan(ctx, "HTTP auth key fetch",
+		semconv.HTTPMethod(http.MethodGet),
+		attribute.String("http.auth.domain", domain),
+	)
+	keyResponse, err := h.fetcher.FetchKey(fetchCtx, domain)
+	telemetry.EndSpan(span, err)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch public key: %w", err)
+		return withReason(ReasonUpstreamError, fmt.Errorf("failed to fetch public key: %w", err))
 	}
 
 	// Parse public key from HTTP response
 	publicKey, err := h.parsePublicKeyFromHTTP(keyResponse)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse public key: %w", err)
+		return withReason(ReasonKeyNotFound, fmt.Errorf("failed to parse public key: %w", err))
 	}
 
 	// Verify signature
-	messageBytes := []byte(timestamp)
-	if !ed25519.Verify(publicKey, messageBytes, signature) {
-		return nil, fmt.Errorf("signature verification failed")
+	if err := publicKey.Verify(message, signature); err != nil {
+		return withReason(ReasonInvalidSignature, verificationError(err))
 	}
+	return nil
+}
 
+// issueToken issues a Registry JWT token for a verified domain
+func (h *HTTPAuthHandler) issueToken(ctx context.Context, domain string) (*auth.TokenResponse, error) {
 	// Build permissions for domain and subdomains
 	permissions := h.buildPermissions(domain)
 
@@ -190,33 +231,16 @@ func (h *HTTPAuthHandler) ExchangeToken(ctx context.Context, domain, timestamp,
 	// Generate Registry JWT token
 	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, jwtClaims)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
+		return nil, withReason(ReasonTokenGeneration, fmt.Errorf("failed to generate JWT token: %w", err))
 	}
 
 	return tokenResponse, nil
 }
 
-// parsePublicKeyFromHTTP parses Ed25519 public key from HTTP response
-func (h *HTTPAuthHandler) parsePublicKeyFromHTTP(response string) (ed25519.PublicKey, error) {
-	// Expected format: v=MCPv1; k=ed25519; p=<base64-encoded-key>
-	mcpPattern := regexp.MustCompile(`v=MCPv1;\s*k=ed25519;\s*p=([A-Za-z0-9+/=]+)`)
-
-	matches := mcpPattern.FindStringSubmatch(response)
-	if len(matches) != 2 {
-		return nil, fmt.Errorf("invalid key format, expected: v=MCPv1; k=ed25519; p=<base64-key>")
-	}
-
-	// Decode base64 public key
-	publicKeyBytes, err := base64.StdEncoding.DecodeString(matches[1])
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode base64 public key: %w", err)
-	}
-
-	if len(publicKeyBytes) != ed25519.PublicKeySize {
-		return nil, fmt.Errorf("invalid public key length: expected %d, got %d", ed25519.PublicKeySize, len(publicKeyBytes))
-	}
-
-	return ed25519.PublicKey(publicKeyBytes), nil
+// parsePublicKeyFromHTTP parses the MCP public key from an HTTP response
+func (h *HTTPAuthHandler) parsePublicKeyFromHTTP(response string) (keyalg.PublicKey, error) {
+	// Expected format: v=MCPv1; k=<algorithm>; p=<base64-encoded-key>
+	return keyalg.ParseRecord(response)
 }
 
 // buildPermissions builds permissions for a domain and its subdomains using reverse DNS notation
@@ -15,7 +15,9 @@ import (
 
 	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
 	intauth "github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth/keyalg"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/httpclient"
 	"github.com/modelcontextprotocol/registry/internal/model"
 )
 
@@ -34,9 +36,10 @@ func (m *MockHTTPKeyFetcher) FetchKey(_ context.Context, domain string) (string,
 
 func TestHTTPAuthHandler_ExchangeToken(t *testing.T) {
 	cfg := &config.Config{
-		JWTPrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		JWTPrivateKey:                "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		AuthAllowTimestampSignatures: true,
 	}
-	handler := auth.NewHTTPAuthHandler(cfg)
+	handler := auth.NewHTTPAuthHandler(cfg, auth.NewChallenges(cfg, newFakeNonceStore()))
 
 	// Generate a test key pair
 	publicKey, privateKey, err := ed25519.GenerateKey(nil)
@@ -234,11 +237,47 @@ func TestHTTPAuthHandler_ExchangeToken(t *testing.T) {
 func TestDefaultHTTPKeyFetcher_FetchKey(t *testing.T) {
 	// This test would require a real HTTP server or more sophisticated mocking
 	// For now, we'll test the basic structure
-	fetcher := auth.NewDefaultHTTPKeyFetcher()
+	fetcher := auth.NewDefaultHTTPKeyFetcher(&config.Config{})
 	assert.NotNil(t, fetcher)
 
 	// Test that it returns an error for non-existent domains
 	// (This will fail with network error, which is expected)
 	_, err := fetcher.FetchKey(context.Background(), "nonexistent-test-domain-12345.com")
 	assert.Error(t, err)
+
+	// Domains that point at internal addresses are never contacted
+	for _, domain := range []string{"localhost", "127.0.0.1", "169.254.169.254"} {
+		_, err = fetcher.FetchKey(context.Background(), domain)
+		assert.ErrorIs(t, err, httpclient.ErrBlockedAddress, domain)
+	}
+}
+
+func TestHTTPAuthHandler_KeyAlgorithms(t *testing.T) {
+	cfg := &config.Config{
+		JWTPrivateKey:                "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		AuthAllowTimestampSignatures: true,
+	}
+	handler := auth.NewHTTPAuthHandler(cfg, auth.NewChallenges(cfg, newFakeNonceStore()))
+
+	for name, signer := range testSigners(t) {
+		t.Run(name, func(t *testing.T) {
+			record, err := keyalg.FormatRecord(signer.Public())
+			require.NoError(t, err)
+			handler.SetFetcher(&MockHTTPKeyFetcher{keyResponses: map[string]string{"example.com": record}})
+
+			timestamp := time.Now().UTC().Format(time.RFC3339)
+			signature, err := keyalg.Sign(signer, []byte(timestamp))
+			require.NoError(t, err)
+
+			result, err := handler.ExchangeToken(context.Background(), "example.com", timestamp, hex.EncodeToString(signature))
+			require.NoError(t, err)
+			assert.NotEmpty(t, result.RegistryToken)
+		})
+	}
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		handler.SetFetcher(&MockHTTPKeyFetcher{keyResponses: map[string]string{"example.com": "v=MCPv1; k=dsa; p=AAAA"}})
+		_, err := handler.ExchangeToken(context.Background(), "example.com", time.Now().UTC().Format(time.RFC3339), "abcdef")
+		assert.ErrorContains(t, err, `unsupported key algorithm "dsa"`)
+	})
 }
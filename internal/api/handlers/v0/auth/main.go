@@ -3,26 +3,40 @@ package auth
 import (
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
+// AuthStore is the state authentication endpoints share across replicas
+type AuthStore interface {
+	NonceStore
+	OIDCPolicyStore
+}
+
 // RegisterAuthEndpoints registers all authentication endpoints
-func RegisterAuthEndpoints(api huma.API, cfg *config.Config) {
+func RegisterAuthEndpoints(api huma.API, cfg *config.Config, metrics *telemetry.Metrics, store AuthStore) {
 	// Register GitHub access token authentication endpoint
-	RegisterGitHubATEndpoint(api, cfg)
+	RegisterGitHubATEndpoint(api, cfg, metrics)
 
 	// Register GitHub OIDC authentication endpoint
-	RegisterGitHubOIDCEndpoint(api, cfg)
+	RegisterGitHubOIDCEndpoint(api, cfg, metrics, store)
+
+	// Register the endpoints namespace owners use to restrict GitHub OIDC publishing
+	RegisterGitHubOIDCPolicyEndpoints(api, cfg, store)
+
+	// Register the challenge endpoint, whose nonces DNS and HTTP authentication sign
+	challenges := NewChallenges(cfg, store)
+	RegisterChallengeEndpoint(api, challenges)
 
 	// Register DNS-based authentication endpoint
-	RegisterDNSEndpoint(api, cfg)
+	RegisterDNSEndpoint(api, cfg, metrics, challenges)
 
 	// Register HTTP-based authentication endpoint
-	RegisterHTTPEndpoint(api, cfg)
+	RegisterHTTPEndpoint(api, cfg, metrics, challenges)
 
 	// Register anonymous authentication endpoint
-	RegisterNoneEndpoint(api, cfg)
+	RegisterNoneEndpoint(api, cfg, metrics)
 
 	// Future auth providers can be registered here:
-	// RegisterGitLabEndpoint(api, cfg)
-	// RegisterOIDCEndpoint(api, cfg)
+	// RegisterGitLabEndpoint(api, cfg, metrics)
+	// RegisterOIDCEndpoint(api, cfg, metrics)
 }
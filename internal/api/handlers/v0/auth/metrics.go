@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Failure reasons reported on the token exchange metric
+const (
+	ReasonInvalidRequest   = "invalid_request"
+	ReasonExpiredTimestamp = "expired_timestamp"
+	ReasonInvalidNonce     = "invalid_nonce"
+	ReasonReplayedNonce    = "replayed_nonce"
+	ReasonKeyNotFound      = "key_not_found"
+	ReasonInvalidSignature = "invalid_signature"
+	ReasonUpstreamError    = "upstream_error"
+	ReasonInvalidToken     = "invalid_token"
+	ReasonPolicyDenied     = "policy_denied"
+	ReasonTokenGeneration  = "token_generation"
+	ReasonUnknown          = "unknown"
+)
+
+// exchangeError attaches a failure reason to a token exchange error without changing its message
+type exchangeError struct {
+	reason string
+	err    error
+}
+
+func (e *exchangeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exchangeError) Unwrap() error {
+	return e.err
+}
+
+// withReason tags err with a failure reason for metrics
+func withReason(reason string, err error) error {
+	return &exchangeError{reason: reason, err: err}
+}
+
+// FailureReason returns the failure reason attached to a token exchange error
+func FailureReason(err error) string {
+	var exchangeErr *exchangeError
+	if errors.As(err, &exchangeErr) {
+		return exchangeErr.reason
+	}
+	return ReasonUnknown
+}
+
+// recordExchange records the outcome of a token exchange
+func recordExchange(ctx context.Context, metrics *telemetry.Metrics, method model.AuthMethod, err error) {
+	attrs := []attribute.KeyValue{
+		attribute.String("method", string(method)),
+		attribute.String("outcome", "success"),
+	}
+	if err != nil {
+		attrs[1] = attribute.String("outcome", "failure")
+		attrs = append(attrs, attribute.String("reason", FailureReason(err)))
+	}
+	metrics.AuthExchanges.Add(ctx, 1, metric.WithAttributes(attrs...))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
 // NoneHandler handles anonymous authentication
@@ -27,7 +28,7 @@ func NewNoneHandler(cfg *config.Config) *NoneHandler {
 }
 
 // RegisterNoneEndpoint registers the anonymous authentication endpoint
-func RegisterNoneEndpoint(api huma.API, cfg *config.Config) {
+func RegisterNoneEndpoint(api huma.API, cfg *config.Config, metrics *telemetry.Metrics) {
 	if !cfg.EnableAnonymousAuth {
 		return
 	}
@@ -44,6 +45,7 @@ func RegisterNoneEndpoint(api huma.API, cfg *config.Config) {
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, _ *struct{}) (*v0.Response[auth.TokenResponse], error) {
 		response, err := handler.GetAnonymousToken(ctx)
+		recordExchange(ctx, metrics, model.AuthMethodNone, err)
 		if err != nil {
 			return nil, huma.Error500InternalServerError("Failed to generate token", err)
 		}
@@ -74,7 +76,7 @@ func (h *NoneHandler) GetAnonymousToken(ctx context.Context) (*auth.TokenRespons
 	// Generate Registry JWT token
 	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, claims)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
+		return nil, withReason(ReasonTokenGeneration, fmt.Errorf("failed to generate JWT token: %w", err))
 	}
 
 	return tokenResponse, nil
@@ -0,0 +1,73 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/clientconfig"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// ServerConfigInput represents the input for rendering client configuration for a server
+type ServerConfigInput struct {
+	ID      string `path:"id" doc:"Server ID (UUID)" format:"uuid"`
+	Client  string `query:"client" doc:"Client to render configuration for, such as vscode, claude-desktop, cursor or mcp-servers" required:"true"`
+	Package string `query:"package" doc:"Registry name of the package to run, such as npm. Defaults to the first package" required:"false"`
+	Remote  string `query:"remote" doc:"Transport type of the remote to connect to, such as sse, instead of a package" required:"false"`
+}
+
+// ServerConfigBody represents the client configuration response body
+type ServerConfigBody struct {
+	Client string                     `json:"client" doc:"Client the configuration is for"`
+	Syntax string                     `json:"syntax" doc:"Language of the configuration" example:"json"`
+	Config string                     `json:"config" doc:"Configuration to paste into the client's configuration file"`
+	Inputs []clientconfig.Placeholder `json:"inputs,omitempty" doc:"Values the user has to provide, referenced from the configuration"`
+}
+
+// RegisterServerConfigEndpoint registers the endpoint that renders configuration for MCP clients,
+// one per formatter
+func RegisterServerConfigEndpoint(api huma.API, registry service.RegistryService, formatters clientconfig.Formatters) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-config",
+		Method:      http.MethodGet,
+		Path:        "/v0/servers/{id}/config",
+		Summary:     "Get MCP client configuration",
+		Description: "Render ready-to-paste configuration for an MCP client to run a package or connect to a remote of the server",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerConfigInput) (*Response[ServerConfigBody], error) {
+		formatter, ok := formatters[input.Client]
+		if !ok {
+			return nil, newProblem(http.StatusBadRequest, CodeInvalidRequest,
+				fmt.Sprintf("Unknown client %q, expected one of %s", input.Client, strings.Join(formatters.Names(), ", ")))
+		}
+
+		server, err := registry.GetByID(ctx, input.ID)
+		if err != nil {
+			return nil, problemFromError(err, "Failed to get server details")
+		}
+
+		config, err := clientconfig.Render(formatter, server.Server, clientconfig.Selection{Package: input.Package, Remote: input.Remote})
+		switch {
+		case errors.Is(err, clientconfig.ErrNoTarget):
+			return nil, newProblem(http.StatusNotFound, CodeNotFound, "The server has no matching package or remote")
+		case errors.Is(err, clientconfig.ErrUnsupported):
+			return nil, newProblem(http.StatusUnprocessableEntity, CodeValidationFailed,
+				fmt.Sprintf("Configuration for %s can't be rendered: %s", input.Client, err))
+		case err != nil:
+			return nil, newProblem(http.StatusInternalServerError, CodeInternalError, "Failed to render configuration", err)
+		}
+
+		return &Response[ServerConfigBody]{
+			Body: ServerConfigBody{
+				Client: input.Client,
+				Syntax: config.Syntax,
+				Config: config.Content,
+				Inputs: config.Inputs,
+			},
+		}, nil
+	})
+}
@@ -0,0 +1,103 @@
+package v0_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/google/uuid"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/clientconfig"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerConfigEndpoint(t *testing.T) {
+	serverID := uuid.New().String()
+	server := &model.ServerResponse{Server: model.ServerDetail{
+		Name: "io.modelcontextprotocol/brave-search",
+		Packages: []model.Package{{
+			RegistryName: "npm",
+			Name:         "@modelcontextprotocol/server-brave-search",
+			Version:      "1.0.2",
+			EnvironmentVariables: []model.KeyValueInput{
+				{Name: "BRAVE_API_KEY", InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true, IsSecret: true}}},
+			},
+		}},
+	}}
+
+	testCases := []struct {
+		name           string
+		query          string
+		setupMocks     func(*MockRegistryService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "vscode",
+			query: "?client=vscode",
+			setupMocks: func(registry *MockRegistryService) {
+				registry.On("GetByID", serverID).Return(server, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `${input:BRAVE_API_KEY}`,
+		},
+		{
+			name:           "unknown client",
+			query:          "?client=emacs",
+			setupMocks:     func(*MockRegistryService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `expected one of claude-desktop, cursor, mcp-servers, vscode`,
+		},
+		{
+			name:  "no matching package",
+			query: "?client=cursor&package=pypi",
+			setupMocks: func(registry *MockRegistryService) {
+				registry.On("GetByID", serverID).Return(server, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `"code":"not_found"`,
+		},
+		{
+			name:  "unknown server",
+			query: "?client=cursor",
+			setupMocks: func(registry *MockRegistryService) {
+				registry.On("GetByID", serverID).Return(nil, database.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `Server not found`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRegistry := new(MockRegistryService)
+			tc.setupMocks(mockRegistry)
+
+			mux := http.NewServeMux()
+			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+			v0.RegisterServerConfigEndpoint(api, mockRegistry, clientconfig.DefaultFormatters())
+
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+serverID+"/config"+tc.query, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tc.expectedBody)
+			mockRegistry.AssertExpectations(t)
+
+			if w.Code == http.StatusOK {
+				var body v0.ServerConfigBody
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+				assert.Equal(t, "json", body.Syntax)
+				require.Len(t, body.Inputs, 1)
+				assert.Equal(t, "BRAVE_API_KEY", body.Inputs[0].ID)
+			}
+		})
+	}
+}
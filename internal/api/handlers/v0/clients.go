@@ -0,0 +1,149 @@
+package v0
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// ListClientsInput represents the input for listing clients
+type ListClientsInput struct {
+	Cursor string `query:"cursor" doc:"Pagination cursor (UUID)" format:"uuid" required:"false"`
+	Limit  int    `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100"`
+	Name   string `query:"name" doc:"Only include the client with this name" required:"false"`
+}
+
+// ListClientsBody represents the paginated client list response body
+type ListClientsBody struct {
+	Clients  []model.ClientResponse `json:"clients" doc:"List of MCP clients with extensions"`
+	Metadata *Metadata              `json:"metadata,omitempty" doc:"Pagination metadata"`
+}
+
+// ClientDetailInput represents the input for getting client details
+type ClientDetailInput struct {
+	ID string `path:"id" doc:"Client ID (UUID)" format:"uuid"`
+}
+
+// PublishClientInput represents the input for publishing a client
+type PublishClientInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github)" required:"false"`
+	RawBody       []byte `body:"raw"`
+}
+
+// RegisterClientsEndpoints registers the read-only client endpoints
+func RegisterClientsEndpoints(api huma.API, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-clients",
+		Method:      http.MethodGet,
+		Path:        "/v0/clients",
+		Summary:     "List MCP clients",
+		Description: "Get a paginated list of MCP clients from the registry, with the features and transports they support",
+		Tags:        []string{"clients"},
+	}, func(ctx context.Context, input *ListClientsInput) (*Response[ListClientsBody], error) {
+		// Validate cursor if provided
+		if input.Cursor != "" {
+			if _, err := uuid.Parse(input.Cursor); err != nil {
+				return nil, huma.Error400BadRequest("Invalid cursor parameter")
+			}
+		}
+
+		var filter map[string]any
+		if input.Name != "" {
+			filter = map[string]any{"name": input.Name}
+		}
+
+		clients, nextCursor, err := registry.ListClients(ctx, filter, input.Cursor, input.Limit)
+		if err != nil {
+			return nil, problemFromError(err, "Failed to get client list")
+		}
+
+		body := ListClientsBody{
+			Clients: clients,
+		}
+
+		// Add metadata if there's a next cursor
+		if nextCursor != "" {
+			body.Metadata = &Metadata{
+				NextCursor: nextCursor,
+				Count:      len(clients),
+			}
+		}
+
+		return &Response[ListClientsBody]{
+			Body: body,
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-client",
+		Method:      http.MethodGet,
+		Path:        "/v0/clients/{id}",
+		Summary:     "Get MCP client details",
+		Description: "Get detailed information about a specific MCP client version",
+		Tags:        []string{"clients"},
+	}, func(ctx context.Context, input *ClientDetailInput) (*Response[model.ClientResponse], error) {
+		client, err := registry.GetClientByID(ctx, input.ID)
+		if err != nil {
+			return nil, clientProblem(err, "Failed to get client details")
+		}
+
+		return &Response[model.ClientResponse]{
+			Body: *client,
+		}, nil
+	})
+}
+
+// RegisterClientPublishEndpoint registers the client publish endpoint
+func RegisterClientPublishEndpoint(api huma.API, registry service.RegistryService, cfg *config.Config) {
+	// Create JWT manager for token validation
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "publish-client",
+		Method:      http.MethodPost,
+		Path:        "/v0/clients",
+		Summary:     "Publish MCP client",
+		Description: "Publish a new MCP client version to the registry. Clients use the same namespaces and permissions as servers.",
+		Tags:        []string{"clients", "publish"},
+	}, func(ctx context.Context, input *PublishClientInput) (*Response[model.ClientResponse], error) {
+		// Validate that only allowed extension fields are present
+		if err := model.ValidatePublishClientRequestExtensions(input.RawBody); err != nil {
+			return nil, huma.Error400BadRequest("Invalid request format", err)
+		}
+
+		// Parse the validated request body
+		var publishRequest model.PublishClientRequest
+		if err := json.Unmarshal(input.RawBody, &publishRequest); err != nil {
+			return nil, huma.Error400BadRequest("Invalid JSON format", err)
+		}
+
+		if _, err := authorizePublish(ctx, jwtManager, input.Authorization, "client", publishRequest.Client.Name); err != nil {
+			return nil, err
+		}
+
+		client, err := registry.PublishClient(ctx, publishRequest)
+		if err != nil {
+			return nil, problemFromError(err, "Failed to publish client")
+		}
+
+		return &Response[model.ClientResponse]{
+			Body: *client,
+		}, nil
+	})
+}
+
+// clientProblem maps a registry service error for a client to an error response
+func clientProblem(err error, msg string) huma.StatusError {
+	problem := problemFromError(err, msg)
+	if problem.GetStatus() == http.StatusNotFound {
+		return newProblem(http.StatusNotFound, CodeNotFound, "Client not found")
+	}
+	return problem
+}
@@ -0,0 +1,205 @@
+package v0_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/google/uuid"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testClient(name string) model.ClientDetail {
+	return model.ClientDetail{
+		Name:          name,
+		Description:   "An agent host",
+		VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		Features:      []model.ClientFeature{model.ClientFeatureTools, model.ClientFeatureSampling},
+		Transports:    []model.ClientTransport{model.ClientTransportStdio},
+	}
+}
+
+func TestClientsEndpoints(t *testing.T) {
+	clientID := uuid.New().String()
+	clients := []model.ClientResponse{{Client: testClient("com.example/agent")}}
+
+	testCases := []struct {
+		name           string
+		path           string
+		setupMocks     func(*MockRegistryService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "list clients",
+			path: "/v0/clients",
+			setupMocks: func(registry *MockRegistryService) {
+				registry.On("ListClients", map[string]any(nil), "", 30).Return(clients, "", nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"transports":["stdio"]`,
+		},
+		{
+			name: "list clients by name",
+			path: "/v0/clients?name=com.example/agent",
+			setupMocks: func(registry *MockRegistryService) {
+				registry.On("ListClients", map[string]any{"name": "com.example/agent"}, "", 30).Return(clients, "", nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"name":"com.example/agent"`,
+		},
+		{
+			name: "get client",
+			path: "/v0/clients/" + clientID,
+			setupMocks: func(registry *MockRegistryService) {
+				registry.On("GetClientByID", clientID).Return(&clients[0], nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"features":["tools","sampling"]`,
+		},
+		{
+			name: "client not found",
+			path: "/v0/clients/" + clientID,
+			setupMocks: func(registry *MockRegistryService) {
+				registry.On("GetClientByID", clientID).Return(nil, database.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "Client not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRegistry := new(MockRegistryService)
+			tc.setupMocks(mockRegistry)
+
+			mux := http.NewServeMux()
+			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+			v0.RegisterClientsEndpoints(api, mockRegistry)
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tc.expectedBody)
+			mockRegistry.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPublishClientEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey: hex.EncodeToString(testSeed),
+	}
+
+	githubToken, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:        model.AuthMethodGitHubAT,
+		AuthMethodSubject: "example",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.example/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		client         model.ClientDetail
+		body           string
+		token          string
+		setupMocks     func(*MockRegistryService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "publish with namespace permission",
+			client: testClient("io.github.example/agent"),
+			token:  githubToken,
+			setupMocks: func(registry *MockRegistryService) {
+				registry.On("PublishClient", mock.AnythingOfType("model.PublishClientRequest")).
+					Return(&model.ClientResponse{Client: testClient("io.github.example/agent")}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"name":"io.github.example/agent"`,
+		},
+		{
+			name:           "github namespace requires a token",
+			client:         testClient("io.github.example/agent"),
+			setupMocks:     func(_ *MockRegistryService) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "Authentication is required for this client namespace",
+		},
+		{
+			name:           "token for another namespace",
+			client:         testClient("io.github.other/agent"),
+			token:          githubToken,
+			setupMocks:     func(_ *MockRegistryService) {},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   "You do not have permission to publish this client",
+		},
+		{
+			name:   "invalid client",
+			client: testClient("io.github.example/agent"),
+			token:  githubToken,
+			setupMocks: func(registry *MockRegistryService) {
+				registry.On("PublishClient", mock.AnythingOfType("model.PublishClientRequest")).
+					Return(nil, database.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `"code":"validation_failed"`,
+		},
+		{
+			name:           "unknown extension fields",
+			body:           `{"client": {"name": "com.example/agent"}, "x-registry": {"id": "forged"}}`,
+			setupMocks:     func(_ *MockRegistryService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "Only 'client' and 'x-publisher' fields are allowed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRegistry := new(MockRegistryService)
+			tc.setupMocks(mockRegistry)
+
+			mux := http.NewServeMux()
+			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+			v0.RegisterClientPublishEndpoint(api, mockRegistry, testConfig)
+
+			body := []byte(tc.body)
+			if tc.body == "" {
+				body, err = json.Marshal(model.PublishClientRequest{Client: tc.client})
+				require.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/v0/clients", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tc.expectedBody)
+			mockRegistry.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/admission"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/policy"
+)
+
+// Stable, machine-readable error codes returned in the "code" field of every error response.
+// Clients should branch on these rather than on the human-readable title or detail.
+const (
+	CodeInvalidRequest   = "invalid_request"
+	CodeValidationFailed = "validation_failed"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeNotFound         = "not_found"
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodeConflict         = "conflict"
+	CodeVersionConflict  = "version_conflict"
+	CodeAlreadyExists    = "already_exists"
+	CodeAdmissionDenied  = "admission_denied"
+	CodePolicyViolation  = "policy_violation"
+	CodeReadOnlyReplica  = "read_only_replica"
+	CodeRateLimited      = "rate_limited"
+	CodeTimeout          = "timeout"
+	CodeUnavailable      = "unavailable"
+	CodeInternalError    = "internal_error"
+)
+
+// ErrorModel is the RFC 7807 problem details body returned for every error response,
+// served as application/problem+json
+type ErrorModel struct {
+	huma.ErrorModel
+	Code      string `json:"code" doc:"Stable machine-readable error code" example:"not_found"`
+	RequestID string `json:"request_id,omitempty" doc:"ID of the request, as returned in the X-Request-ID header"`
+}
+
+func init() {
+	// Every error produced by Huma, including request validation failures, goes through
+	// NewError, so overriding it gives all responses the same shape and a default code.
+	huma.NewError = newErrorModel
+}
+
+// newErrorModel builds an ErrorModel with the default code for the status
+func newErrorModel(status int, msg string, errs ...error) huma.StatusError {
+	details := make([]*huma.ErrorDetail, 0, len(errs))
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if detailer, ok := err.(huma.ErrorDetailer); ok {
+			details = append(details, detailer.ErrorDetail())
+			continue
+		}
+		details = append(details, &huma.ErrorDetail{Message: err.Error()})
+	}
+
+	return &ErrorModel{
+		ErrorModel: huma.ErrorModel{
+			Status: status,
+			Title:  http.StatusText(status),
+			Detail: msg,
+			Errors: details,
+		},
+		Code: codeForStatus(status),
+	}
+}
+
+// newProblem returns an error response with an explicit error code
+func newProblem(status int, code, msg string, errs ...error) huma.StatusError {
+	err := huma.NewError(status, msg, errs...)
+	if model, ok := err.(*ErrorModel); ok {
+		model.Code = code
+	}
+	return err
+}
+
+// codeForStatus returns the default error code for an HTTP status
+func codeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeInvalidRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusUnprocessableEntity:
+		return CodeValidationFailed
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeTimeout
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternalError
+	}
+	return CodeInvalidRequest
+}
+
+// problemFromError maps an error returned by the registry service to an error response.
+// msg is used as the detail for errors that do not map to a more specific status.
+func problemFromError(err error, msg string) huma.StatusError {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return newProblem(http.StatusNotFound, CodeNotFound, "Server not found")
+	case errors.Is(err, database.ErrInvalidVersion):
+		return newProblem(http.StatusConflict, CodeVersionConflict, err.Error())
+	case errors.Is(err, database.ErrAlreadyExists):
+		return newProblem(http.StatusConflict, CodeAlreadyExists, err.Error())
+	case errors.Is(err, database.ErrInvalidInput):
+		return newProblem(http.StatusUnprocessableEntity, CodeValidationFailed, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return newProblem(http.StatusGatewayTimeout, CodeTimeout, msg, err)
+	default:
+		return newProblem(http.StatusInternalServerError, CodeInternalError, msg, err)
+	}
+}
+
+// problemFromAdmissionError maps an error from the admission webhooks to an error response.
+// Denials carry the webhooks' warnings as Warning headers. Failures only name the webhook, and
+// are logged, as their errors can include the webhook's URL.
+func problemFromAdmissionError(ctx context.Context, err error) error {
+	var denied *admission.DeniedError
+	if errors.As(err, &denied) {
+		problem := newProblem(http.StatusForbidden, CodeAdmissionDenied, denied.Error())
+		if len(denied.Warnings) == 0 {
+			return problem
+		}
+		return huma.ErrorWithHeaders(problem, http.Header{"Warning": warningHeaders(denied.Warnings)})
+	}
+	slog.ErrorContext(ctx, "Admission webhook failed", slog.Any("error", err))
+	var failed *admission.FailedError
+	if errors.As(err, &failed) {
+		return newProblem(http.StatusServiceUnavailable, CodeUnavailable, fmt.Sprintf("Admission webhook %s failed", failed.Webhook))
+	}
+	return newProblem(http.StatusServiceUnavailable, CodeUnavailable, "Admission webhook failed")
+}
+
+// problemFromPolicyError maps an error from the publish policies to an error response, with a
+// detail for each violated policy
+func problemFromPolicyError(err error) huma.StatusError {
+	var violation *policy.ViolationError
+	if !errors.As(err, &violation) {
+		return newProblem(http.StatusInternalServerError, CodeInternalError, "Failed to evaluate publish policies", err)
+	}
+	details := make([]error, 0, len(violation.Violations))
+	for _, v := range violation.Violations {
+		details = append(details, &huma.ErrorDetail{Location: "policy." + v.Policy, Message: v.Message})
+	}
+	return newProblem(http.StatusForbidden, CodePolicyViolation, violation.Error(), details...)
+}
+
+// warningHeaders formats warnings as Warning header values
+func warningHeaders(warnings []string) []string {
+	headers := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		headers = append(headers, "299 - "+strconv.Quote(warning))
+	}
+	return headers
+}
@@ -0,0 +1,112 @@
+package v0_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/admission"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateServerErrorMapping(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "not found",
+			err:            database.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   v0.CodeNotFound,
+		},
+		{
+			name:           "older version",
+			err:            fmt.Errorf("%w: cannot update to an older version", database.ErrInvalidVersion),
+			expectedStatus: http.StatusConflict,
+			expectedCode:   v0.CodeVersionConflict,
+		},
+		{
+			name:           "already exists",
+			err:            database.ErrAlreadyExists,
+			expectedStatus: http.StatusConflict,
+			expectedCode:   v0.CodeAlreadyExists,
+		},
+		{
+			name:           "invalid input",
+			err:            fmt.Errorf("%w: repository URL is required", database.ErrInvalidInput),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedCode:   v0.CodeValidationFailed,
+		},
+		{
+			name:           "unexpected error",
+			err:            errors.New("connection reset"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   v0.CodeInternalError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			serverID := uuid.New().String()
+			mockRegistry := new(MockRegistryService)
+			mockRegistry.On("Update", serverID, mock.Anything).Return(tc.err)
+
+			mux := http.NewServeMux()
+			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+			cfg := &config.Config{JWTPrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"}
+			v0.RegisterServerWriteEndpoints(api, mockRegistry, cfg, admission.NewController(cfg), new(policy.Engine))
+
+			body, err := json.Marshal(model.ServerDetail{Name: "io.github.example/test-server"})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPut, "/v0/servers/"+serverID, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+			var problem v0.ErrorModel
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+			assert.Equal(t, tc.expectedStatus, problem.Status)
+			assert.Equal(t, tc.expectedCode, problem.Code)
+
+			mockRegistry.AssertExpectations(t)
+		})
+	}
+}
+
+func TestValidationErrorCode(t *testing.T) {
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, new(MockRegistryService))
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/servers/not-a-uuid", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	var problem v0.ErrorModel
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+	assert.Equal(t, v0.CodeValidationFailed, problem.Code)
+	assert.NotEmpty(t, problem.Errors)
+}
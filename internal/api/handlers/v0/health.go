@@ -3,6 +3,7 @@ package v0
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"go.opentelemetry.io/otel/attribute"
@@ -12,14 +13,74 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
+const (
+	// RolePrimary is reported by write-capable instances
+	RolePrimary = "primary"
+	// RoleReplica is reported by read-only replicas
+	RoleReplica = "replica"
+)
+
+// FreshnessFunc reports when the registry data was last known to be up to date.
+// A zero time means freshness is unknown.
+type FreshnessFunc func() time.Time
+
+// ReadinessCheck reports whether a single dependency is ready to serve traffic
+type ReadinessCheck struct {
+	// Name identifies the dependency in the readiness response
+	Name string
+	// Check returns nil when the dependency is healthy
+	Check func(ctx context.Context) error
+}
+
+// readinessCheckTimeout bounds how long a single dependency check may take
+const readinessCheckTimeout = 2 * time.Second
+
 // HealthBody represents the health check response body
 type HealthBody struct {
-	Status         string `json:"status" example:"ok" doc:"Health status"`
-	GitHubClientID string `json:"github_client_id,omitempty" doc:"GitHub OAuth App Client ID"`
+	Status           string                 `json:"status" example:"ok" doc:"Health status"`
+	GitHubClientID   string                 `json:"github_client_id,omitempty" doc:"GitHub OAuth App Client ID"`
+	GitHubEnterprise []GitHubEnterpriseInfo `json:"github_enterprise,omitempty" doc:"GitHub Enterprise Server instances trusted for GitHub authentication"`
+	Role             string                 `json:"role" example:"primary" enum:"primary,replica" doc:"Whether this instance accepts writes"`
+	PrimaryURL       string                 `json:"primary_url,omitempty" doc:"Write-capable instance that replicas defer to"`
+	LastSyncedAt     *time.Time             `json:"last_synced_at,omitempty" doc:"When the registry data was last synced"`
+	DataAgeSeconds   *int64                 `json:"data_age_seconds,omitempty" doc:"Seconds since the registry data was last synced"`
+}
+
+// GitHubEnterpriseInfo tells publishers how to log in to a GitHub Enterprise Server instance
+type GitHubEnterpriseInfo struct {
+	Host      string `json:"host" example:"github.example.com" doc:"Hostname of the instance"`
+	Namespace string `json:"namespace" example:"com.example.github" doc:"Prefix of the instance's server names, followed by .<owner>/"`
+	OAuthURL  string `json:"oauth_url" example:"https://github.example.com" doc:"Base URL of the OAuth device flow endpoints"`
+	ClientID  string `json:"client_id,omitempty" doc:"GitHub App or OAuth App Client ID"`
+}
+
+// LivenessBody represents the liveness check response body
+type LivenessBody struct {
+	Status string `json:"status" example:"ok" doc:"Liveness status"`
+}
+
+// DependencyStatus is the readiness of a single dependency
+type DependencyStatus struct {
+	Status string `json:"status" example:"ok" enum:"ok,error" doc:"Dependency status"`
+	Error  string `json:"error,omitempty" doc:"Why the dependency is not ready"`
+}
+
+// ReadinessBody represents the readiness check response body
+type ReadinessBody struct {
+	Status string                      `json:"status" example:"ok" enum:"ok,degraded" doc:"Overall readiness status"`
+	Checks map[string]DependencyStatus `json:"checks" doc:"Readiness of each dependency"`
+}
+
+// ReadinessOutput is the readiness response, which is 503 when any dependency is not ready
+type ReadinessOutput struct {
+	Status int
+	Body   ReadinessBody
 }
 
 // RegisterHealthEndpoint registers the health check endpoint
-func RegisterHealthEndpoint(api huma.API, cfg *config.Config, metrics *telemetry.Metrics) {
+func RegisterHealthEndpoint(
+	api huma.API, cfg *config.Config, metrics *telemetry.Metrics, freshness FreshnessFunc, checks []ReadinessCheck,
+) {
 	huma.Register(api, huma.Operation{
 		OperationID: "get-health",
 		Method:      http.MethodGet,
@@ -29,19 +90,96 @@ func RegisterHealthEndpoint(api huma.API, cfg *config.Config, metrics *telemetry
 		Tags:        []string{"health"},
 	}, func(ctx context.Context, _ *struct{}) (*Response[HealthBody], error) {
 		// Record the health check metrics
-		recordHealthMetrics(ctx, metrics, "/v0/health", cfg.Version)
+		recordHealthMetrics(ctx, metrics, "/v0/health", cfg.Version, true)
+
+		body := HealthBody{
+			Status:         "ok",
+			GitHubClientID: cfg.GithubClientID,
+			Role:           RolePrimary,
+		}
+		for _, instance := range cfg.GitHubEnterpriseInstances {
+			body.GitHubEnterprise = append(body.GitHubEnterprise, GitHubEnterpriseInfo{
+				Host:      instance.Host,
+				Namespace: instance.Namespace,
+				OAuthURL:  instance.OAuthURL,
+				ClientID:  instance.ClientID,
+			})
+		}
+
+		if cfg.ReadOnly {
+			body.Role = RoleReplica
+			body.PrimaryURL = cfg.PrimaryURL
+		}
+
+		if freshness != nil {
+			if syncedAt := freshness(); !syncedAt.IsZero() {
+				age := int64(time.Since(syncedAt).Seconds())
+				body.LastSyncedAt = &syncedAt
+				body.DataAgeSeconds = &age
+			}
+		}
 
 		return &Response[HealthBody]{
-			Body: HealthBody{
-				Status:         "ok",
-				GitHubClientID: cfg.GithubClientID,
-			},
+			Body: body,
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-liveness",
+		Method:      http.MethodGet,
+		Path:        "/v0/health/live",
+		Summary:     "Liveness check",
+		Description: "Check that the process is running. Does not check any dependencies.",
+		Tags:        []string{"health"},
+	}, func(_ context.Context, _ *struct{}) (*Response[LivenessBody], error) {
+		return &Response[LivenessBody]{
+			Body: LivenessBody{Status: "ok"},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-readiness",
+		Method:      http.MethodGet,
+		Path:        "/v0/health/ready",
+		Summary:     "Readiness check",
+		Description: "Check that the registry and all of its dependencies are ready to serve traffic. Returns 503 when degraded.",
+		Tags:        []string{"health"},
+	}, func(ctx context.Context, _ *struct{}) (*ReadinessOutput, error) {
+		body := ReadinessBody{
+			Status: "ok",
+			Checks: make(map[string]DependencyStatus, len(checks)),
+		}
+
+		for _, check := range checks {
+			checkCtx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+			err := check.Check(checkCtx)
+			cancel()
+
+			if err != nil {
+				body.Status = "degraded"
+				body.Checks[check.Name] = DependencyStatus{Status: "error", Error: err.Error()}
+				continue
+			}
+			body.Checks[check.Name] = DependencyStatus{Status: "ok"}
+		}
+
+		ready := body.Status == "ok"
+		recordHealthMetrics(ctx, metrics, "/v0/health/ready", cfg.Version, ready)
+
+		status := http.StatusOK
+		if !ready {
+			status = http.StatusServiceUnavailable
+		}
+
+		return &ReadinessOutput{
+			Status: status,
+			Body:   body,
 		}, nil
 	})
 }
 
 // recordHealthMetrics records the health check metrics
-func recordHealthMetrics(ctx context.Context, metrics *telemetry.Metrics, path string, version string) {
+func recordHealthMetrics(ctx context.Context, metrics *telemetry.Metrics, path string, version string, healthy bool) {
 	attrs := []attribute.KeyValue{
 		attribute.String("path", path),
 		attribute.String("version", version),
@@ -49,5 +187,9 @@ func recordHealthMetrics(ctx context.Context, metrics *telemetry.Metrics, path s
 	}
 
 	// metric : Up status (1 = healthy, 0 = unhealthy)
-	metrics.Up.Record(ctx, 1, metric.WithAttributes(attrs...))
+	var up int64
+	if healthy {
+		up = 1
+	}
+	metrics.Up.Record(ctx, up, metric.WithAttributes(attrs...))
 }
@@ -2,9 +2,12 @@ package v0_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
@@ -34,6 +37,19 @@ func TestHealthEndpoint(t *testing.T) {
 				GitHubClientID: "test-github-client-id",
 			},
 		},
+		{
+			name: "returns replica role for read-only instances",
+			config: &config.Config{
+				ReadOnly:   true,
+				PrimaryURL: "https://registry.example.com",
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: v0.HealthBody{
+				Status:     "ok",
+				Role:       v0.RoleReplica,
+				PrimaryURL: "https://registry.example.com",
+			},
+		},
 		{
 			name: "returns health status without github client id",
 			config: &config.Config{
@@ -45,6 +61,18 @@ func TestHealthEndpoint(t *testing.T) {
 				GitHubClientID: "",
 			},
 		},
+		{
+			name: "returns GitHub Enterprise instances",
+			config: &config.Config{
+				GitHubEnterpriseInstances: config.GitHubEnterpriseInstances{{
+					Host:      "github.example.com",
+					Namespace: "com.example.github",
+					OAuthURL:  "https://github.example.com",
+					ClientID:  "enterprise-client-id",
+				}},
+			},
+			expectedStatus: http.StatusOK,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -56,7 +84,7 @@ func TestHealthEndpoint(t *testing.T) {
 			shutdownTelemetry, metrics, _ := telemetry.InitMetrics("test")
 
 			// Register the health endpoint
-			v0.RegisterHealthEndpoint(api, tc.config, metrics)
+			v0.RegisterHealthEndpoint(api, tc.config, metrics, nil, nil)
 
 			// Create a test request
 			req := httptest.NewRequest(http.MethodGet, "/v0/health", nil)
@@ -76,6 +104,19 @@ func TestHealthEndpoint(t *testing.T) {
 			body := w.Body.String()
 			assert.Contains(t, body, `"status":"ok"`)
 
+			if tc.config.ReadOnly {
+				assert.Contains(t, body, `"role":"replica"`)
+				assert.Contains(t, body, `"primary_url":"https://registry.example.com"`)
+			} else {
+				assert.Contains(t, body, `"role":"primary"`)
+			}
+
+			if len(tc.config.GitHubEnterpriseInstances) > 0 {
+				assert.Contains(t, body, `"github_enterprise":[{"host":"github.example.com","namespace":"com.example.github","oauth_url":"https://github.example.com","client_id":"enterprise-client-id"}]`)
+			} else {
+				assert.NotContains(t, body, `"github_enterprise"`)
+			}
+
 			if tc.config.GithubClientID != "" {
 				assert.Contains(t, body, `"github_client_id":"test-github-client-id"`)
 			} else {
@@ -84,3 +125,93 @@ func TestHealthEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestHealthEndpointDataFreshness(t *testing.T) {
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+
+	shutdownTelemetry, metrics, _ := telemetry.InitMetrics("test")
+	defer func() { _ = shutdownTelemetry(context.Background()) }()
+
+	syncedAt := time.Now().Add(-2 * time.Minute)
+	v0.RegisterHealthEndpoint(api, &config.Config{ReadOnly: true}, metrics, func() time.Time { return syncedAt }, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/health", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var body v0.HealthBody
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, v0.RoleReplica, body.Role)
+	if assert.NotNil(t, body.LastSyncedAt) && assert.NotNil(t, body.DataAgeSeconds) {
+		assert.WithinDuration(t, syncedAt, *body.LastSyncedAt, time.Second)
+		assert.GreaterOrEqual(t, *body.DataAgeSeconds, int64(120))
+	}
+}
+
+func TestLivenessAndReadinessEndpoints(t *testing.T) {
+	shutdownTelemetry, metrics, _ := telemetry.InitMetrics("test")
+	defer func() { _ = shutdownTelemetry(context.Background()) }()
+
+	testCases := []struct {
+		name           string
+		checks         []v0.ReadinessCheck
+		expectedStatus int
+		expectedBody   v0.ReadinessBody
+	}{
+		{
+			name: "all dependencies ready",
+			checks: []v0.ReadinessCheck{
+				{Name: "database", Check: func(_ context.Context) error { return nil }},
+				{Name: "jwt_key", Check: func(_ context.Context) error { return nil }},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: v0.ReadinessBody{
+				Status: "ok",
+				Checks: map[string]v0.DependencyStatus{
+					"database": {Status: "ok"},
+					"jwt_key":  {Status: "ok"},
+				},
+			},
+		},
+		{
+			name: "degraded dependency",
+			checks: []v0.ReadinessCheck{
+				{Name: "database", Check: func(_ context.Context) error { return errors.New("database is not reachable") }},
+				{Name: "jwt_key", Check: func(_ context.Context) error { return nil }},
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: v0.ReadinessBody{
+				Status: "degraded",
+				Checks: map[string]v0.DependencyStatus{
+					"database": {Status: "error", Error: "database is not reachable"},
+					"jwt_key":  {Status: "ok"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+			v0.RegisterHealthEndpoint(api, &config.Config{}, metrics, nil, tc.checks)
+
+			// Liveness never depends on the checks
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/health/live", nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Body.String(), `"status":"ok"`)
+
+			w = httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/health/ready", nil))
+			assert.Equal(t, tc.expectedStatus, w.Code)
+
+			var body v0.ReadinessBody
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tc.expectedBody, body)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package v0
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// RecordInstallInput represents the input for reporting an install
+type RecordInstallInput struct {
+	ID            string             `path:"id" doc:"Server ID (UUID)" format:"uuid"`
+	ForwardedFor  string             `header:"X-Forwarded-For" doc:"Set by proxies; only honored from the registry's trusted proxies" required:"false"`
+	Body          *RecordInstallBody `required:"false"`
+	remoteAddress string
+}
+
+// RecordInstallBody represents the optional request body for reporting an install
+type RecordInstallBody struct {
+	ClientID string `json:"client_id,omitempty" doc:"Opaque, random identifier of the installing client. Only a salted daily hash of it is stored" maxLength:"128" required:"false"`
+}
+
+// RecordInstallResponse represents the response body for reporting an install
+type RecordInstallResponse struct {
+	Counted bool `json:"counted" doc:"False if this client was already counted for this server version today"`
+}
+
+// Resolve captures the remote address, which is not otherwise available to handlers
+func (i *RecordInstallInput) Resolve(ctx huma.Context) []error {
+	i.remoteAddress = ctx.RemoteAddr()
+	return nil
+}
+
+// clients returns the keys identifying the installing client for de-duplication: its address,
+// and its client ID if given. The address is always included, so that rotating client IDs can't
+// inflate install counts.
+func (i *RecordInstallInput) clients(proxies config.TrustedProxies) []string {
+	clients := []string{"addr:" + clientAddress(i.remoteAddress, i.ForwardedFor, proxies)}
+	if i.Body != nil && i.Body.ClientID != "" {
+		clients = append(clients, "id:"+i.Body.ClientID)
+	}
+	return clients
+}
+
+// clientAddress returns the address of the client. X-Forwarded-For is followed from the right
+// only through trusted proxies, as the addresses before them can be set by anyone.
+func clientAddress(remoteAddress, forwardedFor string, proxies config.TrustedProxies) string {
+	address := remoteAddress
+	if host, _, err := net.SplitHostPort(remoteAddress); err == nil {
+		address = host
+	}
+
+	hops := strings.Split(forwardedFor, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(address)
+		if err != nil || !proxies.Trusts(addr) {
+			break
+		}
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			break
+		}
+		address = hop
+	}
+	return address
+}
+
+// RegisterInstallEndpoint registers the endpoint that clients and package managers call to report installs
+func RegisterInstallEndpoint(api huma.API, registry service.RegistryService, cfg *config.Config) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "record-install",
+		Method:        http.MethodPost,
+		Path:          "/v0/servers/{id}/installs",
+		Summary:       "Report an install",
+		Description:   "Report an install of a server version. Each client, and each client address, is counted at most once per server version and day.",
+		Tags:          []string{"servers"},
+		DefaultStatus: http.StatusAccepted,
+	}, func(ctx context.Context, input *RecordInstallInput) (*Response[RecordInstallResponse], error) {
+		counted, err := registry.RecordInstall(ctx, input.ID, input.clients(cfg.TrustedProxies)...)
+		if err != nil {
+			return nil, problemFromError(err, "Failed to record install")
+		}
+
+		return &Response[RecordInstallResponse]{
+			Body: RecordInstallResponse{Counted: counted},
+		}, nil
+	})
+}
@@ -0,0 +1,98 @@
+package v0_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/google/uuid"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordInstallEndpoint(t *testing.T) {
+	serverID := uuid.New().String()
+
+	var proxies config.TrustedProxies
+	require.NoError(t, proxies.UnmarshalText([]byte("192.0.2.0/24,10.0.0.0/8")))
+
+	testCases := []struct {
+		name           string
+		body           string
+		headers        map[string]string
+		proxies        config.TrustedProxies
+		setupMocks     func(*MockRegistryService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "client id is counted along with the address",
+			body: `{"client_id":"3f0c1b7e"}`,
+			setupMocks: func(registry *MockRegistryService) {
+				registry.On("RecordInstall", serverID, []string{"addr:192.0.2.1", "id:3f0c1b7e"}).Return(true, nil)
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedBody:   `"counted":true`,
+		},
+		{
+			name:    "forwarded address is ignored without trusted proxies",
+			headers: map[string]string{"X-Forwarded-For": "203.0.113.7"},
+			setupMocks: func(registry *MockRegistryService) {
+				registry.On("RecordInstall", serverID, []string{"addr:192.0.2.1"}).Return(false, nil)
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedBody:   `"counted":false`,
+		},
+		{
+			name:    "forwarded address is followed through trusted proxies",
+			headers: map[string]string{"X-Forwarded-For": "198.51.100.9, 203.0.113.7, 10.0.0.1"},
+			proxies: proxies,
+			setupMocks: func(registry *MockRegistryService) {
+				registry.On("RecordInstall", serverID, []string{"addr:203.0.113.7"}).Return(true, nil)
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedBody:   `"counted":true`,
+		},
+		{
+			name: "unknown server",
+			body: `{}`,
+			setupMocks: func(registry *MockRegistryService) {
+				registry.On("RecordInstall", serverID, []string{"addr:192.0.2.1"}).Return(false, database.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `"code":"not_found"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRegistry := new(MockRegistryService)
+			tc.setupMocks(mockRegistry)
+
+			mux := http.NewServeMux()
+			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+			v0.RegisterInstallEndpoint(api, mockRegistry, &config.Config{TrustedProxies: tc.proxies})
+
+			req := httptest.NewRequest(http.MethodPost, "/v0/servers/"+serverID+"/installs", bytes.NewBufferString(tc.body))
+			req.RemoteAddr = "192.0.2.1:1234"
+			if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			for name, value := range tc.headers {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tc.expectedBody)
+			mockRegistry.AssertExpectations(t)
+		})
+	}
+}
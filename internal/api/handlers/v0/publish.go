@@ -3,14 +3,23 @@ package v0
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/admission"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/policy"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
 // PublishServerInput represents the input for publishing a server
@@ -19,8 +28,18 @@ type PublishServerInput struct {
 	RawBody       []byte `body:"raw"`
 }
 
-// RegisterPublishEndpoint registers the publish endpoint
-func RegisterPublishEndpoint(api huma.API, registry service.RegistryService, cfg *config.Config) {
+// PublishServerOutput is the published server, with any warnings from admission webhooks
+type PublishServerOutput struct {
+	Warnings []string `header:"Warning" doc:"Warnings from admission webhooks"`
+	Body     model.ServerResponse
+}
+
+// RegisterPublishEndpoint registers the publish endpoint. Requests are reviewed by the admission
+// webhooks, and must follow the publish policies, before they are published.
+func RegisterPublishEndpoint(
+	api huma.API, registry service.RegistryService, cfg *config.Config, metrics *telemetry.Metrics,
+	admissions *admission.Controller, policies *policy.Engine,
+) {
 	// Create JWT manager for token validation
 	jwtManager := auth.NewJWTManager(cfg)
 
@@ -31,18 +50,12 @@ func RegisterPublishEndpoint(api huma.API, registry service.RegistryService, cfg
 		Summary:     "Publish MCP server",
 		Description: "Publish a new MCP server to the registry or update an existing one",
 		Tags:        []string{"publish"},
-	}, func(ctx context.Context, input *PublishServerInput) (*Response[model.ServerResponse], error) {
-		// Extract bearer token if provided
-		var token string
-		authHeader := input.Authorization
-		if authHeader != "" {
-			const bearerPrefix = "Bearer "
-			if len(authHeader) >= len(bearerPrefix) && strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
-				token = authHeader[len(bearerPrefix):]
-			} else {
-				token = authHeader
-			}
-		}
+	}, func(ctx context.Context, input *PublishServerInput) (_ *PublishServerOutput, err error) {
+		var (
+			serverName string
+			publishErr error
+		)
+		defer func() { recordPublish(ctx, metrics, serverName, err, publishErr) }()
 
 		// Validate that only allowed extension fields are present
 		if err := model.ValidatePublishRequestExtensions(input.RawBody); err != nil {
@@ -57,53 +70,174 @@ func RegisterPublishEndpoint(api huma.API, registry service.RegistryService, cfg
 
 		// Get server details from request body
 		serverDetail := publishRequest.Server
+		serverName = serverDetail.Name
 
-		// Determine auth method based on server namespace
-		var authMethod model.AuthMethod
-		if strings.HasPrefix(serverDetail.Name, "io.github.") {
-			authMethod = model.AuthMethodGitHubAT // or AuthMethodGitHubOIDC - both require GitHub auth
-		} else {
-			authMethod = model.AuthMethodNone
+		claims, err := authorizePublish(ctx, jwtManager, input.Authorization, "server", serverDetail.Name)
+		if err != nil {
+			return nil, err
 		}
 
-		// Validate authentication only if required by auth method or if token is provided
-		if authMethod != model.AuthMethodNone {
-			// GitHub auth method requires authentication
-			if token == "" {
-				return nil, huma.Error401Unauthorized("Authentication is required for this server namespace")
-			}
-
-			claims, err := jwtManager.ValidateToken(ctx, token)
-			if err != nil {
-				return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
-			}
-
-			// Verify that the token's repository matches the server being published
-			if !jwtManager.HasPermission(serverDetail.Name, auth.PermissionActionPublish, claims.Permissions) {
-				return nil, huma.Error403Forbidden("You do not have permission to publish this server")
-			}
-		} else if token != "" {
-			// If a token is provided but auth method is None, validate it anyway
-			claims, err := jwtManager.ValidateToken(ctx, token)
-			if err != nil {
-				return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
-			}
-
-			// Verify that the token's repository matches the server being published
-			if !jwtManager.HasPermission(serverDetail.Name, auth.PermissionActionPublish, claims.Permissions) {
-				return nil, huma.Error403Forbidden("You do not have permission to publish this server")
-			}
+		admitted, err := admissions.Admit(ctx, admission.OperationPublish, "", publishRequest, admission.CallerFromClaims(claims))
+		if err != nil {
+			return nil, problemFromAdmissionError(ctx, err)
+		}
+
+		if err := enforcePolicies(ctx, registry, policies, policy.Input{
+			Operation: policy.OperationPublish,
+			Server:    &admitted.Request.Server,
+			Claims:    claims,
+		}, ""); err != nil {
+			return nil, err
 		}
 
 		// Publish the server with extensions
-		publishedServer, err := registry.Publish(publishRequest)
-		if err != nil {
-			return nil, huma.Error500InternalServerError("Failed to publish server", err)
+		publishedServer, publishErr := registry.Publish(ctx, admitted.Request)
+		if publishErr != nil {
+			return nil, problemFromError(publishErr, "Failed to publish server")
 		}
 
 		// Return the published server in extension wrapper format
-		return &Response[model.ServerResponse]{
-			Body: *publishedServer,
+		return &PublishServerOutput{
+			Warnings: warningHeaders(admitted.Warnings),
+			Body:     *publishedServer,
 		}, nil
 	})
 }
+
+// authorizePublish checks that the Authorization header grants permission to publish the named
+// entity, a "server" or "client", and returns the token's claims, or nil without a token.
+// Names in the io.github namespace always require a token.
+func authorizePublish(ctx context.Context, jwtManager *auth.JWTManager, authHeader, entity, name string) (*auth.JWTClaims, error) {
+	token := bearerToken(authHeader)
+
+	// Determine auth method based on namespace
+	var authMethod model.AuthMethod
+	if strings.HasPrefix(name, "io.github.") {
+		authMethod = model.AuthMethodGitHubAT // or AuthMethodGitHubOIDC - both require GitHub auth
+	} else {
+		authMethod = model.AuthMethodNone
+	}
+
+	// Validate authentication only if required by auth method or if token is provided
+	if authMethod != model.AuthMethodNone && token == "" {
+		return nil, huma.Error401Unauthorized(fmt.Sprintf("Authentication is required for this %s namespace", entity))
+	}
+	if token == "" {
+		return nil, nil
+	}
+
+	claims, err := jwtManager.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+	}
+
+	// Verify that the token's permissions match the name being published
+	if !jwtManager.HasPermission(name, auth.PermissionActionPublish, claims.Permissions) {
+		return nil, huma.Error403Forbidden(fmt.Sprintf("You do not have permission to publish this %s", entity))
+	}
+
+	return claims, nil
+}
+
+// authenticate returns the claims of the token in an optional Authorization header, or nil without one
+func authenticate(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (*auth.JWTClaims, error) {
+	token := bearerToken(authHeader)
+	if token == "" {
+		return nil, nil
+	}
+	claims, err := jwtManager.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+	}
+	return claims, nil
+}
+
+// bearerToken extracts the token from an Authorization header, with or without the Bearer prefix
+func bearerToken(authHeader string) string {
+	const bearerPrefix = "Bearer "
+	if len(authHeader) >= len(bearerPrefix) && strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return authHeader[len(bearerPrefix):]
+	}
+	return authHeader
+}
+
+// enforcePolicies evaluates the publish policies that apply to input. With an existingID, as for
+// updates, they're evaluated against that record; otherwise against the latest published
+// version of the server.
+func enforcePolicies(ctx context.Context, registry service.RegistryService, policies *policy.Engine, input policy.Input, existingID string) error {
+	if !policies.Applies(input.Operation) {
+		return nil
+	}
+
+	if existingID != "" {
+		existing, err := registry.GetByID(ctx, existingID)
+		if err != nil {
+			return problemFromError(err, "Failed to get server")
+		}
+		input.Latest = existing
+	} else {
+		latest, _, err := registry.List(ctx, map[string]any{"name": input.Server.Name}, "", 1)
+		if err != nil {
+			return problemFromError(err, "Failed to get the latest version of the server")
+		}
+		if len(latest) > 0 {
+			input.Latest = &latest[0]
+		}
+	}
+
+	if err := policies.Evaluate(ctx, input); err != nil {
+		return problemFromPolicyError(err)
+	}
+
+	// A renamed server must also follow the policies of the name it had, so renaming it can't
+	// escape the rules of its namespace
+	if input.Latest != nil && input.Latest.Server.Name != input.Server.Name {
+		renamed := *input.Server
+		renamed.Name = input.Latest.Server.Name
+		input.Server = &renamed
+		if err := policies.Evaluate(ctx, input); err != nil {
+			return problemFromPolicyError(err)
+		}
+	}
+	return nil
+}
+
+// recordPublish records the outcome of a publish attempt, labelled by the namespace of the server.
+// publishErr is the error returned by the registry service, if the request got that far.
+func recordPublish(ctx context.Context, metrics *telemetry.Metrics, serverName string, err, publishErr error) {
+	status := http.StatusOK
+	var statusErr huma.StatusError
+	if errors.As(err, &statusErr) {
+		status = statusErr.GetStatus()
+	}
+
+	outcome := "success"
+	switch {
+	case err == nil:
+	case errors.Is(publishErr, database.ErrInvalidVersion):
+		outcome = "version_conflict"
+	case status == http.StatusUnauthorized:
+		outcome = "unauthorized"
+	case status == http.StatusForbidden:
+		outcome = "forbidden"
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		outcome = "invalid_request"
+	default:
+		outcome = "error"
+	}
+
+	metrics.Publishes.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("namespace", publishNamespace(serverName)),
+		attribute.String("status", strconv.Itoa(status)),
+		attribute.String("outcome", outcome),
+	))
+}
+
+// publishNamespace returns the namespace part of a server name (everything before the first "/")
+func publishNamespace(serverName string) string {
+	namespace, _, found := strings.Cut(serverName, "/")
+	if !found || namespace == "" {
+		return "unknown"
+	}
+	return namespace
+}
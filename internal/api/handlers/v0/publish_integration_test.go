@@ -13,10 +13,12 @@ import (
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/modelcontextprotocol/registry/internal/admission"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/policy"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -50,7 +52,7 @@ func TestPublishIntegration(t *testing.T) {
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
 
 	// Register the endpoint
-	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+	v0.RegisterPublishEndpoint(api, registryService, testConfig, newNoopMetrics(t), admission.NewController(testConfig), new(policy.Engine))
 
 	t.Run("successful publish with GitHub auth", func(t *testing.T) {
 		publishReq := model.PublishRequest{
@@ -221,3 +223,234 @@ func TestPublishIntegration(t *testing.T) {
 		assert.Contains(t, rr.Body.String(), "You do not have permission to publish this server")
 	})
 }
+
+func TestPublishAdmission(t *testing.T) {
+	var reviews []admission.Review
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var review admission.Review
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&review))
+		reviews = append(reviews, review)
+
+		response := admission.Response{UID: review.UID, Allowed: true}
+		switch review.Request.Server.Description {
+		case "denied":
+			response.Allowed = false
+			response.Message = "license is not allowed"
+			response.Warnings = []string{"license MIT-0 is unknown"}
+		case "mutated":
+			mutated := review.Request
+			mutated.Server.Description = "reviewed"
+			response.Request = &mutated
+			response.Warnings = []string{"description was rewritten"}
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer webhook.Close()
+
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+	require.NoError(t, testConfig.AdmissionWebhooks.UnmarshalText([]byte(`[{"name": "policy", "url": "`+webhook.URL+`"}]`)))
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, service.NewFakeRegistryService(), testConfig, newNoopMetrics(t), admission.NewController(testConfig), new(policy.Engine))
+
+	token, err := generateIntegrationTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:        model.AuthMethodGitHubAT,
+		AuthMethodSubject: "testuser",
+		Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.testuser/*"}},
+	})
+	require.NoError(t, err)
+
+	publish := func(version, description string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(model.PublishRequest{Server: model.ServerDetail{
+			Name:          "io.github.testuser/admitted-server",
+			Description:   description,
+			Repository:    model.Repository{URL: "https://github.com/testuser/admitted-server", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: version},
+		}})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("webhooks see the request and caller", func(t *testing.T) {
+		rr := publish("1.0.0", "allowed")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		require.NotEmpty(t, reviews)
+		review := reviews[len(reviews)-1]
+		assert.Equal(t, admission.OperationPublish, review.Operation)
+		assert.Equal(t, "io.github.testuser/admitted-server", review.Request.Server.Name)
+		require.NotNil(t, review.Caller)
+		assert.Equal(t, "testuser", review.Caller.Subject)
+		assert.Empty(t, rr.Header().Values("Warning"))
+	})
+
+	t.Run("denied requests are not published", func(t *testing.T) {
+		rr := publish("1.0.1", "denied")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Equal(t, []string{`299 - "policy: license MIT-0 is unknown"`}, rr.Header().Values("Warning"))
+
+		var problem v0.ErrorModel
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&problem))
+		assert.Equal(t, v0.CodeAdmissionDenied, problem.Code)
+		assert.Contains(t, problem.Detail, "license is not allowed")
+	})
+
+	t.Run("mutations are published", func(t *testing.T) {
+		rr := publish("1.0.2", "mutated")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, []string{`299 - "policy: description was rewritten"`}, rr.Header().Values("Warning"))
+
+		var response model.ServerResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		assert.Equal(t, "reviewed", response.Server.Description)
+	})
+
+	t.Run("unreachable webhooks fail closed", func(t *testing.T) {
+		webhook.Close()
+		rr := publish("1.0.3", "allowed")
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+		// Only the webhook's name is returned, not its URL or the connection error
+		var problem v0.ErrorModel
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&problem))
+		assert.Equal(t, v0.CodeUnavailable, problem.Code)
+		assert.Equal(t, "Admission webhook policy failed", problem.Detail)
+		assert.Empty(t, problem.Errors)
+	})
+}
+
+func TestPublishPolicies(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+	require.NoError(t, testConfig.PublishPolicies.UnmarshalText([]byte(`[
+		{
+			"name": "https-remotes",
+			"operations": ["publish", "update"],
+			"expression": "server.?remotes.orValue([]).all(r, r.url.startsWith('https://'))",
+			"message": "remotes must use https"
+		},
+		{
+			"name": "no-reactivation",
+			"operations": ["update"],
+			"expression": "latest == null || latest.server.?status.orValue('active') != 'deprecated' || server.?status.orValue('active') == 'deprecated'",
+			"message": "deprecated servers can't be re-activated"
+		},
+		{
+			"name": "authenticated-deletes",
+			"operations": ["delete"],
+			"expression": "claims != null && claims.permissions.exists(p, p.action == 'edit')"
+		}
+	]`)))
+	policies, err := policy.NewEngine(testConfig)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	registry := service.NewFakeRegistryService()
+	controller := admission.NewController(testConfig)
+	v0.RegisterPublishEndpoint(api, registry, testConfig, newNoopMetrics(t), controller, policies)
+	v0.RegisterServerWriteEndpoints(api, registry, testConfig, controller, policies)
+
+	policyServer := func(version string, status model.ServerStatus, remoteURL string) model.ServerDetail {
+		return model.ServerDetail{
+			Name:          "com.example/policy-server",
+			Status:        status,
+			Repository:    model.Repository{URL: "https://github.com/example/policy-server", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: version},
+			Remotes:       []model.Remote{{TransportType: "sse", URL: remoteURL}},
+		}
+	}
+	publish := func(version, remoteURL string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(model.PublishRequest{Server: policyServer(version, "", remoteURL)})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("violations are rejected", func(t *testing.T) {
+		rr := publish("1.0.0", "http://mcp.example.com/sse")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		var problem v0.ErrorModel
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&problem))
+		assert.Equal(t, v0.CodePolicyViolation, problem.Code)
+		require.Len(t, problem.Errors, 1)
+		assert.Equal(t, "policy.https-remotes", problem.Errors[0].Location)
+		assert.Equal(t, "remotes must use https", problem.Errors[0].Message)
+	})
+
+	rr := publish("1.0.0", "https://mcp.example.com/sse")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var published model.ServerResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&published))
+	id, _ := published.XIOModelContextProtocolRegistry.(map[string]any)["id"].(string)
+	require.NotEmpty(t, id)
+
+	deleteServer := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, "/v0/servers/"+id, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("delete policies see the caller", func(t *testing.T) {
+		rr := deleteServer("")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		token, err := generateIntegrationTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        model.AuthMethodGitHubAT,
+			AuthMethodSubject: "maintainer",
+			Permissions:       []auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "com.example/*"}},
+		})
+		require.NoError(t, err)
+		rr = deleteServer(token)
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+
+	t.Run("invalid tokens are rejected", func(t *testing.T) {
+		rr := deleteServer("not-a-token")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("updates are checked against the version being updated", func(t *testing.T) {
+		body, err := json.Marshal(model.PublishRequest{Server: policyServer("2.0.0", model.ServerStatusDeprecated, "https://mcp.example.com/sse")})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var old model.ServerResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&old))
+		oldID, _ := old.XIOModelContextProtocolRegistry.(map[string]any)["id"].(string)
+
+		// The latest version is active, but the old one being updated is deprecated
+		rr = publish("2.0.1", "https://mcp.example.com/sse")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		body, err = json.Marshal(policyServer("2.0.0", model.ServerStatusActive, "https://mcp.example.com/sse"))
+		require.NoError(t, err)
+		req = httptest.NewRequest(http.MethodPut, "/v0/servers/"+oldID, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr = httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), "deprecated servers can't be re-activated")
+	})
+}
@@ -8,19 +8,26 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/modelcontextprotocol/registry/internal/admission"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/policy"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
 )
 
 // MockRegistryService is a mock implementation of the RegistryService interface
@@ -28,12 +35,12 @@ type MockRegistryService struct {
 	mock.Mock
 }
 
-func (m *MockRegistryService) List(cursor string, limit int) ([]model.ServerResponse, string, error) {
-	args := m.Called(cursor, limit)
+func (m *MockRegistryService) List(_ context.Context, filter map[string]any, cursor string, limit int) ([]model.ServerResponse, string, error) {
+	args := m.Called(filter, cursor, limit)
 	return args.Get(0).([]model.ServerResponse), args.String(1), args.Error(2)
 }
 
-func (m *MockRegistryService) GetByID(id string) (*model.ServerResponse, error) {
+func (m *MockRegistryService) GetByID(_ context.Context, id string) (*model.ServerResponse, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -41,7 +48,7 @@ func (m *MockRegistryService) GetByID(id string) (*model.ServerResponse, error)
 	return args.Get(0).(*model.ServerResponse), args.Error(1)
 }
 
-func (m *MockRegistryService) Publish(request model.PublishRequest) (*model.ServerResponse, error) {
+func (m *MockRegistryService) Publish(_ context.Context, request model.PublishRequest) (*model.ServerResponse, error) {
 	args := m.Called(request)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -49,16 +56,65 @@ func (m *MockRegistryService) Publish(request model.PublishRequest) (*model.Serv
 	return args.Get(0).(*model.ServerResponse), args.Error(1)
 }
 
-func (m *MockRegistryService) Update(id string, serverDetail *model.ServerDetail) error {
+func (m *MockRegistryService) Update(_ context.Context, id string, serverDetail *model.ServerDetail) error {
 	args := m.Called(id, serverDetail)
 	return args.Error(0)
 }
 
-func (m *MockRegistryService) Delete(id string) error {
+func (m *MockRegistryService) Delete(_ context.Context, id string) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
+func (m *MockRegistryService) ListClients(_ context.Context, filter map[string]any, cursor string, limit int) ([]model.ClientResponse, string, error) {
+	args := m.Called(filter, cursor, limit)
+	return args.Get(0).([]model.ClientResponse), args.String(1), args.Error(2)
+}
+
+func (m *MockRegistryService) GetClientByID(_ context.Context, id string) (*model.ClientResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ClientResponse), args.Error(1)
+}
+
+func (m *MockRegistryService) PublishClient(_ context.Context, request model.PublishClientRequest) (*model.ClientResponse, error) {
+	args := m.Called(request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ClientResponse), args.Error(1)
+}
+
+func (m *MockRegistryService) RecordInstall(_ context.Context, id string, clients ...string) (bool, error) {
+	args := m.Called(id, clients)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRegistryService) ConsumeAuthNonce(_ context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	args := m.Called(nonce, expiresAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRegistryService) GitHubOIDCPolicies(_ context.Context, host, owner string) ([]model.GitHubOIDCPolicy, error) {
+	args := m.Called(host, owner)
+	return args.Get(0).([]model.GitHubOIDCPolicy), args.Error(1)
+}
+
+func (m *MockRegistryService) SetGitHubOIDCPolicies(_ context.Context, host, owner string, policies []model.GitHubOIDCPolicy) error {
+	args := m.Called(host, owner, policies)
+	return args.Error(0)
+}
+
+// Helper function to create metrics that are not exported anywhere
+func newNoopMetrics(t *testing.T) *telemetry.Metrics {
+	t.Helper()
+	metrics, err := telemetry.NewMetrics(noop.NewMeterProvider().Meter("test"))
+	require.NoError(t, err)
+	return metrics
+}
+
 // Helper function to generate a valid JWT token for testing
 func generateTestJWTToken(cfg *config.Config, claims auth.JWTClaims) (string, error) {
 	jwtManager := auth.NewJWTManager(cfg)
@@ -218,6 +274,29 @@ func TestPublishEndpoint(t *testing.T) {
 			expectedStatus: http.StatusInternalServerError,
 			expectedError:  "Failed to publish server",
 		},
+		{
+			name: "version conflict",
+			requestBody: model.PublishRequest{
+				Server: model.ServerDetail{
+					Name:        "example/test-server",
+					Description: "A test server",
+					VersionDetail: model.VersionDetail{
+						Version: "1.0.0",
+					},
+				},
+			},
+			tokenClaims: &auth.JWTClaims{
+				AuthMethod: model.AuthMethodNone,
+				Permissions: []auth.Permission{
+					{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+				},
+			},
+			setupMocks: func(registry *MockRegistryService) {
+				registry.On("Publish", mock.AnythingOfType("model.PublishRequest")).Return(nil, fmt.Errorf("%w: version must be greater than existing version 1.0.0", database.ErrInvalidVersion))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  `"code":"version_conflict"`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -233,7 +312,7 @@ func TestPublishEndpoint(t *testing.T) {
 			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
 
 			// Register the endpoint with test config
-			v0.RegisterPublishEndpoint(api, mockRegistry, testConfig)
+			v0.RegisterPublishEndpoint(api, mockRegistry, testConfig, newNoopMetrics(t), admission.NewController(testConfig), new(policy.Engine))
 
 			// Prepare request body
 			var requestBody []byte
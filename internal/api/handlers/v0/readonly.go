@@ -0,0 +1,107 @@
+package v0
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// readOnlyServerInput matches the path of the server write endpoints
+type readOnlyServerInput struct {
+	ID string `path:"id" doc:"Server ID (UUID)"`
+}
+
+// readOnlyAuthInput matches the path of the auth token endpoints
+type readOnlyAuthInput struct {
+	Method string `path:"method" doc:"Authentication method"`
+}
+
+// readOnlyOwnerInput matches the path of the GitHub OIDC policy endpoints
+type readOnlyOwnerInput struct {
+	Owner string `path:"owner" doc:"GitHub user or organization"`
+}
+
+// RegisterReadOnlyEndpoints registers handlers that reject write requests on read-only replicas.
+// They take the place of the server and client publish, server update/delete, install, auth token
+// and GitHub OIDC policy endpoints. Reading policies is rejected too, since it needs a registry
+// token that replicas don't issue.
+func RegisterReadOnlyEndpoints(api huma.API, cfg *config.Config) {
+	message := "This registry is a read-only replica"
+	if cfg.PrimaryURL != "" {
+		message = fmt.Sprintf("%s; send write requests to the primary at %s", message, cfg.PrimaryURL)
+	}
+
+	reject := func() error {
+		return newProblem(http.StatusMethodNotAllowed, CodeReadOnlyReplica, message)
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "publish-server-read-only",
+		Method:      http.MethodPost,
+		Path:        "/v0/publish",
+		Hidden:      true,
+	}, func(_ context.Context, _ *struct{}) (*struct{}, error) {
+		return nil, reject()
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "publish-client-read-only",
+		Method:      http.MethodPost,
+		Path:        "/v0/clients",
+		Hidden:      true,
+	}, func(_ context.Context, _ *struct{}) (*struct{}, error) {
+		return nil, reject()
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-server-read-only",
+		Method:      http.MethodPut,
+		Path:        "/v0/servers/{id}",
+		Hidden:      true,
+	}, func(_ context.Context, _ *readOnlyServerInput) (*struct{}, error) {
+		return nil, reject()
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-server-read-only",
+		Method:      http.MethodDelete,
+		Path:        "/v0/servers/{id}",
+		Hidden:      true,
+	}, func(_ context.Context, _ *readOnlyServerInput) (*struct{}, error) {
+		return nil, reject()
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "record-install-read-only",
+		Method:      http.MethodPost,
+		Path:        "/v0/servers/{id}/installs",
+		Hidden:      true,
+	}, func(_ context.Context, _ *readOnlyServerInput) (*struct{}, error) {
+		return nil, reject()
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "exchange-token-read-only",
+		Method:      http.MethodPost,
+		Path:        "/v0/auth/{method}",
+		Hidden:      true,
+	}, func(_ context.Context, _ *readOnlyAuthInput) (*struct{}, error) {
+		return nil, reject()
+	})
+
+	for _, method := range []string{http.MethodGet, http.MethodPut} {
+		huma.Register(api, huma.Operation{
+			OperationID: strings.ToLower(method) + "-github-oidc-policies-read-only",
+			Method:      method,
+			Path:        "/v0/auth/github-oidc/policies/{owner}",
+			Hidden:      true,
+		}, func(_ context.Context, _ *readOnlyOwnerInput) (*struct{}, error) {
+			return nil, reject()
+		})
+	}
+}
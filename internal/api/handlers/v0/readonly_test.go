@@ -0,0 +1,54 @@
+package v0_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestReadOnlyEndpoints(t *testing.T) {
+	cfg := &config.Config{
+		ReadOnly:   true,
+		PrimaryURL: "https://registry.example.com",
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterReadOnlyEndpoints(api, cfg)
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+	}{
+		{name: "publish", method: http.MethodPost, path: "/v0/publish"},
+		{name: "update server", method: http.MethodPut, path: "/v0/servers/550e8400-e29b-41d4-a716-446655440000"},
+		{name: "delete server", method: http.MethodDelete, path: "/v0/servers/550e8400-e29b-41d4-a716-446655440000"},
+		{name: "github token exchange", method: http.MethodPost, path: "/v0/auth/github-at"},
+		{name: "dns token exchange", method: http.MethodPost, path: "/v0/auth/dns"},
+		{name: "get github oidc policies", method: http.MethodGet, path: "/v0/auth/github-oidc/policies/octo-org"},
+		{name: "set github oidc policies", method: http.MethodPut, path: "/v0/auth/github-oidc/policies/octo-org"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(`{}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+			assert.Contains(t, w.Body.String(), "read-only replica")
+			assert.Contains(t, w.Body.String(), "https://registry.example.com")
+		})
+	}
+}
@@ -6,15 +6,15 @@ import (
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/admission"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/policy"
 	"github.com/modelcontextprotocol/registry/internal/service"
 )
 
-// Constants for error messages
-const (
-	ErrRecordNotFound = "record not found"
-)
-
 // Metadata contains pagination metadata
 type Metadata struct {
 	NextCursor string `json:"next_cursor,omitempty"`
@@ -24,8 +24,33 @@ type Metadata struct {
 
 // ListServersInput represents the input for listing servers
 type ListServersInput struct {
-	Cursor string `query:"cursor" doc:"Pagination cursor (UUID)" format:"uuid" required:"false"`
-	Limit  int    `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100"`
+	Cursor      string `query:"cursor" doc:"Pagination cursor (UUID)" format:"uuid" required:"false"`
+	Limit       int    `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100"`
+	Sort        string `query:"sort" doc:"Sort order: by ID, or by installs across all versions (most first)" enum:"id,installs" default:"id"`
+	MinInstalls int    `query:"min_installs" doc:"Only include servers with at least this many installs across all versions" minimum:"0" required:"false"`
+	Name        string `query:"name" doc:"Only include the server with this name" required:"false"`
+	Version     string `query:"version" doc:"Only include this version, which need not be the latest" required:"false"`
+}
+
+// listFilter converts the list query parameters to database filters
+func listFilter(input *ListServersInput) map[string]any {
+	filter := map[string]any{}
+	if input.Sort == database.SortInstalls {
+		filter[database.FilterSort] = database.SortInstalls
+	}
+	if input.MinInstalls > 0 {
+		filter[database.FilterMinInstalls] = input.MinInstalls
+	}
+	if input.Name != "" {
+		filter["name"] = input.Name
+	}
+	if input.Version != "" {
+		filter["version"] = input.Version
+	}
+	if len(filter) == 0 {
+		return nil
+	}
+	return filter
 }
 
 // ListServersBody represents the paginated server list response body
@@ -41,8 +66,9 @@ type ServerDetailInput struct {
 
 // UpdateServerInput represents the input for updating server details
 type UpdateServerInput struct {
-	ID   string                `path:"id" doc:"Server ID (UUID)" format:"uuid"`
-	Body model.ServerDetail `json:"body"`
+	Authorization string             `header:"Authorization" doc:"Optional Registry JWT token, passed to admission webhooks and publish policies" required:"false"`
+	ID            string             `path:"id" doc:"Server ID (UUID)" format:"uuid"`
+	Body          model.ServerDetail `json:"body"`
 }
 
 // UpdateServerBody represents the response body for update operations
@@ -51,9 +77,16 @@ type UpdateServerBody struct {
 	ID      string `json:"id"`
 }
 
+// UpdateServerOutput is the update response, with any warnings from admission webhooks
+type UpdateServerOutput struct {
+	Warnings []string `header:"Warning" doc:"Warnings from admission webhooks"`
+	Body     UpdateServerBody
+}
+
 // DeleteServerInput represents the input for deleting a server
 type DeleteServerInput struct {
-	ID string `path:"id" doc:"Server ID (UUID)" format:"uuid"`
+	Authorization string `header:"Authorization" doc:"Optional Registry JWT token, passed to publish policies" required:"false"`
+	ID            string `path:"id" doc:"Server ID (UUID)" format:"uuid"`
 }
 
 // DeleteServerBody represents the response body for delete operations
@@ -62,7 +95,7 @@ type DeleteServerBody struct {
 	ID      string `json:"id"`
 }
 
-// RegisterServersEndpoints registers all server-related endpoints
+// RegisterServersEndpoints registers the read-only server endpoints
 func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 	// List servers endpoint
 	huma.Register(api, huma.Operation{
@@ -72,7 +105,7 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 		Summary:     "List MCP servers",
 		Description: "Get a paginated list of MCP servers from the registry",
 		Tags:        []string{"servers"},
-	}, func(_ context.Context, input *ListServersInput) (*Response[ListServersBody], error) {
+	}, func(ctx context.Context, input *ListServersInput) (*Response[ListServersBody], error) {
 		// Validate cursor if provided
 		if input.Cursor != "" {
 			_, err := uuid.Parse(input.Cursor)
@@ -82,9 +115,9 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 		}
 
 		// Get paginated results
-		servers, nextCursor, err := registry.List(input.Cursor, input.Limit)
+		servers, nextCursor, err := registry.List(ctx, listFilter(input), input.Cursor, input.Limit)
 		if err != nil {
-			return nil, huma.Error500InternalServerError("Failed to get registry list", err)
+			return nil, problemFromError(err, "Failed to get registry list")
 		}
 
 		// Build response body
@@ -113,20 +146,26 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 		Summary:     "Get MCP server details",
 		Description: "Get detailed information about a specific MCP server",
 		Tags:        []string{"servers"},
-	}, func(_ context.Context, input *ServerDetailInput) (*Response[model.ServerResponse], error) {
+	}, func(ctx context.Context, input *ServerDetailInput) (*Response[model.ServerResponse], error) {
 		// Get the server details from the registry service
-		serverDetail, err := registry.GetByID(input.ID)
+		serverDetail, err := registry.GetByID(ctx, input.ID)
 		if err != nil {
-			if err.Error() == ErrRecordNotFound {
-				return nil, huma.Error404NotFound("Server not found")
-			}
-			return nil, huma.Error500InternalServerError("Failed to get server details", err)
+			return nil, problemFromError(err, "Failed to get server details")
 		}
 
 		return &Response[model.ServerResponse]{
 			Body: *serverDetail,
 		}, nil
 	})
+}
+
+// RegisterServerWriteEndpoints registers the endpoints that modify existing servers. Updates are
+// reviewed by the admission webhooks, and updates and deletes must follow the publish policies.
+func RegisterServerWriteEndpoints(
+	api huma.API, registry service.RegistryService, cfg *config.Config, admissions *admission.Controller, policies *policy.Engine,
+) {
+	// Create JWT manager for the optional token
+	jwtManager := auth.NewJWTManager(cfg)
 
 	// Update server details endpoint
 	huma.Register(api, huma.Operation{
@@ -136,29 +175,39 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 		Summary:     "Update MCP server details",
 		Description: "Update the details of an existing MCP server",
 		Tags:        []string{"servers"},
-	}, func(_ context.Context, input *UpdateServerInput) (*Response[UpdateServerBody], error) {
+	}, func(ctx context.Context, input *UpdateServerInput) (*UpdateServerOutput, error) {
 		// Validate required fields
 		if input.Body.Name == "" {
-			return nil, huma.Error400BadRequest("Name is required")
+			return nil, newProblem(http.StatusUnprocessableEntity, CodeValidationFailed, "Name is required")
+		}
+
+		claims, err := authenticate(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		admitted, err := admissions.Admit(ctx, admission.OperationUpdate, input.ID, model.PublishRequest{Server: input.Body}, admission.CallerFromClaims(claims))
+		if err != nil {
+			return nil, problemFromAdmissionError(ctx, err)
+		}
+
+		if err := enforcePolicies(ctx, registry, policies, policy.Input{
+			Operation: policy.OperationUpdate,
+			Server:    &admitted.Request.Server,
+			Claims:    claims,
+		}, input.ID); err != nil {
+			return nil, err
 		}
 
 		// Call the update method on the registry service
-		err := registry.Update(input.ID, &input.Body)
+		err = registry.Update(ctx, input.ID, &admitted.Request.Server)
 		if err != nil {
-			// Check for specific error types and return appropriate HTTP status codes
-			if err.Error() == ErrRecordNotFound {
-				return nil, huma.Error404NotFound("Server not found")
-			}
-			if err.Error() == "invalid version: cannot update to an older version" {
-				return nil, huma.Error400BadRequest("Invalid version: cannot update to an older version")
-			}
-			if err.Error() == "record already exists" {
-				return nil, huma.Error409Conflict("A server with this version already exists")
-			}
-			return nil, huma.Error500InternalServerError("Failed to update server details", err)
+			// Domain errors map to 404, 409 or 422; anything else is a 500
+			return nil, problemFromError(err, "Failed to update server details")
 		}
 
-		return &Response[UpdateServerBody]{
+		return &UpdateServerOutput{
+			Warnings: warningHeaders(admitted.Warnings),
 			Body: UpdateServerBody{
 				Message: "Server updated successfully",
 				ID:      input.ID,
@@ -174,15 +223,30 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 		Summary:     "Delete MCP server",
 		Description: "Delete an MCP server from the registry",
 		Tags:        []string{"servers"},
-	}, func(_ context.Context, input *DeleteServerInput) (*Response[DeleteServerBody], error) {
-		// Call the delete method on the registry service
-		err := registry.Delete(input.ID)
+	}, func(ctx context.Context, input *DeleteServerInput) (*Response[DeleteServerBody], error) {
+		claims, err := authenticate(ctx, jwtManager, input.Authorization)
 		if err != nil {
-			// Check for specific error types and return appropriate HTTP status codes
-			if err.Error() == ErrRecordNotFound {
-				return nil, huma.Error404NotFound("Server not found")
+			return nil, err
+		}
+
+		if policies.Applies(policy.OperationDelete) {
+			existing, err := registry.GetByID(ctx, input.ID)
+			if err != nil {
+				return nil, problemFromError(err, "Failed to get server")
 			}
-			return nil, huma.Error500InternalServerError("Failed to delete server", err)
+			if err := enforcePolicies(ctx, registry, policies, policy.Input{
+				Operation: policy.OperationDelete,
+				Server:    &existing.Server,
+				Claims:    claims,
+			}, ""); err != nil {
+				return nil, err
+			}
+		}
+
+		// Call the delete method on the registry service
+		err = registry.Delete(ctx, input.ID)
+		if err != nil {
+			return nil, problemFromError(err, "Failed to delete server")
 		}
 
 		return &Response[DeleteServerBody]{
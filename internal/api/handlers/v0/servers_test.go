@@ -12,6 +12,7 @@ import (
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
 	"github.com/google/uuid"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -66,7 +67,7 @@ func TestServersListEndpoint(t *testing.T) {
 						},
 					},
 				}
-				registry.Mock.On("List", "", 30).Return(servers, "", nil)
+				registry.Mock.On("List", map[string]any(nil), "", 30).Return(servers, "", nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedServers: []model.ServerResponse{
@@ -130,7 +131,7 @@ func TestServersListEndpoint(t *testing.T) {
 					},
 				}
 				nextCursor := uuid.New().String()
-				registry.Mock.On("List", mock.AnythingOfType("string"), 10).Return(servers, nextCursor, nil)
+				registry.Mock.On("List", map[string]any(nil), mock.AnythingOfType("string"), 10).Return(servers, nextCursor, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedServers: []model.ServerResponse{
@@ -157,6 +158,33 @@ func TestServersListEndpoint(t *testing.T) {
 				Count:      1,
 			},
 		},
+		{
+			name:        "sort by installs with minimum",
+			queryParams: "?sort=installs&min_installs=5",
+			setupMocks: func(registry *MockRegistryService) {
+				filter := map[string]any{database.FilterSort: database.SortInstalls, database.FilterMinInstalls: 5}
+				registry.Mock.On("List", filter, "", 30).Return([]model.ServerResponse{}, "", nil)
+			},
+			expectedStatus:  http.StatusOK,
+			expectedServers: []model.ServerResponse{},
+		},
+		{
+			name:        "filter by name and version",
+			queryParams: "?name=io.github.example/server&version=1.0.0",
+			setupMocks: func(registry *MockRegistryService) {
+				filter := map[string]any{"name": "io.github.example/server", "version": "1.0.0"}
+				registry.Mock.On("List", filter, "", 30).Return([]model.ServerResponse{}, "", nil)
+			},
+			expectedStatus:  http.StatusOK,
+			expectedServers: []model.ServerResponse{},
+		},
+		{
+			name:           "invalid sort parameter",
+			queryParams:    "?sort=popularity",
+			setupMocks:     func(_ *MockRegistryService) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "validation failed",
+		},
 		{
 			name:           "successful list with limit capping at 100",
 			queryParams:    "?limit=150",
@@ -195,7 +223,7 @@ func TestServersListEndpoint(t *testing.T) {
 		{
 			name: "registry service error",
 			setupMocks: func(registry *MockRegistryService) {
-				registry.Mock.On("List", "", 30).Return([]model.ServerResponse{}, "", errors.New("database connection error"))
+				registry.Mock.On("List", map[string]any(nil), "", 30).Return([]model.ServerResponse{}, "", errors.New("database connection error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedError:  "Failed to get registry list",
@@ -306,7 +334,7 @@ func TestServersDetailEndpoint(t *testing.T) {
 			name:     "server not found",
 			serverID: uuid.New().String(),
 			setupMocks: func(registry *MockRegistryService, serverID string) {
-				registry.Mock.On("GetByID", serverID).Return(nil, errors.New("record not found"))
+				registry.Mock.On("GetByID", serverID).Return(nil, database.ErrNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedError:  "Server not found",
@@ -401,7 +429,7 @@ func TestServersEndpointsIntegration(t *testing.T) {
 	}
 
 	// Setup mocks
-	mockRegistry.Mock.On("List", "", 30).Return(servers, "", nil)
+	mockRegistry.Mock.On("List", map[string]any(nil), "", 30).Return(servers, "", nil)
 	mockRegistry.Mock.On("GetByID", serverID).Return(serverDetail, nil)
 
 	// Create a new test API
@@ -1,20 +1,28 @@
 package v0_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
 
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/api/router"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/logging"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/policy"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
@@ -55,7 +63,7 @@ func TestPrometheusHandler(t *testing.T) {
 	api.UseMiddleware(router.MetricTelemetryMiddleware(metrics,
 		router.WithSkipPaths("/health", "/metrics", "/ping", "/docs"),
 	))
-	v0.RegisterHealthEndpoint(api, cfg, metrics)
+	v0.RegisterHealthEndpoint(api, cfg, metrics, nil, nil)
 	v0.RegisterServersEndpoints(api, mockRegistry)
 
 	// Add /metrics for Prometheus metrics using promhttp
@@ -89,3 +97,94 @@ func TestPrometheusHandler(t *testing.T) {
 	assert.Contains(t, body, "mcp_registry_http_requests_total")
 	assert.Contains(t, body, "path=\"/v0/servers/{id}\"")
 }
+
+func TestTracingMiddleware(t *testing.T) {
+	mockRegistry := new(MockRegistryService)
+
+	serverID := uuid.New().String()
+	mockServerEndpoint(mockRegistry, serverID)
+
+	shutdownTracing, err := telemetry.InitTracing(context.Background(), &config.Config{TraceSampleRatio: 1})
+	assert.NoError(t, err)
+	defer func() { _ = shutdownTracing(context.Background()) }()
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.TracingMiddleware())
+	v0.RegisterServersEndpoints(api, mockRegistry)
+
+	t.Run("starts a new trace", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+serverID, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Len(t, w.Header().Get("X-Trace-ID"), 32)
+	})
+
+	t.Run("continues an incoming trace", func(t *testing.T) {
+		const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+serverID, nil)
+		req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, traceID, w.Header().Get("X-Trace-ID"))
+	})
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var logs bytes.Buffer
+	logger, err := logging.New(&logs, &config.Config{LogLevel: "info", LogFormat: config.LogFormatJSON})
+	require.NoError(t, err)
+	defaultLogger := slog.Default()
+	slog.SetDefault(logger)
+	defer slog.SetDefault(defaultLogger)
+
+	metrics, err := telemetry.NewMetrics(noop.NewMeterProvider().Meter("test"))
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		ReadOnly:   true,
+		PrimaryURL: "https://registry.example.com",
+		AccessLog:  true,
+	}
+	mux := http.NewServeMux()
+	policies, err := policy.NewEngine(cfg)
+	require.NoError(t, err)
+	router.NewHumaAPI(cfg, new(MockRegistryService), mux, metrics, nil, nil, policies)
+
+	t.Run("propagates an incoming request ID", func(t *testing.T) {
+		logs.Reset()
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(router.RequestIDHeader, "client-request-1")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		assert.Equal(t, "client-request-1", w.Header().Get(router.RequestIDHeader))
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "client-request-1", body["request_id"])
+
+		var accessLog map[string]any
+		require.NoError(t, json.Unmarshal(logs.Bytes(), &accessLog))
+		assert.Equal(t, "HTTP request", accessLog["msg"])
+		assert.Equal(t, "client-request-1", accessLog["request_id"])
+		assert.InDelta(t, http.StatusMethodNotAllowed, accessLog["status"], 0)
+	})
+
+	t.Run("generates a request ID when missing or invalid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/ping", nil)
+		req.Header.Set(router.RequestIDHeader, "not valid\n")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		_, err := uuid.Parse(w.Header().Get(router.RequestIDHeader))
+		assert.NoError(t, err)
+	})
+}
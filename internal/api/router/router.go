@@ -2,16 +2,26 @@
 package router
 
 import (
+	"log/slog"
 	"net/http"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
 
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+	"github.com/modelcontextprotocol/registry/internal/policy"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
@@ -82,6 +92,105 @@ func MetricTelemetryMiddleware(metrics *telemetry.Metrics, options ...Middleware
 	}
 }
 
+// headerCarrier adapts a huma.Context to the OpenTelemetry propagation carrier interface
+type headerCarrier struct {
+	ctx huma.Context
+}
+
+func (c headerCarrier) Get(key string) string { return c.ctx.Header(key) }
+
+func (c headerCarrier) Set(key, value string) { c.ctx.SetHeader(key, value) }
+
+func (c headerCarrier) Keys() []string {
+	var keys []string
+	c.ctx.EachHeader(func(name, _ string) {
+		keys = append(keys, name)
+	})
+	return keys
+}
+
+// TracingMiddleware starts a server span for each request, continuing any incoming trace context,
+// and returns the trace ID in the X-Trace-ID response header
+func TracingMiddleware() func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		routePath := getRoutePath(ctx)
+
+		parent := otel.GetTextMapPropagator().Extract(ctx.Context(), headerCarrier{ctx: ctx})
+		spanCtx, span := otel.Tracer(telemetry.TracerName).Start(parent, ctx.Method()+" "+routePath,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethod(ctx.Method()),
+				semconv.HTTPRoute(routePath),
+				semconv.HTTPTarget(ctx.URL().Path),
+			),
+		)
+		defer span.End()
+
+		if traceID := telemetry.TraceID(spanCtx); traceID != "" {
+			ctx.SetHeader("X-Trace-ID", traceID)
+		}
+
+		next(huma.WithContext(ctx, spanCtx))
+
+		statusCode := ctx.Status()
+		span.SetAttributes(semconv.HTTPStatusCode(statusCode))
+		if statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(statusCode))
+			slog.ErrorContext(spanCtx, "Request failed",
+				slog.String("method", ctx.Method()),
+				slog.String("path", ctx.URL().Path),
+				slog.Int("status", statusCode),
+			)
+		}
+	}
+}
+
+// RequestIDHeader is the header used to propagate request IDs
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID limits propagated request IDs to a safe character set and length
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestIDMiddleware propagates the incoming X-Request-ID header, or generates a new ID,
+// attaches it to the request context and echoes it in the response
+func RequestIDMiddleware() func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		requestID := ctx.Header(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.New().String()
+		}
+
+		ctx.SetHeader(RequestIDHeader, requestID)
+		next(huma.WithContext(ctx, logging.WithRequestID(ctx.Context(), requestID)))
+	}
+}
+
+// AccessLogMiddleware logs one line per request with its outcome and duration
+func AccessLogMiddleware() func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		start := time.Now()
+
+		next(ctx)
+
+		slog.InfoContext(ctx.Context(), "HTTP request",
+			slog.String("method", ctx.Method()),
+			slog.String("path", ctx.URL().Path),
+			slog.Int("status", ctx.Status()),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("remote_addr", ctx.RemoteAddr()),
+			slog.String("user_agent", ctx.Header("User-Agent")),
+		)
+	}
+}
+
+// requestIDTransformer includes the request ID in every error response
+func requestIDTransformer(ctx huma.Context, _ string, v any) (any, error) {
+	if errModel, ok := v.(*v0.ErrorModel); ok {
+		errModel.RequestID = logging.RequestID(ctx.Context())
+	}
+	return v, nil
+}
+
 // WithSkipPaths allows skipping instrumentation for specific paths
 func WithSkipPaths(paths ...string) MiddlewareOption {
 	return func(c *middlewareConfig) {
@@ -94,23 +203,37 @@ func WithSkipPaths(paths ...string) MiddlewareOption {
 // NewHumaAPI creates a new Huma API with all routes registered
 //
 //nolint:ireturn // huma.API is the expected interface type for Huma APIs
-func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.ServeMux, metrics *telemetry.Metrics) huma.API {
+func NewHumaAPI(
+	cfg *config.Config, registry service.RegistryService, mux *http.ServeMux, metrics *telemetry.Metrics,
+	freshness v0.FreshnessFunc, checks []v0.ReadinessCheck, policies *policy.Engine,
+) huma.API {
 	// Create Huma API configuration
 	humaConfig := huma.DefaultConfig("MCP Registry API", "1.0.0")
 	humaConfig.Info.Description = "A community driven registry service for Model Context Protocol (MCP) servers."
 	// Disable $schema property in responses: https://github.com/danielgtaylor/huma/issues/230
 	humaConfig.CreateHooks = []func(huma.Config) huma.Config{}
+	humaConfig.Transformers = append(humaConfig.Transformers, requestIDTransformer)
 
 	// Create a new API using humago adapter for standard library
 	api := humago.New(mux, humaConfig)
 
+	// Assign the request ID first so it is available to every log line, including the tracing middleware's
+	api.UseMiddleware(RequestIDMiddleware())
+
+	// Add tracing middleware so every other middleware and handler runs inside the request span
+	api.UseMiddleware(TracingMiddleware())
+
+	if cfg.AccessLog {
+		api.UseMiddleware(AccessLogMiddleware())
+	}
+
 	// Add metrics middleware with options
 	api.UseMiddleware(MetricTelemetryMiddleware(metrics,
-		WithSkipPaths("/health", "/metrics", "/ping", "/docs"),
+		WithSkipPaths("/health", "/live", "/ready", "/metrics", "/ping", "/docs"),
 	))
 
 	// Register routes for all API versions
-	RegisterV0Routes(api, cfg, registry, metrics)
+	RegisterV0Routes(api, cfg, registry, metrics, freshness, checks, policies)
 
 	// Add /metrics for Prometheus metrics using promhttp
 	mux.Handle("/metrics", metrics.PrometheusHandler())
@@ -4,19 +4,36 @@ package router
 import (
 	"github.com/danielgtaylor/huma/v2"
 
+	"github.com/modelcontextprotocol/registry/internal/admission"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/clientconfig"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/policy"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
 func RegisterV0Routes(
 	api huma.API, cfg *config.Config, registry service.RegistryService, metrics *telemetry.Metrics,
+	freshness v0.FreshnessFunc, checks []v0.ReadinessCheck, policies *policy.Engine,
 ) {
-	v0.RegisterHealthEndpoint(api, cfg, metrics)
+	v0.RegisterHealthEndpoint(api, cfg, metrics, freshness, checks)
 	v0.RegisterPingEndpoint(api)
 	v0.RegisterServersEndpoints(api, registry)
-	v0auth.RegisterAuthEndpoints(api, cfg)
-	v0.RegisterPublishEndpoint(api, registry, cfg)
+	v0.RegisterServerConfigEndpoint(api, registry, clientconfig.DefaultFormatters())
+	v0.RegisterClientsEndpoints(api, registry)
+
+	// Read-only replicas never register write or token endpoints
+	if cfg.ReadOnly {
+		v0.RegisterReadOnlyEndpoints(api, cfg)
+		return
+	}
+
+	admissions := admission.NewController(cfg)
+	v0.RegisterServerWriteEndpoints(api, registry, cfg, admissions, policies)
+	v0.RegisterInstallEndpoint(api, registry, cfg)
+	v0auth.RegisterAuthEndpoints(api, cfg, metrics, registry)
+	v0.RegisterPublishEndpoint(api, registry, cfg, metrics, admissions, policies)
+	v0.RegisterClientPublishEndpoint(api, registry, cfg)
 }
@@ -2,14 +2,16 @@ package api
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/api/router"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/policy"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
@@ -23,11 +25,14 @@ type Server struct {
 }
 
 // NewServer creates a new HTTP server
-func NewServer(cfg *config.Config, registryService service.RegistryService, metrics *telemetry.Metrics) *Server {
+func NewServer(
+	cfg *config.Config, registryService service.RegistryService, metrics *telemetry.Metrics,
+	freshness v0.FreshnessFunc, checks []v0.ReadinessCheck, policies *policy.Engine,
+) *Server {
 	// Create HTTP mux and Huma API
 	mux := http.NewServeMux()
 
-	api := router.NewHumaAPI(cfg, registryService, mux, metrics)
+	api := router.NewHumaAPI(cfg, registryService, mux, metrics, freshness, checks, policies)
 
 	server := &Server{
 		config:   cfg,
@@ -45,7 +50,7 @@ func NewServer(cfg *config.Config, registryService service.RegistryService, metr
 
 // Start begins listening for incoming HTTP requests
 func (s *Server) Start() error {
-	log.Printf("HTTP server starting on %s", s.config.ServerAddress)
+	slog.Info("HTTP server starting", slog.String("address", s.config.ServerAddress))
 	return s.server.ListenAndServe()
 }
 
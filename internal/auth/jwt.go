@@ -48,15 +48,31 @@ type JWTManager struct {
 	tokenDuration time.Duration
 }
 
-func NewJWTManager(cfg *config.Config) *JWTManager {
-	seed, err := hex.DecodeString(cfg.JWTPrivateKey)
+// ValidatePrivateKey checks that the configured JWT private key is a valid hex-encoded Ed25519 seed
+func ValidatePrivateKey(cfg *config.Config) error {
+	_, err := decodeSeed(cfg.JWTPrivateKey)
+	return err
+}
+
+// decodeSeed decodes a hex-encoded Ed25519 seed
+func decodeSeed(privateKey string) ([]byte, error) {
+	seed, err := hex.DecodeString(privateKey)
 	if err != nil {
-		panic(fmt.Sprintf("JWTPrivateKey must be a valid hex-encoded string: %v", err))
+		return nil, fmt.Errorf("JWTPrivateKey must be a valid hex-encoded string: %w", err)
 	}
 
 	// Require a valid Ed25519 seed (32 bytes)
 	if len(seed) != ed25519.SeedSize {
-		panic(fmt.Sprintf("JWTPrivateKey seed must be exactly %d bytes for Ed25519, got %d bytes", ed25519.SeedSize, len(seed)))
+		return nil, fmt.Errorf("JWTPrivateKey seed must be exactly %d bytes for Ed25519, got %d bytes", ed25519.SeedSize, len(seed))
+	}
+
+	return seed, nil
+}
+
+func NewJWTManager(cfg *config.Config) *JWTManager {
+	seed, err := decodeSeed(cfg.JWTPrivateKey)
+	if err != nil {
+		panic(err.Error())
 	}
 
 	// Generate the full Ed25519 key pair from the seed
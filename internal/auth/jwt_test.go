@@ -283,3 +283,11 @@ func TestNewJWTManager_InvalidKeySize(t *testing.T) {
 		auth.NewJWTManager(cfg)
 	})
 }
+
+func TestValidatePrivateKey(t *testing.T) {
+	assert.NoError(t, auth.ValidatePrivateKey(&config.Config{
+		JWTPrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	}))
+	assert.ErrorContains(t, auth.ValidatePrivateKey(&config.Config{JWTPrivateKey: "not-hex"}), "hex-encoded")
+	assert.ErrorContains(t, auth.ValidatePrivateKey(&config.Config{JWTPrivateKey: "abcd"}), "exactly 32 bytes")
+}
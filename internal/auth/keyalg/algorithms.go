@@ -0,0 +1,160 @@
+package keyalg
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// MinRSAKeyBits is the smallest RSA modulus accepted in key records
+const MinRSAKeyBits = 2048
+
+func init() {
+	Register(ed25519Algorithm{})
+	Register(ecdsaP256Algorithm{})
+	Register(rsaPSSAlgorithm{})
+}
+
+// ed25519Algorithm is Ed25519, with the raw 32-byte public key in key records
+type ed25519Algorithm struct{}
+
+func (ed25519Algorithm) Name() string { return "ed25519" }
+
+func (ed25519Algorithm) ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	if len(data) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key length: expected %d, got %d", ed25519.PublicKeySize, len(data))
+	}
+	return ed25519.PublicKey(data), nil
+}
+
+func (ed25519Algorithm) MarshalPublicKey(publicKey crypto.PublicKey) ([]byte, error) {
+	key, ok := publicKey.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("not an Ed25519 public key: %T", publicKey)
+	}
+	return key, nil
+}
+
+func (ed25519Algorithm) Sign(privateKey crypto.Signer, message []byte) ([]byte, error) {
+	return privateKey.Sign(rand.Reader, message, crypto.Hash(0))
+}
+
+func (ed25519Algorithm) Verify(publicKey crypto.PublicKey, message, signature []byte) error {
+	key, ok := publicKey.(ed25519.PublicKey)
+	if !ok {
+		return fmt.Errorf("not an Ed25519 public key: %T", publicKey)
+	}
+	if len(signature) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid signature length: expected %d, got %d", ed25519.SignatureSize, len(signature))
+	}
+	if !ed25519.Verify(key, message, signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// ecdsaP256Algorithm is ECDSA on P-256 with SHA-256, as offered by cloud KMSs and HSMs. Key
+// records hold the DER-encoded SubjectPublicKeyInfo. Signatures are ASN.1 DER encoded, as
+// KMSs return them, or the 64-byte r||s form used by WebCrypto.
+type ecdsaP256Algorithm struct{}
+
+func (ecdsaP256Algorithm) Name() string { return "ecdsap256" }
+
+func (a ecdsaP256Algorithm) ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	key, err := x509.ParsePKIXPublicKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ECDSA public key: %w", err)
+	}
+	if _, err := a.MarshalPublicKey(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (ecdsaP256Algorithm) MarshalPublicKey(publicKey crypto.PublicKey) ([]byte, error) {
+	key, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok || key.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("not an ECDSA P-256 public key: %T", publicKey)
+	}
+	return x509.MarshalPKIXPublicKey(key)
+}
+
+func (ecdsaP256Algorithm) Sign(privateKey crypto.Signer, message []byte) ([]byte, error) {
+	digest := sha256.Sum256(message)
+	return privateKey.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+func (ecdsaP256Algorithm) Verify(publicKey crypto.PublicKey, message, signature []byte) error {
+	key, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("not an ECDSA public key: %T", publicKey)
+	}
+	digest := sha256.Sum256(message)
+
+	var valid bool
+	if len(signature) == 64 {
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		valid = ecdsa.Verify(key, digest[:], r, s)
+	} else {
+		valid = ecdsa.VerifyASN1(key, digest[:], signature)
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// rsaPSSAlgorithm is RSASSA-PSS with SHA-256. Key records hold the DER-encoded
+// SubjectPublicKeyInfo of a key of at least MinRSAKeyBits bits.
+type rsaPSSAlgorithm struct{}
+
+func (rsaPSSAlgorithm) Name() string { return "rsa" }
+
+func (a rsaPSSAlgorithm) ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	key, err := x509.ParsePKIXPublicKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RSA public key: %w", err)
+	}
+	if _, err := a.MarshalPublicKey(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (rsaPSSAlgorithm) MarshalPublicKey(publicKey crypto.PublicKey) ([]byte, error) {
+	key, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("not an RSA public key: %T", publicKey)
+	}
+	if key.N.BitLen() < MinRSAKeyBits {
+		return nil, fmt.Errorf("RSA key is too small: %d bits, need at least %d", key.N.BitLen(), MinRSAKeyBits)
+	}
+	return x509.MarshalPKIXPublicKey(key)
+}
+
+func (rsaPSSAlgorithm) Sign(privateKey crypto.Signer, message []byte) ([]byte, error) {
+	digest := sha256.Sum256(message)
+	return privateKey.Sign(rand.Reader, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256})
+}
+
+func (rsaPSSAlgorithm) Verify(publicKey crypto.PublicKey, message, signature []byte) error {
+	key, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("not an RSA public key: %T", publicKey)
+	}
+	digest := sha256.Sum256(message)
+	err := rsa.VerifyPSS(key, crypto.SHA256, digest[:], signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+	if errors.Is(err, rsa.ErrVerification) {
+		return ErrInvalidSignature
+	}
+	return err
+}
@@ -0,0 +1,164 @@
+// Package keyalg is the registry of signature algorithms for DNS and HTTP authentication.
+//
+// Domains publish their public keys in key records of the form
+//
+//	v=MCPv1; k=<algorithm>; p=<base64-encoded public key>
+//
+// in a DNS TXT record or at /.well-known/mcp-registry-auth. The registry verifies
+// signatures with the algorithm named by k=, and the publisher signs with it.
+package keyalg
+
+import (
+	"crypto"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Algorithm parses the public keys of one signature algorithm, and makes and verifies
+// signatures with its keys
+type Algorithm interface {
+	// Name is the k= value that selects the algorithm in key records
+	Name() string
+	// ParsePublicKey decodes a public key from the p= value of a key record
+	ParsePublicKey(data []byte) (crypto.PublicKey, error)
+	// MarshalPublicKey encodes a public key for the p= value of a key record, failing if
+	// the key is not for this algorithm
+	MarshalPublicKey(publicKey crypto.PublicKey) ([]byte, error)
+	// Sign signs message with a private key for this algorithm
+	Sign(privateKey crypto.Signer, message []byte) ([]byte, error)
+	// Verify checks that signature is a valid signature of message for publicKey
+	Verify(publicKey crypto.PublicKey, message, signature []byte) error
+}
+
+var (
+	algorithms = map[string]Algorithm{}
+	// order keeps the registration order, so lookups by key are deterministic
+	order []Algorithm
+)
+
+// ErrInvalidSignature is returned by Verify when a well-formed signature does not match
+var ErrInvalidSignature = errors.New("signature verification failed")
+
+// Register adds an algorithm to the registry. It panics if the name is already taken.
+func Register(algorithm Algorithm) {
+	if _, exists := algorithms[algorithm.Name()]; exists {
+		panic("keyalg: algorithm registered twice: " + algorithm.Name())
+	}
+	algorithms[algorithm.Name()] = algorithm
+	order = append(order, algorithm)
+}
+
+// Lookup returns the algorithm for a k= value
+func Lookup(name string) (Algorithm, bool) {
+	algorithm, ok := algorithms[name]
+	return algorithm, ok
+}
+
+// Names returns the names of the registered algorithms
+func Names() []string {
+	names := make([]string, 0, len(order))
+	for _, algorithm := range order {
+		names = append(names, algorithm.Name())
+	}
+	return names
+}
+
+// ForPublicKey returns the algorithm that handles publicKey
+//
+//nolint:ireturn // Algorithm is the registry's interface type
+func ForPublicKey(publicKey crypto.PublicKey) (Algorithm, error) {
+	for _, algorithm := range order {
+		if _, err := algorithm.MarshalPublicKey(publicKey); err == nil {
+			return algorithm, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported key type %T: supported algorithms are %s", publicKey, strings.Join(Names(), ", "))
+}
+
+// PublicKey is a public key read from a key record, together with its algorithm
+type PublicKey struct {
+	Algorithm Algorithm
+	Key       crypto.PublicKey
+}
+
+// Verify checks that signature is a valid signature of message for the key
+func (k PublicKey) Verify(message, signature []byte) error {
+	return k.Algorithm.Verify(k.Key, message, signature)
+}
+
+// Equal reports whether two public keys are the same key
+func (k PublicKey) Equal(other crypto.PublicKey) bool {
+	key, ok := k.Key.(interface{ Equal(x crypto.PublicKey) bool })
+	return ok && key.Equal(other)
+}
+
+var recordPattern = regexp.MustCompile(`v=MCPv1;\s*k=([A-Za-z0-9-]+);\s*p=([A-Za-z0-9+/=]+)`)
+
+// ParseRecord parses the first key record in s
+func ParseRecord(s string) (PublicKey, error) {
+	matches := recordPattern.FindStringSubmatch(s)
+	if len(matches) != 3 {
+		return PublicKey{}, fmt.Errorf("invalid key format, expected: v=MCPv1; k=<algorithm>; p=<base64-key>")
+	}
+
+	algorithm, ok := Lookup(matches[1])
+	if !ok {
+		return PublicKey{}, fmt.Errorf("unsupported key algorithm %q: supported algorithms are %s", matches[1], strings.Join(Names(), ", "))
+	}
+
+	data, err := base64.StdEncoding.DecodeString(matches[2])
+	if err != nil {
+		return PublicKey{}, fmt.Errorf("failed to decode base64 public key: %w", err)
+	}
+
+	key, err := algorithm.ParsePublicKey(data)
+	if err != nil {
+		return PublicKey{}, err
+	}
+	return PublicKey{Algorithm: algorithm, Key: key}, nil
+}
+
+// ParseRecords returns the valid key records in records, skipping anything else, such as
+// other TXT records on the same domain
+func ParseRecords(records []string) []PublicKey {
+	var publicKeys []PublicKey
+	for _, record := range records {
+		publicKey, err := ParseRecord(record)
+		if err != nil {
+			continue
+		}
+		publicKeys = append(publicKeys, publicKey)
+	}
+	return publicKeys
+}
+
+// FormatRecord formats the key record for publicKey
+func FormatRecord(publicKey crypto.PublicKey) (string, error) {
+	algorithm, err := ForPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	data, err := algorithm.MarshalPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("v=MCPv1; k=%s; p=%s", algorithm.Name(), base64.StdEncoding.EncodeToString(data)), nil
+}
+
+// Sign signs message with privateKey, using the algorithm for its public key
+func Sign(privateKey crypto.Signer, message []byte) ([]byte, error) {
+	algorithm, err := ForPublicKey(privateKey.Public())
+	if err != nil {
+		return nil, err
+	}
+	return algorithm.Sign(privateKey, message)
+}
+
+// ChallengeMessage is the message signed to answer a registry challenge: the nonce and the
+// domain it was issued for, so a signature can't be reused for another domain
+func ChallengeMessage(domain, nonce string) []byte {
+	return []byte(nonce + ":" + strings.ToLower(domain))
+}
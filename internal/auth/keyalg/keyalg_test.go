@@ -0,0 +1,96 @@
+package keyalg_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/auth/keyalg"
+)
+
+func TestRoundTrip(t *testing.T) {
+	_, ed25519Key, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for name, signer := range map[string]crypto.Signer{"ed25519": ed25519Key, "ecdsap256": ecdsaKey, "rsa": rsaKey} {
+		t.Run(name, func(t *testing.T) {
+			record, err := keyalg.FormatRecord(signer.Public())
+			require.NoError(t, err)
+
+			publicKey, err := keyalg.ParseRecord(record)
+			require.NoError(t, err)
+			assert.Equal(t, name, publicKey.Algorithm.Name())
+			assert.True(t, publicKey.Equal(signer.Public()))
+
+			signature, err := keyalg.Sign(signer, []byte("2025-01-01T00:00:00Z"))
+			require.NoError(t, err)
+			assert.NoError(t, publicKey.Verify([]byte("2025-01-01T00:00:00Z"), signature))
+			assert.ErrorIs(t, publicKey.Verify([]byte("2025-01-01T00:00:01Z"), signature), keyalg.ErrInvalidSignature)
+		})
+	}
+}
+
+func TestECDSARawSignature(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	record, err := keyalg.FormatRecord(ecdsaKey.Public())
+	require.NoError(t, err)
+	publicKey, err := keyalg.ParseRecord(record)
+	require.NoError(t, err)
+
+	// WebCrypto signs with the fixed-size r||s encoding rather than ASN.1
+	digest := sha256.Sum256([]byte("message"))
+	r, s, err := ecdsa.Sign(rand.Reader, ecdsaKey, digest[:])
+	require.NoError(t, err)
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	assert.NoError(t, publicKey.Verify([]byte("message"), signature))
+}
+
+func TestParseRecordErrors(t *testing.T) {
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	p384DER, err := x509.MarshalPKIXPublicKey(p384Key.Public())
+	require.NoError(t, err)
+	smallRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	smallRSADER, err := x509.MarshalPKIXPublicKey(smallRSAKey.Public())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		record string
+		error  string
+	}{
+		{"not a key record", "v=spf1 -all", "invalid key format"},
+		{"unsupported algorithm", "v=MCPv1; k=dsa; p=AAAA", `unsupported key algorithm "dsa"`},
+		{"invalid base64", "v=MCPv1; k=ed25519; p=AAA", "failed to decode base64"},
+		{"short ed25519 key", "v=MCPv1; k=ed25519; p=AAAA", "invalid public key length"},
+		{"wrong curve", "v=MCPv1; k=ecdsap256; p=" + base64.StdEncoding.EncodeToString(p384DER), "not an ECDSA P-256 public key"},
+		{"small RSA key", "v=MCPv1; k=rsa; p=" + base64.StdEncoding.EncodeToString(smallRSADER), "RSA key is too small"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := keyalg.ParseRecord(tt.record)
+			assert.ErrorContains(t, err, tt.error)
+		})
+	}
+
+	assert.Empty(t, keyalg.ParseRecords([]string{"v=spf1 -all", "v=MCPv1; k=dsa; p=AAAA"}))
+}
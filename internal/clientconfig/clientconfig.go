@@ -0,0 +1,300 @@
+// Package clientconfig renders ready-to-paste configuration for MCP clients from the packages and
+// remotes of a server. Values the user has to provide, such as API keys, become placeholders
+// derived from their input definitions. Each client's configuration format is a Formatter.
+package clientconfig
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+var (
+	// ErrNoTarget is returned when the server has no package or remote matching the selection
+	ErrNoTarget = errors.New("no matching package or remote")
+	// ErrUnsupported is returned when a client can't run the selected package or remote
+	ErrUnsupported = errors.New("not supported")
+)
+
+// Placeholder is a value the user has to provide, derived from an input definition
+type Placeholder struct {
+	ID          string       `json:"id" doc:"Identifier of the value, referenced from the configuration"`
+	Description string       `json:"description,omitempty"`
+	Format      model.Format `json:"format,omitempty"`
+	IsRequired  bool         `json:"is_required,omitempty"`
+	IsSecret    bool         `json:"is_secret,omitempty"`
+	Default     string       `json:"default,omitempty"`
+	Choices     []string     `json:"choices,omitempty"`
+}
+
+// Variable is an environment variable or header
+type Variable struct {
+	Name  string
+	Value string
+}
+
+// Target is how a client runs a server: a command for packages, or a URL for remotes. Values
+// the user has to provide are referenced as the formatter's placeholders.
+type Target struct {
+	// Name is the key of the server in the client's configuration
+	Name string
+
+	// Command, Args and Env start a package over stdio
+	Command string
+	Args    []string
+	Env     []Variable
+
+	// Transport, URL and Headers connect to a remote
+	Transport string
+	URL       string
+	Headers   []Variable
+
+	// Placeholders are the values the user has to provide
+	Placeholders []Placeholder
+}
+
+// IsRemote reports whether the target is a remote rather than a package
+func (t Target) IsRemote() bool {
+	return t.URL != ""
+}
+
+// Formatter renders configuration for one client
+type Formatter interface {
+	// Placeholder returns how the client's configuration refers to a value the user provides
+	Placeholder(placeholder Placeholder) string
+	// Format renders the configuration for target, or returns ErrUnsupported
+	Format(target Target) (Snippet, error)
+}
+
+// Snippet is configuration for a client
+type Snippet struct {
+	// Syntax is the language of the content, such as json
+	Syntax string
+	// Content is the configuration to paste into the client's configuration file
+	Content string
+}
+
+// Formatters are the clients configuration can be rendered for, by name
+type Formatters map[string]Formatter
+
+// Names returns the names of the clients, sorted
+func (f Formatters) Names() []string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Selection picks the package or remote to configure. Without one, the first package is
+// configured, or the first remote for servers without packages.
+type Selection struct {
+	// Package is the registry name of the package, such as npm
+	Package string
+	// Remote is the transport type of the remote, such as sse
+	Remote string
+}
+
+// Config is configuration rendered for a client
+type Config struct {
+	Snippet
+	// Inputs are the values the user has to fill in
+	Inputs []Placeholder
+}
+
+// Render renders the configuration of the selected package or remote of server with formatter
+func Render(formatter Formatter, server model.ServerDetail, selection Selection) (*Config, error) {
+	target, err := NewTarget(formatter, server, selection)
+	if err != nil {
+		return nil, err
+	}
+	snippet, err := formatter.Format(*target)
+	if err != nil {
+		return nil, err
+	}
+	return &Config{Snippet: snippet, Inputs: target.Placeholders}, nil
+}
+
+// NewTarget resolves the selected package or remote of server to a target, referring to values
+// the user has to provide with the formatter's placeholders
+func NewTarget(formatter Formatter, server model.ServerDetail, selection Selection) (*Target, error) {
+	r := &resolver{placeholder: formatter.Placeholder}
+	name := server.Name[strings.LastIndex(server.Name, "/")+1:]
+
+	if selection.Remote == "" {
+		for _, pkg := range server.Packages {
+			if selection.Package == "" || pkg.RegistryName == selection.Package {
+				return r.packageTarget(name, pkg)
+			}
+		}
+	}
+	if selection.Package == "" {
+		for _, remote := range server.Remotes {
+			if selection.Remote == "" || remote.TransportType == selection.Remote {
+				return r.remoteTarget(name, remote), nil
+			}
+		}
+	}
+	return nil, ErrNoTarget
+}
+
+// runtime is how packages of a registry are run
+type runtime struct {
+	command string
+	// args are passed to the command before the package, unless the package has runtime arguments
+	args []string
+	// reference returns the package reference passed to the command
+	reference func(name, version string) string
+}
+
+// runtimes are the package registries the registry knows how to run packages from
+var runtimes = map[string]runtime{
+	"npm":    {command: "npx", args: []string{"-y"}, reference: versioned("@")},
+	"pypi":   {command: "uvx", reference: versioned("==")},
+	"docker": {command: "docker", args: []string{"run", "-i", "--rm"}, reference: versioned(":")},
+	"nuget":  {command: "dnx", reference: versioned("@")},
+}
+
+// versioned returns a package reference of the name and version joined by separator
+func versioned(separator string) func(name, version string) string {
+	return func(name, version string) string {
+		if version == "" {
+			return name
+		}
+		return name + separator + version
+	}
+}
+
+// packageTarget resolves the command that runs pkg
+func (r *resolver) packageTarget(name string, pkg model.Package) (*Target, error) {
+	rt, known := runtimes[pkg.RegistryName]
+	command := rt.command
+	if pkg.RunTimeHint != "" {
+		command = pkg.RunTimeHint
+	}
+	if command == "" {
+		return nil, fmt.Errorf("%w: packages from %s need a runtime_hint", ErrUnsupported, pkg.RegistryName)
+	}
+
+	target := &Target{Name: name, Command: command}
+	for _, env := range pkg.EnvironmentVariables {
+		if value, ok := r.resolve(env.Name, env.InputWithVariables); ok {
+			target.Env = append(target.Env, Variable{Name: env.Name, Value: value})
+		}
+	}
+
+	if len(pkg.RuntimeArguments) > 0 {
+		target.Args = r.arguments(pkg.RuntimeArguments)
+	} else if command == rt.command {
+		target.Args = slices.Clone(rt.args)
+	}
+	// Containers don't inherit the environment of the client, so pass it through
+	if pkg.RegistryName == "docker" && command == rt.command {
+		for _, env := range target.Env {
+			target.Args = append(target.Args, "-e", env.Name)
+		}
+	}
+
+	if known {
+		target.Args = append(target.Args, rt.reference(pkg.Name, pkg.Version))
+	} else {
+		target.Args = append(target.Args, pkg.Name)
+	}
+	target.Args = append(target.Args, r.arguments(pkg.PackageArguments)...)
+	target.Placeholders = r.placeholders
+	return target, nil
+}
+
+// remoteTarget resolves the URL and headers of remote
+func (r *resolver) remoteTarget(name string, remote model.Remote) *Target {
+	target := &Target{Name: name, Transport: remote.TransportType, URL: remote.URL}
+	for _, header := range remote.Headers {
+		if value, ok := r.resolve(header.Name, header.InputWithVariables); ok {
+			target.Headers = append(target.Headers, Variable{Name: header.Name, Value: value})
+		}
+	}
+	target.Placeholders = r.placeholders
+	return target
+}
+
+// variablePattern matches {variable} references in input values
+var variablePattern = regexp.MustCompile(`\{[A-Za-z0-9_.-]+\}`)
+
+// resolver resolves inputs to values, collecting a placeholder for each value the user provides
+type resolver struct {
+	placeholder  func(Placeholder) string
+	placeholders []Placeholder
+}
+
+// arguments resolves command-line arguments. Named arguments are followed by their value, if
+// they have one, and optional arguments without a value are left out.
+func (r *resolver) arguments(arguments []model.Argument) []string {
+	var args []string
+	for i, argument := range arguments {
+		switch argument.Type {
+		case model.ArgumentTypeNamed:
+			if argument.Value == "" && !argument.IsRequired {
+				continue
+			}
+			args = append(args, argument.Name)
+			if value, ok := r.resolve(strings.TrimLeft(argument.Name, "-"), argument.InputWithVariables); ok {
+				args = append(args, value)
+			}
+		default:
+			id := argument.ValueHint
+			if id == "" {
+				id = "arg" + strconv.Itoa(i+1)
+			}
+			if value, ok := r.resolve(id, argument.InputWithVariables); ok {
+				args = append(args, value)
+			}
+		}
+	}
+	return args
+}
+
+// resolve returns the value of an input: its value with variables substituted, or a
+// placeholder if it's required and has no value. Optional inputs without a value are left out.
+func (r *resolver) resolve(id string, input model.InputWithVariables) (string, bool) {
+	if input.Value != "" {
+		return variablePattern.ReplaceAllStringFunc(input.Value, func(match string) string {
+			name := match[1 : len(match)-1]
+			variable, ok := input.Variables[name]
+			if !ok {
+				// References to unknown variables are kept as they are
+				return match
+			}
+			if variable.Value != "" {
+				return variable.Value
+			}
+			return r.add(name, variable)
+		}), true
+	}
+	if !input.IsRequired {
+		return "", false
+	}
+	return r.add(id, input.Input), true
+}
+
+// add records a placeholder for input, once per ID, and returns how the formatter refers to it
+func (r *resolver) add(id string, input model.Input) string {
+	placeholder := Placeholder{
+		ID:          id,
+		Description: input.Description,
+		Format:      input.Format,
+		IsRequired:  input.IsRequired,
+		IsSecret:    input.IsSecret,
+		Default:     input.Default,
+		Choices:     input.Choices,
+	}
+	if !slices.ContainsFunc(r.placeholders, func(p Placeholder) bool { return p.ID == id }) {
+		r.placeholders = append(r.placeholders, placeholder)
+	}
+	return r.placeholder(placeholder)
+}
@@ -0,0 +1,241 @@
+package clientconfig_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/clientconfig"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+func required(description string) model.InputWithVariables {
+	return model.InputWithVariables{Input: model.Input{Description: description, IsRequired: true}}
+}
+
+func TestNewTarget(t *testing.T) {
+	formatter := clientconfig.MCPServers{Remotes: true}
+
+	tests := []struct {
+		name         string
+		server       model.ServerDetail
+		selection    clientconfig.Selection
+		expected     clientconfig.Target
+		placeholders []string
+	}{
+		{
+			name: "npm package with a secret",
+			server: model.ServerDetail{
+				Name: "io.modelcontextprotocol/brave-search",
+				Packages: []model.Package{{
+					RegistryName: "npm",
+					Name:         "@modelcontextprotocol/server-brave-search",
+					Version:      "1.0.2",
+					EnvironmentVariables: []model.KeyValueInput{
+						{Name: "BRAVE_API_KEY", InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true, IsSecret: true}}},
+						{Name: "LOG_LEVEL", InputWithVariables: model.InputWithVariables{Input: model.Input{Default: "info"}}},
+					},
+				}},
+			},
+			expected: clientconfig.Target{
+				Name:    "brave-search",
+				Command: "npx",
+				Args:    []string{"-y", "@modelcontextprotocol/server-brave-search@1.0.2"},
+				Env:     []clientconfig.Variable{{Name: "BRAVE_API_KEY", Value: "<BRAVE_API_KEY>"}},
+			},
+			placeholders: []string{"BRAVE_API_KEY"},
+		},
+		{
+			name: "docker package with variables",
+			server: model.ServerDetail{
+				Name: "io.modelcontextprotocol/filesystem",
+				Packages: []model.Package{{
+					RegistryName: "docker",
+					Name:         "mcp/filesystem",
+					Version:      "1.0.2",
+					RuntimeArguments: []model.Argument{
+						{Type: model.ArgumentTypePositional, InputWithVariables: model.InputWithVariables{Input: model.Input{Value: "run"}}},
+						{Type: model.ArgumentTypePositional, InputWithVariables: model.InputWithVariables{Input: model.Input{Value: "-i"}}},
+						{
+							Type: model.ArgumentTypeNamed,
+							Name: "--mount",
+							InputWithVariables: model.InputWithVariables{
+								Input: model.Input{Value: "type=bind,src={source_path},dst={target_path}", IsRequired: true},
+								Variables: map[string]model.Input{
+									"source_path": {Description: "Source path on host", Format: model.FormatFilePath, IsRequired: true},
+									"target_path": {Value: "/project"},
+								},
+							},
+						},
+					},
+					PackageArguments: []model.Argument{
+						{Type: model.ArgumentTypePositional, ValueHint: "target_dir", InputWithVariables: model.InputWithVariables{Input: model.Input{Value: "/project"}}},
+						{Type: model.ArgumentTypeNamed, Name: "--verbose", InputWithVariables: model.InputWithVariables{Input: model.Input{Format: model.FormatBoolean}}},
+					},
+					EnvironmentVariables: []model.KeyValueInput{
+						{Name: "LOG_LEVEL", InputWithVariables: model.InputWithVariables{Input: model.Input{Value: "debug"}}},
+					},
+				}},
+			},
+			expected: clientconfig.Target{
+				Name:    "filesystem",
+				Command: "docker",
+				Args:    []string{"run", "-i", "--mount", "type=bind,src=<source_path>,dst=/project", "-e", "LOG_LEVEL", "mcp/filesystem:1.0.2", "/project"},
+				Env:     []clientconfig.Variable{{Name: "LOG_LEVEL", Value: "debug"}},
+			},
+			placeholders: []string{"source_path"},
+		},
+		{
+			name: "pypi package with required arguments",
+			server: model.ServerDetail{
+				Name: "io.example/weather",
+				Packages: []model.Package{{
+					RegistryName: "pypi",
+					Name:         "weather-mcp-server",
+					Version:      "0.5.0",
+					PackageArguments: []model.Argument{
+						{Type: model.ArgumentTypeNamed, Name: "--units", InputWithVariables: required("Temperature units")},
+						{Type: model.ArgumentTypePositional, InputWithVariables: required("City")},
+					},
+				}},
+			},
+			expected: clientconfig.Target{
+				Name:    "weather",
+				Command: "uvx",
+				Args:    []string{"weather-mcp-server==0.5.0", "--units", "<units>", "<arg2>"},
+			},
+			placeholders: []string{"units", "arg2"},
+		},
+		{
+			name: "runtime hint for an unknown registry",
+			server: model.ServerDetail{
+				Name:     "io.example/binary",
+				Packages: []model.Package{{RegistryName: "binary", Name: "binary-mcp-server", Version: "2.1.0", RunTimeHint: "binary-mcp-server"}},
+			},
+			expected: clientconfig.Target{
+				Name:    "binary",
+				Command: "binary-mcp-server",
+				Args:    []string{"binary-mcp-server"},
+			},
+		},
+		{
+			name: "remote selected by transport",
+			server: model.ServerDetail{
+				Name:     "io.example/hybrid",
+				Packages: []model.Package{{RegistryName: "npm", Name: "@example/hybrid-mcp-server"}},
+				Remotes: []model.Remote{
+					{TransportType: "sse", URL: "https://mcp.example.com/sse"},
+					{TransportType: "streamable-http", URL: "https://mcp.example.com/mcp", Headers: []model.KeyValueInput{
+						{Name: "X-API-Key", InputWithVariables: required("API key")},
+					}},
+				},
+			},
+			selection: clientconfig.Selection{Remote: "streamable-http"},
+			expected: clientconfig.Target{
+				Name:      "hybrid",
+				Transport: "streamable-http",
+				URL:       "https://mcp.example.com/mcp",
+				Headers:   []clientconfig.Variable{{Name: "X-API-Key", Value: "<X-API-Key>"}},
+			},
+			placeholders: []string{"X-API-Key"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := clientconfig.NewTarget(formatter, tt.server, tt.selection)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, placeholder := range target.Placeholders {
+				ids = append(ids, placeholder.ID)
+			}
+			assert.Equal(t, tt.placeholders, ids)
+
+			target.Placeholders = nil
+			assert.Equal(t, tt.expected, *target)
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		server := model.ServerDetail{
+			Name:     "io.example/binary",
+			Packages: []model.Package{{RegistryName: "binary", Name: "binary-mcp-server"}},
+		}
+		_, err := clientconfig.NewTarget(formatter, server, clientconfig.Selection{})
+		assert.ErrorIs(t, err, clientconfig.ErrUnsupported)
+
+		_, err = clientconfig.NewTarget(formatter, server, clientconfig.Selection{Package: "npm"})
+		assert.ErrorIs(t, err, clientconfig.ErrNoTarget)
+
+		_, err = clientconfig.NewTarget(formatter, server, clientconfig.Selection{Remote: "sse"})
+		assert.ErrorIs(t, err, clientconfig.ErrNoTarget)
+	})
+}
+
+func TestRender(t *testing.T) {
+	server := model.ServerDetail{
+		Name: "io.modelcontextprotocol/brave-search",
+		Packages: []model.Package{{
+			RegistryName: "npm",
+			Name:         "@modelcontextprotocol/server-brave-search",
+			Version:      "1.0.2",
+			EnvironmentVariables: []model.KeyValueInput{
+				{Name: "BRAVE_API_KEY", InputWithVariables: model.InputWithVariables{Input: model.Input{Description: "Brave Search API Key", IsRequired: true, IsSecret: true}}},
+				{Name: "SAFE_SEARCH", InputWithVariables: model.InputWithVariables{Input: model.Input{IsRequired: true, Default: "moderate", Choices: []string{"off", "moderate", "strict"}}}},
+			},
+		}},
+		Remotes: []model.Remote{{TransportType: "sse", URL: "https://mcp.example.com/sse"}},
+	}
+
+	t.Run("mcpServers", func(t *testing.T) {
+		config, err := clientconfig.Render(clientconfig.MCPServers{}, server, clientconfig.Selection{})
+		require.NoError(t, err)
+		assert.Equal(t, "json", config.Syntax)
+		assert.JSONEq(t, `{
+			"mcpServers": {
+				"brave-search": {
+					"command": "npx",
+					"args": ["-y", "@modelcontextprotocol/server-brave-search@1.0.2"],
+					"env": {"BRAVE_API_KEY": "<BRAVE_API_KEY>", "SAFE_SEARCH": "<SAFE_SEARCH>"}
+				}
+			}
+		}`, config.Content)
+		assert.Contains(t, config.Content, `"<BRAVE_API_KEY>"`)
+		require.Len(t, config.Inputs, 2)
+		assert.True(t, config.Inputs[0].IsSecret)
+	})
+
+	t.Run("VS Code", func(t *testing.T) {
+		config, err := clientconfig.Render(clientconfig.VSCode{}, server, clientconfig.Selection{})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"mcp": {
+				"inputs": [
+					{"type": "promptString", "id": "BRAVE_API_KEY", "description": "Brave Search API Key", "password": true},
+					{"type": "pickString", "id": "SAFE_SEARCH", "options": ["off", "moderate", "strict"], "default": "moderate"}
+				],
+				"servers": {
+					"brave-search": {
+						"type": "stdio",
+						"command": "npx",
+						"args": ["-y", "@modelcontextprotocol/server-brave-search@1.0.2"],
+						"env": {"BRAVE_API_KEY": "${input:BRAVE_API_KEY}", "SAFE_SEARCH": "${input:SAFE_SEARCH}"}
+					}
+				}
+			}
+		}`, config.Content)
+	})
+
+	t.Run("remotes", func(t *testing.T) {
+		config, err := clientconfig.Render(clientconfig.VSCode{}, server, clientconfig.Selection{Remote: "sse"})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"mcp": {"inputs": [], "servers": {"brave-search": {"type": "sse", "url": "https://mcp.example.com/sse"}}}}`, config.Content)
+
+		_, err = clientconfig.Render(clientconfig.MCPServers{}, server, clientconfig.Selection{Remote: "sse"})
+		assert.ErrorIs(t, err, clientconfig.ErrUnsupported)
+	})
+
+	assert.Equal(t, []string{"claude-desktop", "cursor", "mcp-servers", "vscode"}, clientconfig.DefaultFormatters().Names())
+}
@@ -0,0 +1,152 @@
+package clientconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// DefaultFormatters returns the formatters of the clients the registry renders configuration for
+func DefaultFormatters() Formatters {
+	return Formatters{
+		"mcp-servers":    MCPServers{Remotes: true},
+		"claude-desktop": MCPServers{},
+		"cursor":         MCPServers{Remotes: true},
+		"vscode":         VSCode{},
+	}
+}
+
+// MCPServers renders the "mcpServers" JSON used by Claude Desktop, Cursor and many other
+// clients. Values the user provides are written as <ID>, to be replaced by hand.
+type MCPServers struct {
+	// Remotes is whether the client connects to remotes by URL
+	Remotes bool
+}
+
+// mcpServer is an entry of "mcpServers"
+type mcpServer struct {
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Placeholder implements Formatter
+func (MCPServers) Placeholder(placeholder Placeholder) string {
+	return "<" + placeholder.ID + ">"
+}
+
+// Format implements Formatter
+func (f MCPServers) Format(target Target) (Snippet, error) {
+	if target.IsRemote() && !f.Remotes {
+		return Snippet{}, fmt.Errorf("%w: the client can't connect to remotes", ErrUnsupported)
+	}
+	server := mcpServer{
+		Command: target.Command,
+		Args:    target.Args,
+		Env:     variables(target.Env),
+		URL:     target.URL,
+		Headers: variables(target.Headers),
+	}
+	return jsonSnippet(map[string]any{
+		"mcpServers": map[string]mcpServer{target.Name: server},
+	})
+}
+
+// VSCode renders the "mcp" section of Visual Studio Code's settings.json. Values the user
+// provides are inputs, which VS Code prompts for when the server starts.
+type VSCode struct{}
+
+// vsCodeServer is an entry of "servers"
+type vsCodeServer struct {
+	Type    string            `json:"type"`
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// vsCodeInput is an entry of "inputs"
+type vsCodeInput struct {
+	Type        string   `json:"type"`
+	ID          string   `json:"id"`
+	Description string   `json:"description,omitempty"`
+	Password    bool     `json:"password,omitempty"`
+	Options     []string `json:"options,omitempty"`
+	Default     string   `json:"default,omitempty"`
+}
+
+// Placeholder implements Formatter
+func (VSCode) Placeholder(placeholder Placeholder) string {
+	return "${input:" + placeholder.ID + "}"
+}
+
+// Format implements Formatter
+func (VSCode) Format(target Target) (Snippet, error) {
+	server := vsCodeServer{
+		Type:    "stdio",
+		Command: target.Command,
+		Args:    target.Args,
+		Env:     variables(target.Env),
+	}
+	if target.IsRemote() {
+		server = vsCodeServer{Type: "http", URL: target.URL, Headers: variables(target.Headers)}
+		if target.Transport == "sse" {
+			server.Type = "sse"
+		}
+	}
+
+	inputs := make([]vsCodeInput, 0, len(target.Placeholders))
+	for _, placeholder := range target.Placeholders {
+		input := vsCodeInput{
+			Type:        "promptString",
+			ID:          placeholder.ID,
+			Description: placeholder.Description,
+			Password:    placeholder.IsSecret,
+			Default:     placeholder.Default,
+		}
+		if len(placeholder.Choices) > 0 {
+			input = vsCodeInput{
+				Type:        "pickString",
+				ID:          placeholder.ID,
+				Description: placeholder.Description,
+				Options:     placeholder.Choices,
+				Default:     placeholder.Default,
+			}
+		}
+		inputs = append(inputs, input)
+	}
+
+	return jsonSnippet(map[string]any{
+		"mcp": map[string]any{
+			"inputs":  inputs,
+			"servers": map[string]vsCodeServer{target.Name: server},
+		},
+	})
+}
+
+// variables converts environment variables or headers to a map, or nil without any
+func variables(vars []Variable) map[string]string {
+	if len(vars) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(vars))
+	for _, v := range vars {
+		m[v.Name] = v.Value
+	}
+	return m
+}
+
+// jsonSnippet renders v as indented JSON, leaving placeholders like <ID> unescaped
+func jsonSnippet(v any) (Snippet, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return Snippet{}, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	return Snippet{Syntax: "json", Content: buf.String()}, nil
+}
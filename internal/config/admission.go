@@ -0,0 +1,104 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// AdmissionFailurePolicy decides what happens to a request when an admission webhook can't be
+// reached or doesn't answer in time
+type AdmissionFailurePolicy string
+
+const (
+	// AdmissionFailClosed rejects the request
+	AdmissionFailClosed AdmissionFailurePolicy = "closed"
+	// AdmissionFailOpen lets the request through as if the webhook had allowed it
+	AdmissionFailOpen AdmissionFailurePolicy = "open"
+)
+
+const (
+	defaultAdmissionTimeout = 5 * time.Second
+	maxAdmissionTimeout     = 30 * time.Second
+)
+
+// AdmissionWebhook is an endpoint that reviews servers before they are published or updated
+type AdmissionWebhook struct {
+	// Name identifies the webhook in errors, logs and metrics
+	Name string `json:"name"`
+	// URL is where reviews are POSTed
+	URL string `json:"url"`
+	// Operations are the operations the webhook reviews, "publish" and "update", all by default
+	Operations []string `json:"operations,omitempty"`
+	// Timeout bounds a review, 5s by default and at most 30s
+	Timeout time.Duration `json:"-"`
+	// FailurePolicy applies when the webhook fails, "closed" by default
+	FailurePolicy AdmissionFailurePolicy `json:"failure_policy,omitempty"`
+}
+
+// Reviews reports whether the webhook reviews operation
+func (w AdmissionWebhook) Reviews(operation string) bool {
+	return len(w.Operations) == 0 || slices.Contains(w.Operations, operation)
+}
+
+// AdmissionWebhooks are the admission webhooks, in the order they are called, parsed from a
+// JSON list
+type AdmissionWebhooks []AdmissionWebhook
+
+// UnmarshalText parses a JSON list of webhooks, filling in defaults and checking their settings
+func (a *AdmissionWebhooks) UnmarshalText(text []byte) error {
+	var raw []struct {
+		AdmissionWebhook
+		Timeout string `json:"timeout,omitempty"`
+	}
+	if err := json.Unmarshal(text, &raw); err != nil {
+		return fmt.Errorf("invalid admission webhooks: %w", err)
+	}
+
+	webhooks := make(AdmissionWebhooks, 0, len(raw))
+	names := make(map[string]bool)
+	for i, entry := range raw {
+		webhook := entry.AdmissionWebhook
+		if webhook.Name == "" {
+			return fmt.Errorf("admission webhook %d: name is required", i)
+		}
+		if names[webhook.Name] {
+			return fmt.Errorf("admission webhook %s is configured twice", webhook.Name)
+		}
+		names[webhook.Name] = true
+
+		u, err := url.Parse(webhook.URL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("admission webhook %s: url must be an http or https URL, got %q", webhook.Name, webhook.URL)
+		}
+
+		for _, operation := range webhook.Operations {
+			if operation != "publish" && operation != "update" {
+				return fmt.Errorf("admission webhook %s: unknown operation %q, expected publish or update", webhook.Name, operation)
+			}
+		}
+
+		webhook.Timeout = defaultAdmissionTimeout
+		if entry.Timeout != "" {
+			webhook.Timeout, err = time.ParseDuration(entry.Timeout)
+			if err != nil || webhook.Timeout <= 0 || webhook.Timeout > maxAdmissionTimeout {
+				return fmt.Errorf("admission webhook %s: timeout must be a duration up to %s, got %q", webhook.Name, maxAdmissionTimeout, entry.Timeout)
+			}
+		}
+
+		switch webhook.FailurePolicy {
+		case "":
+			webhook.FailurePolicy = AdmissionFailClosed
+		case AdmissionFailClosed, AdmissionFailOpen:
+		default:
+			return fmt.Errorf("admission webhook %s: failure_policy must be closed or open, got %q", webhook.Name, webhook.FailurePolicy)
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	*a = webhooks
+	return nil
+}
@@ -0,0 +1,59 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestAdmissionWebhooks(t *testing.T) {
+	var webhooks config.AdmissionWebhooks
+	require.NoError(t, webhooks.UnmarshalText([]byte(`[
+		{"name": "license", "url": "https://admission.example.com/license"},
+		{"name": "scan", "url": "http://scanner.internal:8080/review", "operations": ["publish"], "timeout": "20s", "failure_policy": "open"}
+	]`)))
+	assert.Equal(t, config.AdmissionWebhooks{
+		{
+			Name:          "license",
+			URL:           "https://admission.example.com/license",
+			Timeout:       5 * time.Second,
+			FailurePolicy: config.AdmissionFailClosed,
+		},
+		{
+			Name:          "scan",
+			URL:           "http://scanner.internal:8080/review",
+			Operations:    []string{"publish"},
+			Timeout:       20 * time.Second,
+			FailurePolicy: config.AdmissionFailOpen,
+		},
+	}, webhooks)
+	assert.True(t, webhooks[0].Reviews("update"))
+	assert.False(t, webhooks[1].Reviews("update"))
+
+	tests := []struct {
+		name   string
+		value  string
+		errMsg string
+	}{
+		{"not JSON", `https://admission.example.com`, "invalid admission webhooks"},
+		{"missing name", `[{"url": "https://admission.example.com"}]`, "name is required"},
+		{"duplicate name", `[{"name": "a", "url": "https://a.example.com"}, {"name": "a", "url": "https://b.example.com"}]`, "configured twice"},
+		{"relative url", `[{"name": "a", "url": "/review"}]`, "must be an http or https URL"},
+		{"unknown operation", `[{"name": "a", "url": "https://a.example.com", "operations": ["delete"]}]`, "unknown operation"},
+		{"invalid timeout", `[{"name": "a", "url": "https://a.example.com", "timeout": "soon"}]`, "timeout must be a duration"},
+		{"long timeout", `[{"name": "a", "url": "https://a.example.com", "timeout": "1m"}]`, "timeout must be a duration"},
+		{"unknown failure policy", `[{"name": "a", "url": "https://a.example.com", "failure_policy": "ignore"}]`, "failure_policy must be closed or open"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var webhooks config.AdmissionWebhooks
+			err := webhooks.UnmarshalText([]byte(tt.value))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
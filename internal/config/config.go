@@ -1,6 +1,9 @@
 package config
 
 import (
+	"fmt"
+	"time"
+
 	env "github.com/caarlos0/env/v11"
 )
 
@@ -11,21 +14,101 @@ const (
 	DatabaseTypeMemory     DatabaseType = "memory"
 )
 
+type LogFormat string
+
+const (
+	LogFormatText LogFormat = "text"
+	LogFormatJSON LogFormat = "json"
+)
+
+type MirrorConflictPolicy string
+
+const (
+	// MirrorConflictPolicyLocalWins keeps the local record when it diverges from upstream
+	MirrorConflictPolicyLocalWins MirrorConflictPolicy = "local-wins"
+	// MirrorConflictPolicyUpstreamWins overwrites the local record with the upstream one
+	MirrorConflictPolicyUpstreamWins MirrorConflictPolicy = "upstream-wins"
+)
+
+// UnmarshalText accepts only the known conflict policies, so a typo fails startup instead of
+// silently keeping local records
+func (p *MirrorConflictPolicy) UnmarshalText(text []byte) error {
+	switch policy := MirrorConflictPolicy(text); policy {
+	case MirrorConflictPolicyLocalWins, MirrorConflictPolicyUpstreamWins:
+		*p = policy
+		return nil
+	default:
+		return fmt.Errorf("invalid mirror conflict policy %q: must be %q or %q",
+			text, MirrorConflictPolicyLocalWins, MirrorConflictPolicyUpstreamWins)
+	}
+}
+
 // Config holds the application configuration
 // See .env.example for more documentation
 type Config struct {
-	ServerAddress       string       `env:"SERVER_ADDRESS" envDefault:":8080"`
-	DatabaseType        DatabaseType `env:"DATABASE_TYPE" envDefault:"postgresql"`
-	DatabaseURL         string       `env:"DATABASE_URL" envDefault:"postgres://localhost:5432/mcp-registry?sslmode=disable"`
-	DatabaseName        string       `env:"DATABASE_NAME" envDefault:"mcp-registry"`
-	CollectionName      string       `env:"COLLECTION_NAME" envDefault:"servers_v2"`
-	LogLevel            string       `env:"LOG_LEVEL" envDefault:"info"`
-	SeedFrom            string       `env:"SEED_FROM" envDefault:""`
-	Version             string       `env:"VERSION" envDefault:"dev"`
-	GithubClientID      string       `env:"GITHUB_CLIENT_ID" envDefault:""`
-	GithubClientSecret  string       `env:"GITHUB_CLIENT_SECRET" envDefault:""`
-	JWTPrivateKey       string       `env:"JWT_PRIVATE_KEY" envDefault:""`
-	EnableAnonymousAuth bool         `env:"ENABLE_ANONYMOUS_AUTH" envDefault:"false"`
+	ServerAddress        string        `env:"SERVER_ADDRESS" envDefault:":8080"`
+	DatabaseType         DatabaseType  `env:"DATABASE_TYPE" envDefault:"postgresql"`
+	DatabaseURL          string        `env:"DATABASE_URL" envDefault:"postgres://localhost:5432/mcp-registry?sslmode=disable"`
+	DatabaseName         string        `env:"DATABASE_NAME" envDefault:"mcp-registry"`
+	CollectionName       string        `env:"COLLECTION_NAME" envDefault:"servers_v2"`
+	DatabaseReadTimeout  time.Duration `env:"DATABASE_READ_TIMEOUT" envDefault:"5s"`
+	DatabaseWriteTimeout time.Duration `env:"DATABASE_WRITE_TIMEOUT" envDefault:"5s"`
+	LogLevel             string        `env:"LOG_LEVEL" envDefault:"info"`
+	LogFormat            LogFormat     `env:"LOG_FORMAT" envDefault:"text"`
+	AccessLog            bool          `env:"ACCESS_LOG" envDefault:"false"`
+	OTLPEndpoint         string        `env:"OTLP_ENDPOINT" envDefault:""`
+	TraceSampleRatio     float64       `env:"TRACE_SAMPLE_RATIO" envDefault:"1.0"`
+	SeedFrom             string        `env:"SEED_FROM" envDefault:""`
+	Version              string        `env:"VERSION" envDefault:"dev"`
+	GithubClientID       string        `env:"GITHUB_CLIENT_ID" envDefault:""`
+	GithubClientSecret   string        `env:"GITHUB_CLIENT_SECRET" envDefault:""`
+	JWTPrivateKey        string        `env:"JWT_PRIVATE_KEY" envDefault:""`
+	EnableAnonymousAuth  bool          `env:"ENABLE_ANONYMOUS_AUTH" envDefault:"false"`
+
+	// GitHub instances trusted for GitHub authentication. Public GitHub's URLs can be overridden,
+	// for example to go through a proxy, and GitHub Enterprise Server instances can be added.
+	GithubAPIURL              string                    `env:"GITHUB_API_URL" envDefault:"https://api.github.com"`
+	GithubOAuthURL            string                    `env:"GITHUB_OAUTH_URL" envDefault:"https://github.com"`
+	GithubOIDCIssuer          string                    `env:"GITHUB_OIDC_ISSUER" envDefault:"https://token.actions.githubusercontent.com"`
+	GitHubEnterpriseInstances GitHubEnterpriseInstances `env:"GITHUB_ENTERPRISE_INSTANCES"`
+
+	// Caching of GitHub lookups. Tokens' users and organizations are cached for
+	// GitHubIdentityCacheTTL (0 disables it); OIDC signing keys for their Cache-Control max-age,
+	// or GitHubJWKSCacheTTL when GitHub doesn't send one.
+	GitHubIdentityCacheTTL time.Duration `env:"GITHUB_IDENTITY_CACHE_TTL" envDefault:"5m"`
+	GitHubJWKSCacheTTL     time.Duration `env:"GITHUB_JWKS_CACHE_TTL" envDefault:"1h"`
+
+	// Challenge-response nonces for DNS and HTTP authentication
+	AuthChallengeTTL             time.Duration `env:"AUTH_CHALLENGE_TTL" envDefault:"2m"`
+	AuthAllowTimestampSignatures bool          `env:"AUTH_ALLOW_TIMESTAMP_SIGNATURES" envDefault:"false"`
+
+	// Outbound HTTP requests, such as fetching HTTP authentication keys and calling GitHub
+	OutboundTimeout              time.Duration `env:"OUTBOUND_TIMEOUT" envDefault:"10s"`
+	OutboundMaxResponseBytes     int64         `env:"OUTBOUND_MAX_RESPONSE_BYTES" envDefault:"1048576"`
+	OutboundRetries              int           `env:"OUTBOUND_RETRIES" envDefault:"2"`
+	OutboundProxy                string        `env:"OUTBOUND_PROXY" envDefault:""`
+	OutboundAllowPrivateNetworks bool          `env:"OUTBOUND_ALLOW_PRIVATE_NETWORKS" envDefault:"false"`
+
+	// Admission webhooks, which review servers before they are published or updated
+	AdmissionWebhooks AdmissionWebhooks `env:"ADMISSION_WEBHOOKS"`
+
+	// Publish policies, CEL rules read from a JSON file that servers must follow
+	PublishPolicies PublishPolicies `env:"PUBLISH_POLICIES_FILE,file"`
+
+	// Read-only replica mode
+	ReadOnly   bool   `env:"READ_ONLY" envDefault:"false"`
+	PrimaryURL string `env:"PRIMARY_URL" envDefault:""`
+
+	// Upstream mirroring
+	MirrorUpstreams       []string             `env:"MIRROR_UPSTREAMS" envSeparator:","`
+	MirrorInterval        time.Duration        `env:"MIRROR_INTERVAL" envDefault:"15m"`
+	MirrorLocalNamespaces []string             `env:"MIRROR_LOCAL_NAMESPACES" envSeparator:","`
+	MirrorConflictPolicy  MirrorConflictPolicy `env:"MIRROR_CONFLICT_POLICY" envDefault:"local-wins"`
+
+	// Install tracking. X-Forwarded-For is only used to find the address of installing clients
+	// when the request comes from one of the TrustedProxies.
+	InstallHashSalt string         `env:"INSTALL_HASH_SALT" envDefault:""`
+	TrustedProxies  TrustedProxies `env:"TRUSTED_PROXIES"`
 }
 
 // NewConfig creates a new configuration with default values
@@ -0,0 +1,27 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestMirrorConflictPolicy(t *testing.T) {
+	assert.Equal(t, config.MirrorConflictPolicyLocalWins, config.NewConfig().MirrorConflictPolicy)
+
+	t.Setenv("MCP_REGISTRY_MIRROR_CONFLICT_POLICY", "upstream-wins")
+	assert.Equal(t, config.MirrorConflictPolicyUpstreamWins, config.NewConfig().MirrorConflictPolicy)
+
+	for _, value := range []string{"upstream_wins", "UPSTREAM-WINS", "remote-wins"} {
+		var policy config.MirrorConflictPolicy
+		err := policy.UnmarshalText([]byte(value))
+		require.Error(t, err, value)
+		assert.Contains(t, err.Error(), "invalid mirror conflict policy")
+
+		t.Setenv("MCP_REGISTRY_MIRROR_CONFLICT_POLICY", value)
+		assert.Panics(t, func() { config.NewConfig() }, value)
+	}
+}
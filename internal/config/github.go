@@ -0,0 +1,123 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// GitHubDotComHost is the host of public GitHub
+	GitHubDotComHost = "github.com"
+	// GitHubDotComNamespace is the namespace prefix of servers published with public GitHub logins
+	GitHubDotComNamespace = "io.github"
+
+	defaultGitHubAPIURL     = "https://api.github.com"
+	defaultGitHubOAuthURL   = "https://github.com"
+	defaultGitHubOIDCIssuer = "https://token.actions.githubusercontent.com"
+)
+
+var namespacePattern = regexp.MustCompile(`^[a-z0-9-]+(\.[a-z0-9-]+)+$`)
+
+// GitHubInstance is a GitHub host the registry trusts for GitHub authentication. Users and
+// organizations of the instance publish under <Namespace>.<owner>/*.
+type GitHubInstance struct {
+	// Host is the hostname of the instance, such as "github.example.com"
+	Host string `json:"host"`
+	// Namespace is the prefix of the instance's server names, such as "com.example.github"
+	Namespace string `json:"namespace"`
+	// APIURL is the base URL of the REST API, https://<host>/api/v3 by default
+	APIURL string `json:"api_url,omitempty"`
+	// OAuthURL is the base URL of the OAuth device flow endpoints, https://<host> by default
+	OAuthURL string `json:"oauth_url,omitempty"`
+	// OIDCIssuer is the issuer of GitHub Actions OIDC tokens, https://<host>/_services/token by default
+	OIDCIssuer string `json:"oidc_issuer,omitempty"`
+	// ClientID is the client ID of the GitHub App or OAuth App publishers log in with
+	ClientID string `json:"client_id,omitempty"`
+}
+
+// JWKSURL is where the instance publishes the keys that sign its OIDC tokens
+func (i GitHubInstance) JWKSURL() string {
+	return i.OIDCIssuer + "/.well-known/jwks"
+}
+
+// GitHubEnterpriseInstances are GitHub Enterprise Server instances, parsed from a JSON list
+type GitHubEnterpriseInstances []GitHubInstance
+
+// UnmarshalText parses a JSON list of instances, filling in default URLs and checking that
+// hosts and namespaces are distinct
+func (g *GitHubEnterpriseInstances) UnmarshalText(text []byte) error {
+	var instances []GitHubInstance
+	if err := json.Unmarshal(text, &instances); err != nil {
+		return fmt.Errorf("invalid GitHub Enterprise instances: %w", err)
+	}
+
+	namespaces := []string{GitHubDotComNamespace}
+	hosts := map[string]bool{GitHubDotComHost: true}
+	issuers := map[string]bool{defaultGitHubOIDCIssuer: true}
+	for i := range instances {
+		instance := &instances[i]
+		instance.Host = strings.ToLower(instance.Host)
+		if instance.Host == "" || strings.ContainsAny(instance.Host, "/:") {
+			return fmt.Errorf("GitHub Enterprise instance %d: host must be a hostname, got %q", i, instance.Host)
+		}
+		if hosts[instance.Host] {
+			return fmt.Errorf("GitHub Enterprise instance %s is configured twice", instance.Host)
+		}
+		hosts[instance.Host] = true
+
+		if !namespacePattern.MatchString(instance.Namespace) {
+			return fmt.Errorf("GitHub Enterprise instance %s: namespace %q must be in reverse DNS form, such as com.example.github", instance.Host, instance.Namespace)
+		}
+		// Names of one instance must never be claimable through another, including public GitHub
+		for _, other := range namespaces {
+			if instance.Namespace == other || strings.HasPrefix(instance.Namespace, other+".") || strings.HasPrefix(other, instance.Namespace+".") {
+				return fmt.Errorf("GitHub Enterprise instance %s: namespace %s overlaps %s", instance.Host, instance.Namespace, other)
+			}
+		}
+		namespaces = append(namespaces, instance.Namespace)
+
+		if instance.APIURL == "" {
+			instance.APIURL = "https://" + instance.Host + "/api/v3"
+		}
+		if instance.OAuthURL == "" {
+			instance.OAuthURL = "https://" + instance.Host
+		}
+		if instance.OIDCIssuer == "" {
+			instance.OIDCIssuer = "https://" + instance.Host + "/_services/token"
+		}
+		instance.APIURL = strings.TrimSuffix(instance.APIURL, "/")
+		instance.OAuthURL = strings.TrimSuffix(instance.OAuthURL, "/")
+		instance.OIDCIssuer = strings.TrimSuffix(instance.OIDCIssuer, "/")
+
+		// The issuer of an OIDC token decides which instance, and so which namespace, it is for
+		if issuers[instance.OIDCIssuer] {
+			return fmt.Errorf("GitHub Enterprise instance %s: OIDC issuer %s is already used by another instance", instance.Host, instance.OIDCIssuer)
+		}
+		issuers[instance.OIDCIssuer] = true
+	}
+
+	*g = instances
+	return nil
+}
+
+// GitHubInstances returns every GitHub instance the registry trusts, public GitHub first
+func (c *Config) GitHubInstances() []GitHubInstance {
+	return append([]GitHubInstance{{
+		Host:       GitHubDotComHost,
+		Namespace:  GitHubDotComNamespace,
+		APIURL:     orDefault(c.GithubAPIURL, defaultGitHubAPIURL),
+		OAuthURL:   orDefault(c.GithubOAuthURL, defaultGitHubOAuthURL),
+		OIDCIssuer: orDefault(c.GithubOIDCIssuer, defaultGitHubOIDCIssuer),
+		ClientID:   c.GithubClientID,
+	}}, c.GitHubEnterpriseInstances...)
+}
+
+// orDefault returns url without a trailing slash, or fallback if url is empty
+func orDefault(url, fallback string) string {
+	if url == "" {
+		return fallback
+	}
+	return strings.TrimSuffix(url, "/")
+}
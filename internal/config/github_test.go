@@ -0,0 +1,75 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestGitHubEnterpriseInstances(t *testing.T) {
+	var instances config.GitHubEnterpriseInstances
+	require.NoError(t, instances.UnmarshalText([]byte(`[
+		{"host": "GitHub.example.com", "namespace": "com.example.github", "client_id": "Iv1.abc"},
+		{"host": "ghe.example.org", "namespace": "org.example.ghe", "api_url": "https://api.ghe.example.org/", "oidc_issuer": "https://oidc.ghe.example.org"}
+	]`)))
+
+	cfg := &config.Config{GitHubEnterpriseInstances: instances}
+	assert.Equal(t, []config.GitHubInstance{
+		{
+			Host:       "github.com",
+			Namespace:  "io.github",
+			APIURL:     "https://api.github.com",
+			OAuthURL:   "https://github.com",
+			OIDCIssuer: "https://token.actions.githubusercontent.com",
+		},
+		{
+			Host:       "github.example.com",
+			Namespace:  "com.example.github",
+			APIURL:     "https://github.example.com/api/v3",
+			OAuthURL:   "https://github.example.com",
+			OIDCIssuer: "https://github.example.com/_services/token",
+			ClientID:   "Iv1.abc",
+		},
+		{
+			Host:       "ghe.example.org",
+			Namespace:  "org.example.ghe",
+			APIURL:     "https://api.ghe.example.org",
+			OAuthURL:   "https://ghe.example.org",
+			OIDCIssuer: "https://oidc.ghe.example.org",
+		},
+	}, cfg.GitHubInstances())
+	assert.Equal(t, "https://github.example.com/_services/token/.well-known/jwks", cfg.GitHubInstances()[1].JWKSURL())
+
+	tests := []struct {
+		name   string
+		value  string
+		errMsg string
+	}{
+		{"not JSON", `github.example.com`, "invalid GitHub Enterprise instances"},
+		{"missing host", `[{"namespace": "com.example.github"}]`, "host must be a hostname"},
+		{"URL as host", `[{"host": "https://github.example.com", "namespace": "com.example.github"}]`, "host must be a hostname"},
+		{"github.com", `[{"host": "github.com", "namespace": "com.example.github"}]`, "configured twice"},
+		{"missing namespace", `[{"host": "github.example.com"}]`, "reverse DNS form"},
+		{"io.github namespace", `[{"host": "github.example.com", "namespace": "io.github.example"}]`, "overlaps io.github"},
+		{"nested namespaces", `[
+			{"host": "github.example.com", "namespace": "com.example"},
+			{"host": "ghe.example.com", "namespace": "com.example.ghe"}
+		]`, "overlaps com.example"},
+		{"shared issuer", `[
+			{"host": "github.example.com", "namespace": "com.example.github", "oidc_issuer": "https://token.example.com"},
+			{"host": "ghe.example.com", "namespace": "com.example.ghe", "oidc_issuer": "https://token.example.com"}
+		]`, "already used"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var instances config.GitHubEnterpriseInstances
+			err := instances.UnmarshalText([]byte(tt.value))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
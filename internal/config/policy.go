@@ -0,0 +1,64 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// PolicyOperations are the operations publish policies can apply to
+var PolicyOperations = []string{"publish", "update", "delete"}
+
+// PublishPolicy is a rule every server must follow when it is published, updated or deleted.
+// Its expressions are CEL, and are compiled by the policy package.
+type PublishPolicy struct {
+	// Name identifies the policy in violations and metrics
+	Name string `json:"name"`
+	// Operations are the operations the policy applies to, all by default
+	Operations []string `json:"operations,omitempty"`
+	// Match is an optional expression that decides whether the policy applies to a request
+	Match string `json:"match,omitempty"`
+	// Expression must evaluate to true for the request to be allowed
+	Expression string `json:"expression"`
+	// Message explains a violation to the caller
+	Message string `json:"message,omitempty"`
+}
+
+// Applies reports whether the policy applies to operation
+func (p PublishPolicy) Applies(operation string) bool {
+	return len(p.Operations) == 0 || slices.Contains(p.Operations, operation)
+}
+
+// PublishPolicies are the publish policies, parsed from a JSON list
+type PublishPolicies []PublishPolicy
+
+// UnmarshalText parses a JSON list of policies, checking that they are named and have an expression
+func (p *PublishPolicies) UnmarshalText(text []byte) error {
+	var policies []PublishPolicy
+	if err := json.Unmarshal(text, &policies); err != nil {
+		return fmt.Errorf("invalid publish policies: %w", err)
+	}
+
+	names := make(map[string]bool)
+	for i, policy := range policies {
+		if policy.Name == "" {
+			return fmt.Errorf("publish policy %d: name is required", i)
+		}
+		if names[policy.Name] {
+			return fmt.Errorf("publish policy %s is configured twice", policy.Name)
+		}
+		names[policy.Name] = true
+
+		if policy.Expression == "" {
+			return fmt.Errorf("publish policy %s: expression is required", policy.Name)
+		}
+		for _, operation := range policy.Operations {
+			if !slices.Contains(PolicyOperations, operation) {
+				return fmt.Errorf("publish policy %s: unknown operation %q, expected publish, update or delete", policy.Name, operation)
+			}
+		}
+	}
+
+	*p = policies
+	return nil
+}
@@ -0,0 +1,43 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestPublishPolicies(t *testing.T) {
+	var policies config.PublishPolicies
+	require.NoError(t, policies.UnmarshalText([]byte(`[
+		{"name": "https-remotes", "expression": "server.?remotes.orValue([]).all(r, r.url.startsWith('https://'))"},
+		{"name": "no-deletes", "operations": ["delete"], "expression": "false", "message": "servers can't be deleted"}
+	]`)))
+	require.Len(t, policies, 2)
+	assert.True(t, policies[0].Applies("update"))
+	assert.True(t, policies[1].Applies("delete"))
+	assert.False(t, policies[1].Applies("publish"))
+	assert.Equal(t, "servers can't be deleted", policies[1].Message)
+
+	tests := []struct {
+		name   string
+		value  string
+		errMsg string
+	}{
+		{"not JSON", `server.name != ''`, "invalid publish policies"},
+		{"missing name", `[{"expression": "true"}]`, "name is required"},
+		{"duplicate name", `[{"name": "a", "expression": "true"}, {"name": "a", "expression": "false"}]`, "configured twice"},
+		{"missing expression", `[{"name": "a"}]`, "expression is required"},
+		{"unknown operation", `[{"name": "a", "expression": "true", "operations": ["list"]}]`, "unknown operation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var policies config.PublishPolicies
+			err := policies.UnmarshalText([]byte(tt.value))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// TrustedProxies are the addresses of the reverse proxies whose X-Forwarded-For headers are
+// trusted, parsed from a comma-separated list of IP addresses and CIDR ranges
+type TrustedProxies []netip.Prefix
+
+// UnmarshalText parses a comma-separated list of IP addresses and CIDR ranges
+func (p *TrustedProxies) UnmarshalText(text []byte) error {
+	var proxies TrustedProxies
+	for _, value := range strings.Split(string(text), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if strings.Contains(value, "/") {
+			prefix, err := netip.ParsePrefix(value)
+			if err != nil {
+				return fmt.Errorf("invalid trusted proxy %q: %w", value, err)
+			}
+			proxies = append(proxies, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxy %q: %w", value, err)
+		}
+		proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	*p = proxies
+	return nil
+}
+
+// Trusts reports whether addr is one of the trusted proxies
+func (p TrustedProxies) Trusts(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range p {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
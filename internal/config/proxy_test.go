@@ -0,0 +1,28 @@
+package config_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestTrustedProxies(t *testing.T) {
+	var proxies config.TrustedProxies
+	require.NoError(t, proxies.UnmarshalText([]byte("10.0.0.0/8, 192.0.2.1,2001:db8::/32")))
+	require.Len(t, proxies, 3)
+
+	assert.True(t, proxies.Trusts(netip.MustParseAddr("10.1.2.3")))
+	assert.True(t, proxies.Trusts(netip.MustParseAddr("::ffff:10.1.2.3")))
+	assert.True(t, proxies.Trusts(netip.MustParseAddr("192.0.2.1")))
+	assert.True(t, proxies.Trusts(netip.MustParseAddr("2001:db8::1")))
+	assert.False(t, proxies.Trusts(netip.MustParseAddr("192.0.2.2")))
+	assert.False(t, config.TrustedProxies(nil).Trusts(netip.MustParseAddr("10.1.2.3")))
+
+	err := proxies.UnmarshalText([]byte("10.0.0.0/8,proxy.internal"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid trusted proxy")
+}
@@ -3,17 +3,32 @@ package database
 import (
 	"context"
 	"errors"
+	"net/http"
+	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/model"
 )
 
-// Common database errors
+// Common database errors. Errors returned by Database implementations wrap one of these,
+// so callers should match them with errors.Is rather than comparing messages.
 var (
 	ErrNotFound       = errors.New("record not found")
 	ErrAlreadyExists  = errors.New("record already exists")
 	ErrInvalidInput   = errors.New("invalid input")
 	ErrDatabase       = errors.New("database error")
-	ErrInvalidVersion = errors.New("invalid version: cannot publish older version after newer version")
+	ErrInvalidVersion = errors.New("invalid version")
+)
+
+// List filter keys and values understood by every Database implementation, in addition to
+// the "name", "version" and "status" equality filters. Only the latest version of each server
+// is listed, unless the "version" filter is set.
+const (
+	// FilterMinInstalls only includes servers with at least this many installs across all versions (int)
+	FilterMinInstalls = "min_installs"
+	// FilterSort selects the order of results; SortInstalls orders by installs across all versions, most first
+	FilterSort = "sort"
+	// SortInstalls is the FilterSort value that orders servers by installs
+	SortInstalls = "installs"
 )
 
 // Database defines the interface for database operations with extension wrapper architecture
@@ -28,12 +43,49 @@ type Database interface {
 	Update(ctx context.Context, id string, serverDetail *model.ServerDetail) error
 	// Delete removes a ServerDetail from the database by ID
 	Delete(ctx context.Context, id string) error
-	// ImportSeed imports initial data from a seed file
-	ImportSeed(ctx context.Context, seedFilePath string) error
+	// ImportSeed imports initial data from a seed file, fetching remote seeds with client
+	ImportSeed(ctx context.Context, client *http.Client, seedFilePath string) error
+	// RecordInstall counts an install of a server version on the day containing the given time.
+	// clientHashes identify the installing client, such as by ID and by address; it returns false if any of them was
+	// already counted for the version that day.
+	RecordInstall(ctx context.Context, id string, day time.Time, clientHashes ...string) (bool, error)
+	// InstallStats returns install statistics for the given server versions as of the day containing the given time
+	InstallStats(ctx context.Context, ids []string, day time.Time) (map[string]*model.InstallStats, error)
+	// ListClients retrieves the latest version of every ClientRecord, with optional "name" filtering
+	ListClients(ctx context.Context, filter map[string]any, cursor string, limit int) ([]*model.ClientRecord, string, error)
+	// GetClientByID retrieves a single ClientRecord by its ID
+	GetClientByID(ctx context.Context, id string) (*model.ClientRecord, error)
+	// PublishClient adds a new client version to the database
+	PublishClient(ctx context.Context, clientDetail model.ClientDetail, publisherExtensions map[string]interface{}) (*model.ClientRecord, error)
+	// ConsumeNonce marks an authentication challenge nonce as used until it expires.
+	// It returns false if the nonce was already used, so that signed challenges can't be replayed.
+	ConsumeNonce(ctx context.Context, nonce string, expiresAt time.Time) (bool, error)
+	// GitHubOIDCPolicies returns the GitHub OIDC policies of an owner on the GitHub instance at host,
+	// matched case-insensitively. It returns an empty list if the owner has none.
+	GitHubOIDCPolicies(ctx context.Context, host, owner string) ([]model.GitHubOIDCPolicy, error)
+	// SetGitHubOIDCPolicies replaces the GitHub OIDC policies of an owner on a GitHub instance. An empty list removes them.
+	SetGitHubOIDCPolicies(ctx context.Context, host, owner string, policies []model.GitHubOIDCPolicy) error
+	// MirroredHash returns the hash of the upstream copy the mirror last applied to a server version,
+	// or an empty string if the version wasn't mirrored
+	MirroredHash(ctx context.Context, id string) (string, error)
+	// SetMirroredHash records the hash of the upstream copy the mirror applied to a server version
+	SetMirroredHash(ctx context.Context, id, upstream, hash string) error
+	// Stats returns the number of distinct servers and published versions
+	Stats(ctx context.Context) (*CatalogStats, error)
+	// Connection returns information about the underlying database connection
+	Connection() *ConnectionInfo
 	// Close closes the database connection
 	Close() error
 }
 
+// CatalogStats describes the size of the registry catalog
+type CatalogStats struct {
+	// Servers is the number of distinct server names
+	Servers int
+	// Versions is the number of published server versions
+	Versions int
+}
+
 // ConnectionType represents the type of database connection
 type ConnectionType string
 
@@ -51,7 +103,7 @@ type ConnectionInfo struct {
 	// IsConnected indicates whether the database is currently connected
 	IsConnected bool
 	// Raw provides access to the underlying connection object, which will vary by implementation
-	// For PostgreSQL, this will be *pgx.Conn
+	// For PostgreSQL, this will be *pgxpool.Pool
 	// For MemoryDB, this will be map[string]*model.MCPRegistry
 	Raw any
 }
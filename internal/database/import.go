@@ -10,6 +10,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/httpclient"
 	"github.com/modelcontextprotocol/registry/internal/model"
 )
 
@@ -18,7 +20,7 @@ import (
 // 2. Direct HTTP URLs to seed.json files - expects extension wrapper format  
 // 3. Registry root URLs (automatically appends /v0/servers and paginates)
 // Only the extension wrapper format is supported (array of ServerResponse objects)
-func ReadSeedFile(ctx context.Context, path string) ([]*model.ServerRecord, error) {
+func ReadSeedFile(ctx context.Context, client *http.Client, path string) ([]*model.ServerRecord, error) {
 	var data []byte
 	var err error
 
@@ -26,10 +28,10 @@ func ReadSeedFile(ctx context.Context, path string) ([]*model.ServerRecord, erro
 		// Handle HTTP URLs
 		if strings.HasSuffix(path, "/v0/servers") || strings.Contains(path, "/v0/servers") {
 			// This is a registry API endpoint - fetch paginated data
-			return fetchFromRegistryAPI(ctx, path)
+			return FetchFromRegistryAPI(ctx, client, path)
 		}
 		// This is a direct file URL
-		data, err = fetchFromHTTP(ctx, path)
+		data, err = fetchFromHTTP(ctx, client, path)
 	} else {
 		// Handle local file paths
 		data, err = os.ReadFile(path)
@@ -59,13 +61,24 @@ func ReadSeedFile(ctx context.Context, path string) ([]*model.ServerRecord, erro
 	return records, nil
 }
 
-func fetchFromHTTP(ctx context.Context, url string) ([]byte, error) {
+// NewImportClient creates the client that fetches seed files and upstream registry pages, with
+// the configured outbound proxy and retries. Their URLs are configured by the operator, so they
+// may be on a private network, and whole registries can be large.
+func NewImportClient(cfg *config.Config) *http.Client {
+	opts := httpclient.FromConfig(cfg, "registry_import")
+	opts.Timeout = max(opts.Timeout, time.Minute)
+	opts.MaxResponseBytes = max(opts.MaxResponseBytes, 256<<20)
+	opts.AllowPrivateNetworks = true
+	return httpclient.New(opts)
+}
+
+func fetchFromHTTP(ctx context.Context, client *http.Client, url string) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch from HTTP: %w", err)
 	}
@@ -78,7 +91,8 @@ func fetchFromHTTP(ctx context.Context, url string) ([]byte, error) {
 	return io.ReadAll(resp.Body)
 }
 
-func fetchFromRegistryAPI(ctx context.Context, baseURL string) ([]*model.ServerRecord, error) {
+// FetchFromRegistryAPI pages through a registry's /v0/servers endpoint and returns every server record
+func FetchFromRegistryAPI(ctx context.Context, client *http.Client, baseURL string) ([]*model.ServerRecord, error) {
 	var allRecords []*model.ServerRecord
 	cursor := ""
 
@@ -92,7 +106,7 @@ func fetchFromRegistryAPI(ctx context.Context, baseURL string) ([]*model.ServerR
 			}
 		}
 
-		data, err := fetchFromHTTP(ctx, url)
+		data, err := fetchFromHTTP(ctx, client, url)
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch page from registry API: %w", err)
 		}
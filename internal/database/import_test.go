@@ -8,6 +8,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/model"
 	"github.com/stretchr/testify/assert"
@@ -55,7 +56,7 @@ func TestReadSeedFile_LocalFile(t *testing.T) {
 	defer os.Remove(tempFile)
 
 	// Test reading the file
-	result, err := database.ReadSeedFile(context.Background(), tempFile)
+	result, err := database.ReadSeedFile(context.Background(), database.NewImportClient(&config.Config{}), tempFile)
 	assert.NoError(t, err)
 	assert.Len(t, result, 1)
 	assert.Equal(t, "test-server-1", result[0].ServerJSON.Name)
@@ -86,7 +87,7 @@ func TestReadSeedFile_DirectHTTPURL(t *testing.T) {
 	defer server.Close()
 
 	// Test reading from HTTP URL ending in .json
-	result, err := database.ReadSeedFile(context.Background(), server.URL+"/seed.json")
+	result, err := database.ReadSeedFile(context.Background(), database.NewImportClient(&config.Config{}), server.URL+"/seed.json")
 	assert.NoError(t, err)
 	assert.Len(t, result, 1)
 	assert.Equal(t, "test-server-1", result[0].ServerJSON.Name)
@@ -204,7 +205,7 @@ func TestReadSeedFile_RegistryURL(t *testing.T) {
 	defer server.Close()
 
 	// Test reading from registry root URL (this should trigger pagination)
-	result, err := database.ReadSeedFile(context.Background(), server.URL+"/v0/servers")
+	result, err := database.ReadSeedFile(context.Background(), database.NewImportClient(&config.Config{}), server.URL+"/v0/servers")
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
 
@@ -0,0 +1,198 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+// InstrumentedDB wraps a Database and records latency for every operation
+// and version conflicts for rejected publishes
+type InstrumentedDB struct {
+	db      Database
+	metrics *telemetry.Metrics
+}
+
+// NewInstrumentedDB wraps db so that its operations are recorded in metrics
+func NewInstrumentedDB(db Database, metrics *telemetry.Metrics) *InstrumentedDB {
+	return &InstrumentedDB{
+		db:      db,
+		metrics: metrics,
+	}
+}
+
+// observe records the duration and outcome of a database operation
+func (i *InstrumentedDB) observe(ctx context.Context, operation string, start time.Time, err error) {
+	outcome := "success"
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrNotFound):
+		outcome = "not_found"
+	default:
+		outcome = "error"
+	}
+
+	i.metrics.DBOperationDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("outcome", outcome),
+	))
+}
+
+// List retrieves ServerRecord entries with optional filtering and pagination
+func (i *InstrumentedDB) List(ctx context.Context, filter map[string]any, cursor string, limit int) ([]*model.ServerRecord, string, error) {
+	start := time.Now()
+	records, nextCursor, err := i.db.List(ctx, filter, cursor, limit)
+	i.observe(ctx, "list", start, err)
+	return records, nextCursor, err
+}
+
+// GetByID retrieves a single ServerRecord by its ID
+func (i *InstrumentedDB) GetByID(ctx context.Context, id string) (*model.ServerRecord, error) {
+	start := time.Now()
+	record, err := i.db.GetByID(ctx, id)
+	i.observe(ctx, "get_by_id", start, err)
+	return record, err
+}
+
+// Publish adds a new server to the database and counts version conflicts
+func (i *InstrumentedDB) Publish(ctx context.Context, serverDetail model.ServerDetail, publisherExtensions map[string]interface{}) (*model.ServerRecord, error) {
+	start := time.Now()
+	record, err := i.db.Publish(ctx, serverDetail, publisherExtensions)
+	i.observe(ctx, "publish", start, err)
+	if errors.Is(err, ErrInvalidVersion) {
+		i.metrics.VersionConflicts.Add(ctx, 1)
+	}
+	return record, err
+}
+
+// RecordInstall counts an install of a server version
+func (i *InstrumentedDB) RecordInstall(ctx context.Context, id string, day time.Time, clientHashes ...string) (bool, error) {
+	start := time.Now()
+	counted, err := i.db.RecordInstall(ctx, id, day, clientHashes...)
+	i.observe(ctx, "record_install", start, err)
+	return counted, err
+}
+
+// InstallStats returns install statistics for the given server versions
+func (i *InstrumentedDB) InstallStats(ctx context.Context, ids []string, day time.Time) (map[string]*model.InstallStats, error) {
+	start := time.Now()
+	stats, err := i.db.InstallStats(ctx, ids, day)
+	i.observe(ctx, "install_stats", start, err)
+	return stats, err
+}
+
+// ListClients retrieves ClientRecord entries with optional filtering and pagination
+func (i *InstrumentedDB) ListClients(ctx context.Context, filter map[string]any, cursor string, limit int) ([]*model.ClientRecord, string, error) {
+	start := time.Now()
+	records, nextCursor, err := i.db.ListClients(ctx, filter, cursor, limit)
+	i.observe(ctx, "list_clients", start, err)
+	return records, nextCursor, err
+}
+
+// GetClientByID retrieves a single ClientRecord by its ID
+func (i *InstrumentedDB) GetClientByID(ctx context.Context, id string) (*model.ClientRecord, error) {
+	start := time.Now()
+	record, err := i.db.GetClientByID(ctx, id)
+	i.observe(ctx, "get_client_by_id", start, err)
+	return record, err
+}
+
+// PublishClient adds a new client version to the database and counts version conflicts
+func (i *InstrumentedDB) PublishClient(ctx context.Context, clientDetail model.ClientDetail, publisherExtensions map[string]interface{}) (*model.ClientRecord, error) {
+	start := time.Now()
+	record, err := i.db.PublishClient(ctx, clientDetail, publisherExtensions)
+	i.observe(ctx, "publish_client", start, err)
+	if errors.Is(err, ErrInvalidVersion) {
+		i.metrics.VersionConflicts.Add(ctx, 1)
+	}
+	return record, err
+}
+
+// Update updates an existing ServerDetail in the database
+func (i *InstrumentedDB) Update(ctx context.Context, id string, serverDetail *model.ServerDetail) error {
+	start := time.Now()
+	err := i.db.Update(ctx, id, serverDetail)
+	i.observe(ctx, "update", start, err)
+	return err
+}
+
+// Delete removes a ServerDetail from the database by ID
+func (i *InstrumentedDB) Delete(ctx context.Context, id string) error {
+	start := time.Now()
+	err := i.db.Delete(ctx, id)
+	i.observe(ctx, "delete", start, err)
+	return err
+}
+
+// ImportSeed imports initial data from a seed file
+func (i *InstrumentedDB) ImportSeed(ctx context.Context, client *http.Client, seedFilePath string) error {
+	start := time.Now()
+	err := i.db.ImportSeed(ctx, client, seedFilePath)
+	i.observe(ctx, "import_seed", start, err)
+	return err
+}
+
+// ConsumeNonce marks an authentication nonce as used until it expires
+func (i *InstrumentedDB) ConsumeNonce(ctx context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	start := time.Now()
+	consumed, err := i.db.ConsumeNonce(ctx, nonce, expiresAt)
+	i.observe(ctx, "consume_nonce", start, err)
+	return consumed, err
+}
+
+// GitHubOIDCPolicies returns the GitHub OIDC policies of an owner on a GitHub instance
+func (i *InstrumentedDB) GitHubOIDCPolicies(ctx context.Context, host, owner string) ([]model.GitHubOIDCPolicy, error) {
+	start := time.Now()
+	policies, err := i.db.GitHubOIDCPolicies(ctx, host, owner)
+	i.observe(ctx, "github_oidc_policies", start, err)
+	return policies, err
+}
+
+// SetGitHubOIDCPolicies replaces the GitHub OIDC policies of an owner on a GitHub instance
+func (i *InstrumentedDB) SetGitHubOIDCPolicies(ctx context.Context, host, owner string, policies []model.GitHubOIDCPolicy) error {
+	start := time.Now()
+	err := i.db.SetGitHubOIDCPolicies(ctx, host, owner, policies)
+	i.observe(ctx, "set_github_oidc_policies", start, err)
+	return err
+}
+
+// MirroredHash returns the hash of the upstream copy the mirror last applied to a server version
+func (i *InstrumentedDB) MirroredHash(ctx context.Context, id string) (string, error) {
+	start := time.Now()
+	hash, err := i.db.MirroredHash(ctx, id)
+	i.observe(ctx, "mirrored_hash", start, err)
+	return hash, err
+}
+
+// SetMirroredHash records the hash of the upstream copy the mirror applied to a server version
+func (i *InstrumentedDB) SetMirroredHash(ctx context.Context, id, upstream, hash string) error {
+	start := time.Now()
+	err := i.db.SetMirroredHash(ctx, id, upstream, hash)
+	i.observe(ctx, "set_mirrored_hash", start, err)
+	return err
+}
+
+// Stats returns the number of distinct servers and published versions
+func (i *InstrumentedDB) Stats(ctx context.Context) (*CatalogStats, error) {
+	start := time.Now()
+	stats, err := i.db.Stats(ctx)
+	i.observe(ctx, "stats", start, err)
+	return stats, err
+}
+
+// Connection returns information about the underlying database connection
+func (i *InstrumentedDB) Connection() *ConnectionInfo {
+	return i.db.Connection()
+}
+
+// Close closes the underlying database
+func (i *InstrumentedDB) Close() error {
+	return i.db.Close()
+}
@@ -0,0 +1,75 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+func testServer(version string) model.ServerDetail {
+	return model.ServerDetail{
+		Name:          "com.example/test-server",
+		Description:   "A test server",
+		Repository:    model.Repository{URL: "https://github.com/example/test-server", Source: "github"},
+		VersionDetail: model.VersionDetail{Version: version},
+	}
+}
+
+func TestInstrumentedDB(t *testing.T) {
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	metrics, err := telemetry.NewMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"))
+	require.NoError(t, err)
+
+	db := database.NewInstrumentedDB(database.NewMemoryDB(map[string]*model.ServerDetail{}), metrics)
+
+	_, err = db.Publish(ctx, testServer("1.0.0"), nil)
+	require.NoError(t, err)
+	_, err = db.Publish(ctx, testServer("1.1.0"), nil)
+	require.NoError(t, err)
+
+	// Publishing a version that is not newer than the latest is a version conflict
+	_, err = db.Publish(ctx, testServer("1.0.5"), nil)
+	require.ErrorIs(t, err, database.ErrInvalidVersion)
+
+	_, err = db.GetByID(ctx, "00000000-0000-0000-0000-000000000000")
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	stats, err := db.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &database.CatalogStats{Servers: 1, Versions: 2}, stats)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+
+	var conflicts int64
+	operations := map[string]uint64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch m.Name {
+			case "mcp_registry.publish.version_conflicts":
+				conflicts = m.Data.(metricdata.Sum[int64]).DataPoints[0].Value
+			case "mcp_registry.db.operation.duration":
+				for _, dp := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+					operation, _ := dp.Attributes.Value("operation")
+					outcome, _ := dp.Attributes.Value("outcome")
+					operations[operation.AsString()+"/"+outcome.AsString()] += dp.Count
+				}
+			}
+		}
+	}
+
+	assert.Equal(t, int64(1), conflicts)
+	assert.Equal(t, uint64(2), operations["publish/success"])
+	assert.Equal(t, uint64(1), operations["publish/error"])
+	assert.Equal(t, uint64(1), operations["get_by_id/not_found"])
+	assert.Equal(t, uint64(1), operations["stats/success"])
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sort"
 	"strconv"
 	"strings"
@@ -15,8 +16,14 @@ import (
 
 // MemoryDB is an in-memory implementation of the Database interface
 type MemoryDB struct {
-	entries map[string]*model.ServerRecord // maps registry metadata ID to ServerRecord
-	mu      sync.RWMutex
+	entries        map[string]*model.ServerRecord      // maps registry metadata ID to ServerRecord
+	installs       map[string]map[string]int64         // maps registry metadata ID to install counts per day
+	installClients map[string]map[string]struct{}      // maps day to the registry metadata ID and client hash pairs counted that day
+	clients        map[string]*model.ClientRecord      // maps registry metadata ID to ClientRecord
+	nonces         map[string]time.Time                // maps consumed authentication nonces to when they expire
+	oidcPolicies   map[string][]model.GitHubOIDCPolicy // maps lower-cased GitHub owners to their OIDC policies
+	mirrored       map[string]string                   // maps registry metadata ID to the hash of the mirrored upstream copy
+	mu             sync.RWMutex
 }
 
 // NewMemoryDB creates a new instance of the in-memory database
@@ -40,17 +47,23 @@ func NewMemoryDB(e map[string]*model.ServerDetail) *MemoryDB {
 		serverRecords[registryID] = record
 	}
 	return &MemoryDB{
-		entries: serverRecords,
+		entries:        serverRecords,
+		installs:       make(map[string]map[string]int64),
+		installClients: make(map[string]map[string]struct{}),
+		clients:        make(map[string]*model.ClientRecord),
+		nonces:         make(map[string]time.Time),
+		oidcPolicies:   make(map[string][]model.GitHubOIDCPolicy),
+		mirrored:       make(map[string]string),
 	}
 }
 
-// compareSemanticVersions compares two semantic version strings
+// CompareSemanticVersions compares two semantic version strings
 // Returns:
 //
 //	-1 if version1 < version2
 //	 0 if version1 == version2
 //	+1 if version1 > version2
-func compareSemanticVersions(version1, version2 string) int {
+func CompareSemanticVersions(version1, version2 string) int {
 	// Simple semantic version comparison
 	// Assumes format: major.minor.patch
 
@@ -112,13 +125,18 @@ func (db *MemoryDB) List(
 
 
 	// Convert all entries to a slice for pagination, filter by is_latest
+	// unless a specific version was requested
+	_, anyVersion := filter["version"]
 	var allEntries []*model.ServerRecord
 	for _, entry := range db.entries {
-		if entry.RegistryMetadata.IsLatest {
+		if entry.RegistryMetadata.IsLatest || anyVersion {
 			allEntries = append(allEntries, entry)
 		}
 	}
 
+	// Installs across all versions, needed to filter and sort by installs
+	installTotals := db.installTotalsByName()
+
 	// Simple filtering implementation
 	var filteredEntries []*model.ServerRecord
 	for _, entry := range allEntries {
@@ -139,6 +157,10 @@ func (db *MemoryDB) List(
 				if string(entry.ServerJSON.Status) != value.(string) {
 					include = false
 				}
+			case FilterMinInstalls:
+				if installTotals[entry.ServerJSON.Name] < int64(value.(int)) {
+					include = false
+				}
 			}
 		}
 
@@ -147,8 +169,17 @@ func (db *MemoryDB) List(
 		}
 	}
 
-	// Sort filteredEntries by registry metadata ID for consistent pagination
+	// Sort filteredEntries by registry metadata ID for consistent pagination,
+	// after the number of installs if requested
+	sortByInstalls := filter[FilterSort] == SortInstalls
 	sort.Slice(filteredEntries, func(i, j int) bool {
+		if sortByInstalls {
+			installsI := installTotals[filteredEntries[i].ServerJSON.Name]
+			installsJ := installTotals[filteredEntries[j].ServerJSON.Name]
+			if installsI != installsJ {
+				return installsI > installsJ
+			}
+		}
 		return filteredEntries[i].RegistryMetadata.ID < filteredEntries[j].RegistryMetadata.ID
 	})
 
@@ -210,12 +241,12 @@ func (db *MemoryDB) Publish(ctx context.Context, serverDetail model.ServerDetail
 	// Extract name and version for validation
 	name := serverDetail.Name
 	if name == "" {
-		return nil, fmt.Errorf("name is required in server JSON")
+		return nil, fmt.Errorf("%w: name is required in server JSON", ErrInvalidInput)
 	}
 
 	version := serverDetail.VersionDetail.Version
 	if version == "" {
-		return nil, fmt.Errorf("version is required in version_detail")
+		return nil, fmt.Errorf("%w: version is required in version_detail", ErrInvalidInput)
 	}
 
 	db.mu.Lock()
@@ -233,14 +264,14 @@ func (db *MemoryDB) Publish(ctx context.Context, serverDetail model.ServerDetail
 	// Version comparison
 	if existingRecord != nil {
 		existingVersion := existingRecord.ServerJSON.VersionDetail.Version
-		if compareSemanticVersions(version, existingVersion) <= 0 {
-			return nil, fmt.Errorf("version must be greater than existing version %s", existingVersion)
+		if CompareSemanticVersions(version, existingVersion) <= 0 {
+			return nil, fmt.Errorf("%w: version must be greater than existing version %s", ErrInvalidVersion, existingVersion)
 		}
 	}
 
 	// Validate repository URL
 	if serverDetail.Repository.URL == "" {
-		return nil, ErrInvalidInput
+		return nil, fmt.Errorf("%w: repository URL is required", ErrInvalidInput)
 	}
 
 	// Create new registry metadata
@@ -272,14 +303,14 @@ func (db *MemoryDB) Publish(ctx context.Context, serverDetail model.ServerDetail
 }
 
 // ImportSeed imports initial data from a seed file into memory database
-func (db *MemoryDB) ImportSeed(ctx context.Context, seedFilePath string) error {
+func (db *MemoryDB) ImportSeed(ctx context.Context, client *http.Client, seedFilePath string) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
 
 	// This will need to be updated to work with the new ServerRecord format
 	// Read seed data using the shared ReadSeedFile function
-	seedData, err := ReadSeedFile(ctx, seedFilePath)
+	seedData, err := ReadSeedFile(ctx, client, seedFilePath)
 	if err != nil {
 		return fmt.Errorf("failed to read seed file: %w", err)
 	}
@@ -311,8 +342,15 @@ func (db *MemoryDB) Update(_ context.Context, id string, serverDetail *model.Ser
 
 	// Validate version if provided
 	if serverDetail.VersionDetail.Version != "" {
-		if compareSemanticVersions(serverDetail.VersionDetail.Version, existingRecord.ServerJSON.VersionDetail.Version) < 0 {
-			return fmt.Errorf("invalid version: cannot update to an older version")
+		if CompareSemanticVersions(serverDetail.VersionDetail.Version, existingRecord.ServerJSON.VersionDetail.Version) < 0 {
+			return fmt.Errorf("%w: cannot update to an older version", ErrInvalidVersion)
+		}
+	}
+
+	// Name and version are unique, as in PostgreSQL
+	for otherID, other := range db.entries {
+		if otherID != id && other.ServerJSON.Name == serverDetail.Name && other.ServerJSON.VersionDetail.Version == serverDetail.VersionDetail.Version {
+			return fmt.Errorf("%w: server %s version %s", ErrAlreadyExists, serverDetail.Name, serverDetail.VersionDetail.Version)
 		}
 	}
 
@@ -334,11 +372,356 @@ func (db *MemoryDB) Delete(_ context.Context, id string) error {
 		return ErrNotFound
 	}
 
-	// Delete the record
+	// Delete the record, its install counts and mirror origin
 	delete(db.entries, id)
+	delete(db.installs, id)
+	delete(db.mirrored, id)
+	return nil
+}
+
+// ListClients retrieves the latest version of every ClientRecord with optional filtering and pagination
+func (db *MemoryDB) ListClients(ctx context.Context, filter map[string]any, cursor string, limit int) ([]*model.ClientRecord, string, error) {
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var filteredEntries []*model.ClientRecord
+	for _, entry := range db.clients {
+		if !entry.RegistryMetadata.IsLatest {
+			continue
+		}
+		if name, ok := filter["name"]; ok && entry.ClientJSON.Name != name.(string) {
+			continue
+		}
+		filteredEntries = append(filteredEntries, entry)
+	}
+
+	// Sort by registry metadata ID for consistent pagination
+	sort.Slice(filteredEntries, func(i, j int) bool {
+		return filteredEntries[i].RegistryMetadata.ID < filteredEntries[j].RegistryMetadata.ID
+	})
+
+	startIdx := 0
+	if cursor != "" {
+		for i, entry := range filteredEntries {
+			if entry.RegistryMetadata.ID == cursor {
+				startIdx = i + 1 // Start after the cursor
+				break
+			}
+		}
+	}
+
+	endIdx := min(startIdx+limit, len(filteredEntries))
+
+	result := []*model.ClientRecord{}
+	if startIdx < len(filteredEntries) {
+		result = filteredEntries[startIdx:endIdx]
+	}
+
+	nextCursor := ""
+	if endIdx < len(filteredEntries) {
+		nextCursor = filteredEntries[endIdx-1].RegistryMetadata.ID
+	}
+
+	return result, nextCursor, nil
+}
+
+// GetClientByID retrieves a single ClientRecord by its registry metadata ID
+func (db *MemoryDB) GetClientByID(ctx context.Context, id string) (*model.ClientRecord, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if entry, exists := db.clients[id]; exists {
+		entryCopy := *entry
+		return &entryCopy, nil
+	}
+
+	return nil, ErrNotFound
+}
+
+// PublishClient adds a new client version, which must be greater than the latest published version
+func (db *MemoryDB) PublishClient(ctx context.Context, clientDetail model.ClientDetail, publisherExtensions map[string]interface{}) (*model.ClientRecord, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if clientDetail.Name == "" {
+		return nil, fmt.Errorf("%w: name is required in client JSON", ErrInvalidInput)
+	}
+	version := clientDetail.VersionDetail.Version
+	if version == "" {
+		return nil, fmt.Errorf("%w: version is required in version_detail", ErrInvalidInput)
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var existingRecord *model.ClientRecord
+	for _, entry := range db.clients {
+		if entry.RegistryMetadata.IsLatest && entry.ClientJSON.Name == clientDetail.Name {
+			existingRecord = entry
+			break
+		}
+	}
+
+	if existingRecord != nil {
+		existingVersion := existingRecord.ClientJSON.VersionDetail.Version
+		if CompareSemanticVersions(version, existingVersion) <= 0 {
+			return nil, fmt.Errorf("%w: version must be greater than existing version %s", ErrInvalidVersion, existingVersion)
+		}
+	}
+
+	now := time.Now()
+	record := &model.ClientRecord{
+		ClientJSON: clientDetail,
+		RegistryMetadata: model.RegistryMetadata{
+			ID:          uuid.New().String(),
+			PublishedAt: now,
+			UpdatedAt:   now,
+			IsLatest:    true,
+			ReleaseDate: now.Format(time.RFC3339),
+		},
+		PublisherExtensions: publisherExtensions,
+	}
+
+	if existingRecord != nil {
+		existingRecord.RegistryMetadata.IsLatest = false
+	}
+	db.clients[record.RegistryMetadata.ID] = record
+
+	return record, nil
+}
+
+// RecordInstall counts an install of a server version, once per client and day
+func (db *MemoryDB) RecordInstall(ctx context.Context, id string, day time.Time, clientHashes ...string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, exists := db.entries[id]; !exists {
+		return false, ErrNotFound
+	}
+
+	date := installDate(day)
+
+	// Client hashes are only needed to de-duplicate installs within a day, so forget older ones
+	yesterday := installDate(day.AddDate(0, 0, -1))
+	for clientsDate := range db.installClients {
+		if clientsDate < yesterday {
+			delete(db.installClients, clientsDate)
+		}
+	}
+
+	clients, ok := db.installClients[date]
+	if !ok {
+		clients = make(map[string]struct{})
+		db.installClients[date] = clients
+	}
+	// Every hash is remembered, so that a client seen under one of them isn't counted under another
+	seen := false
+	for _, clientHash := range clientHashes {
+		clientKey := id + "/" + clientHash
+		if _, ok := clients[clientKey]; ok {
+			seen = true
+		}
+		clients[clientKey] = struct{}{}
+	}
+	if seen {
+		return false, nil
+	}
+
+	counts, ok := db.installs[id]
+	if !ok {
+		counts = make(map[string]int64)
+		db.installs[id] = counts
+	}
+	counts[date]++
+
+	return true, nil
+}
+
+// InstallStats returns install statistics for the given server versions
+func (db *MemoryDB) InstallStats(ctx context.Context, ids []string, day time.Time) (map[string]*model.InstallStats, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	installTotals := db.installTotalsByName()
+	since7 := installDate(day.AddDate(0, 0, -6))
+	since14 := installDate(day.AddDate(0, 0, -13))
+	since30 := installDate(day.AddDate(0, 0, -29))
+
+	result := make(map[string]*model.InstallStats, len(ids))
+	for _, id := range ids {
+		entry, exists := db.entries[id]
+		if !exists {
+			continue
+		}
+
+		stats := &model.InstallStats{AllVersions: installTotals[entry.ServerJSON.Name]}
+		for date, count := range db.installs[id] {
+			stats.Total += count
+			if date >= since30 {
+				stats.Last30Days += count
+			}
+			switch {
+			case date >= since7:
+				stats.Last7Days += count
+			case date >= since14:
+				stats.Previous7Days += count
+			}
+		}
+		stats.Trend = model.NewInstallTrend(stats.Last7Days, stats.Previous7Days)
+		result[id] = stats
+	}
+
+	return result, nil
+}
+
+// installTotalsByName returns all-time installs per server name, summed across versions.
+// The caller must hold db.mu.
+func (db *MemoryDB) installTotalsByName() map[string]int64 {
+	totals := make(map[string]int64)
+	for id, counts := range db.installs {
+		entry, exists := db.entries[id]
+		if !exists {
+			continue
+		}
+		for _, count := range counts {
+			totals[entry.ServerJSON.Name] += count
+		}
+	}
+	return totals
+}
+
+// installDate returns the UTC calendar day that installs at t are counted against
+func installDate(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+// ConsumeNonce marks an authentication nonce as used until it expires
+func (db *MemoryDB) ConsumeNonce(ctx context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	// Expired nonces are rejected before they get here, so there is no need to remember them
+	now := time.Now()
+	for consumed, expiry := range db.nonces {
+		if expiry.Before(now) {
+			delete(db.nonces, consumed)
+		}
+	}
+
+	if _, used := db.nonces[nonce]; used {
+		return false, nil
+	}
+	db.nonces[nonce] = expiresAt
+	return true, nil
+}
+
+// GitHubOIDCPolicies returns the GitHub OIDC policies of an owner on a GitHub instance
+func (db *MemoryDB) GitHubOIDCPolicies(ctx context.Context, host, owner string) ([]model.GitHubOIDCPolicy, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return append([]model.GitHubOIDCPolicy{}, db.oidcPolicies[oidcPolicyKey(host, owner)]...), nil
+}
+
+// SetGitHubOIDCPolicies replaces the GitHub OIDC policies of an owner on a GitHub instance
+func (db *MemoryDB) SetGitHubOIDCPolicies(ctx context.Context, host, owner string, policies []model.GitHubOIDCPolicy) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if len(policies) == 0 {
+		delete(db.oidcPolicies, oidcPolicyKey(host, owner))
+		return nil
+	}
+	db.oidcPolicies[oidcPolicyKey(host, owner)] = append([]model.GitHubOIDCPolicy{}, policies...)
 	return nil
 }
 
+// oidcPolicyKey identifies an owner on a GitHub instance, ignoring case like GitHub does
+func oidcPolicyKey(host, owner string) string {
+	return strings.ToLower(host) + "/" + strings.ToLower(owner)
+}
+
+// MirroredHash returns the hash of the upstream copy the mirror last applied to a server version
+func (db *MemoryDB) MirroredHash(ctx context.Context, id string) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return db.mirrored[id], nil
+}
+
+// SetMirroredHash records the hash of the upstream copy the mirror applied to a server version
+func (db *MemoryDB) SetMirroredHash(ctx context.Context, id, _, hash string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, exists := db.entries[id]; !exists {
+		return ErrNotFound
+	}
+	db.mirrored[id] = hash
+	return nil
+}
+
+// Stats returns the number of distinct servers and published versions
+func (db *MemoryDB) Stats(ctx context.Context) (*CatalogStats, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	names := make(map[string]struct{})
+	for _, entry := range db.entries {
+		names[entry.ServerJSON.Name] = struct{}{}
+	}
+
+	return &CatalogStats{
+		Servers:  len(names),
+		Versions: len(db.entries),
+	}, nil
+}
+
 // Close closes the database connection
 // For an in-memory database, this is a no-op
 func (db *MemoryDB) Close() error {
@@ -0,0 +1,224 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
+
+func TestMemoryDBRecordInstall(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemoryDB(map[string]*model.ServerDetail{})
+
+	v1, err := db.Publish(ctx, testServer("1.0.0"), nil)
+	require.NoError(t, err)
+	v2, err := db.Publish(ctx, testServer("1.1.0"), nil)
+	require.NoError(t, err)
+
+	today := time.Date(2025, 6, 20, 12, 0, 0, 0, time.UTC)
+
+	// A client is counted once per version and day
+	counted, err := db.RecordInstall(ctx, v2.RegistryMetadata.ID, today, "client-a")
+	require.NoError(t, err)
+	assert.True(t, counted)
+	counted, err = db.RecordInstall(ctx, v2.RegistryMetadata.ID, today.Add(time.Hour), "client-a")
+	require.NoError(t, err)
+	assert.False(t, counted)
+
+	// A client identified by several keys isn't counted if any of them was, and all are remembered
+	counted, err = db.RecordInstall(ctx, v2.RegistryMetadata.ID, today, "client-a", "client-c")
+	require.NoError(t, err)
+	assert.False(t, counted)
+	counted, err = db.RecordInstall(ctx, v2.RegistryMetadata.ID, today, "client-c")
+	require.NoError(t, err)
+	assert.False(t, counted)
+
+	// ...but again on another day
+	_, err = db.RecordInstall(ctx, v2.RegistryMetadata.ID, today.AddDate(0, 0, -10), "client-a")
+	require.NoError(t, err)
+	_, err = db.RecordInstall(ctx, v2.RegistryMetadata.ID, today.AddDate(0, 0, -20), "client-a")
+	require.NoError(t, err)
+	_, err = db.RecordInstall(ctx, v2.RegistryMetadata.ID, today.AddDate(0, 0, -40), "client-a")
+	require.NoError(t, err)
+	_, err = db.RecordInstall(ctx, v1.RegistryMetadata.ID, today, "client-b")
+	require.NoError(t, err)
+
+	_, err = db.RecordInstall(ctx, "00000000-0000-0000-0000-000000000000", today, "client-a")
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	stats, err := db.InstallStats(ctx, []string{v1.RegistryMetadata.ID, v2.RegistryMetadata.ID}, today)
+	require.NoError(t, err)
+	assert.Equal(t, &model.InstallStats{
+		Total:         4,
+		AllVersions:   5,
+		Last7Days:     1,
+		Last30Days:    3,
+		Previous7Days: 1,
+		Trend:         model.InstallTrendFlat,
+	}, stats[v2.RegistryMetadata.ID])
+	assert.Equal(t, int64(1), stats[v1.RegistryMetadata.ID].Total)
+	assert.Equal(t, model.InstallTrendUp, stats[v1.RegistryMetadata.ID].Trend)
+}
+
+func TestMemoryDBConsumeNonce(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemoryDB(map[string]*model.ServerDetail{})
+
+	// A nonce can only be used once while it is valid
+	consumed, err := db.ConsumeNonce(ctx, "nonce-a", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, consumed)
+	consumed, err = db.ConsumeNonce(ctx, "nonce-a", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, consumed)
+
+	// Expired nonces are forgotten
+	_, err = db.ConsumeNonce(ctx, "nonce-b", time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	consumed, err = db.ConsumeNonce(ctx, "nonce-b", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, consumed)
+}
+
+func TestMemoryDBListByInstalls(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemoryDB(map[string]*model.ServerDetail{})
+	today := time.Now()
+
+	ids := make(map[string]string)
+	for name, installs := range map[string]int{"com.example/a": 0, "com.example/b": 3, "com.example/c": 1} {
+		server := testServer("1.0.0")
+		server.Name = name
+		record, err := db.Publish(ctx, server, nil)
+		require.NoError(t, err)
+		ids[name] = record.RegistryMetadata.ID
+
+		for i := range installs {
+			_, err := db.RecordInstall(ctx, record.RegistryMetadata.ID, today, string(rune('a'+i)))
+			require.NoError(t, err)
+		}
+	}
+
+	sorted := map[string]any{database.FilterSort: database.SortInstalls}
+	records, nextCursor, err := db.List(ctx, sorted, "", 2)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "com.example/b", records[0].ServerJSON.Name)
+	assert.Equal(t, "com.example/c", records[1].ServerJSON.Name)
+
+	records, _, err = db.List(ctx, sorted, nextCursor, 2)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "com.example/a", records[0].ServerJSON.Name)
+
+	records, _, err = db.List(ctx, map[string]any{database.FilterMinInstalls: 2}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, ids["com.example/b"], records[0].RegistryMetadata.ID)
+}
+
+func TestMemoryDBListVersion(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemoryDB(map[string]*model.ServerDetail{})
+
+	v1, err := db.Publish(ctx, testServer("1.0.0"), nil)
+	require.NoError(t, err)
+	_, err = db.Publish(ctx, testServer("1.1.0"), nil)
+	require.NoError(t, err)
+
+	// A specific version is listed even when it is not the latest
+	filter := map[string]any{"name": v1.ServerJSON.Name, "version": "1.0.0"}
+	records, _, err := db.List(ctx, filter, "", 10)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, v1.RegistryMetadata.ID, records[0].RegistryMetadata.ID)
+
+	records, _, err = db.List(ctx, map[string]any{"name": v1.ServerJSON.Name}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "1.1.0", records[0].ServerJSON.VersionDetail.Version)
+}
+
+func TestMemoryDBUpdateConflict(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemoryDB(map[string]*model.ServerDetail{})
+
+	v1, err := db.Publish(ctx, testServer("1.0.0"), nil)
+	require.NoError(t, err)
+	_, err = db.Publish(ctx, testServer("1.1.0"), nil)
+	require.NoError(t, err)
+
+	// Updating a version to one that is already published collides with it
+	server := testServer("1.1.0")
+	err = db.Update(ctx, v1.RegistryMetadata.ID, &server)
+	require.ErrorIs(t, err, database.ErrAlreadyExists)
+}
+
+func TestMemoryDBClients(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemoryDB(map[string]*model.ServerDetail{})
+
+	client := model.ClientDetail{
+		Name:          "com.example/agent",
+		VersionDetail: model.VersionDetail{Version: "1.0.0"},
+		Transports:    []model.ClientTransport{model.ClientTransportStdio},
+	}
+	v1, err := db.PublishClient(ctx, client, nil)
+	require.NoError(t, err)
+
+	// Versions must increase, as for servers
+	_, err = db.PublishClient(ctx, client, nil)
+	require.ErrorIs(t, err, database.ErrInvalidVersion)
+
+	client.VersionDetail.Version = "1.1.0"
+	v2, err := db.PublishClient(ctx, client, nil)
+	require.NoError(t, err)
+
+	// Only the latest version is listed, but every version can be fetched
+	records, nextCursor, err := db.ListClients(ctx, nil, "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, nextCursor)
+	require.Len(t, records, 1)
+	assert.Equal(t, v2.RegistryMetadata.ID, records[0].RegistryMetadata.ID)
+
+	record, err := db.GetClientByID(ctx, v1.RegistryMetadata.ID)
+	require.NoError(t, err)
+	assert.False(t, record.RegistryMetadata.IsLatest)
+
+	// Clients and servers are stored separately
+	_, err = db.GetByID(ctx, v1.RegistryMetadata.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestMemoryDBGitHubOIDCPolicies(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemoryDB(map[string]*model.ServerDetail{})
+
+	policies, err := db.GitHubOIDCPolicies(ctx, "github.com", "octo-org")
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+
+	// Owners are matched case-insensitively, like GitHub logins
+	policy := model.GitHubOIDCPolicy{Resource: "io.github.octo-org/server", Repository: "octo-org/octo-repo"}
+	require.NoError(t, db.SetGitHubOIDCPolicies(ctx, "github.com", "Octo-Org", []model.GitHubOIDCPolicy{policy}))
+	policies, err = db.GitHubOIDCPolicies(ctx, "github.com", "octo-org")
+	require.NoError(t, err)
+	assert.Equal(t, []model.GitHubOIDCPolicy{policy}, policies)
+
+	// The same owner name on another GitHub instance is a different owner
+	policies, err = db.GitHubOIDCPolicies(ctx, "github.example.com", "octo-org")
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+
+	// An empty list removes the owner's policies
+	require.NoError(t, db.SetGitHubOIDCPolicies(ctx, "github.com", "octo-org", nil))
+	policies, err = db.GitHubOIDCPolicies(ctx, "github.com", "octo-org")
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+}
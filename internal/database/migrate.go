@@ -5,7 +5,7 @@ import (
 	"embed"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"path/filepath"
 	"sort"
 	"strconv"
@@ -85,13 +85,13 @@ func (m *Migrator) loadMigrations() ([]Migration, error) {
 		name := entry.Name()
 		parts := strings.SplitN(name, "_", 2)
 		if len(parts) != 2 {
-			log.Printf("Skipping migration file with invalid name format: %s", name)
+			slog.Warn("Skipping migration file with invalid name format", slog.String("file", name))
 			continue
 		}
 
 		version, err := strconv.Atoi(parts[0])
 		if err != nil {
-			log.Printf("Skipping migration file with invalid version: %s", name)
+			slog.Warn("Skipping migration file with invalid version", slog.String("file", name))
 			continue
 		}
 
@@ -116,6 +116,42 @@ func (m *Migrator) loadMigrations() ([]Migration, error) {
 	return migrations, nil
 }
 
+// MigrationStatus compares the migrations applied to the database with the embedded ones
+type MigrationStatus struct {
+	// Applied is the highest applied migration version
+	Applied int
+	// Latest is the highest embedded migration version
+	Latest int
+	// Pending lists embedded migration versions that have not been applied
+	Pending []int
+}
+
+// Status reports which embedded migrations have been applied
+func (m *Migrator) Status(ctx context.Context) (*MigrationStatus, error) {
+	applied, err := m.getAppliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := m.loadMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	status := &MigrationStatus{}
+	for version := range applied {
+		status.Applied = max(status.Applied, version)
+	}
+	for _, migration := range migrations {
+		status.Latest = max(status.Latest, migration.Version)
+		if !applied[migration.Version] {
+			status.Pending = append(status.Pending, migration.Version)
+		}
+	}
+
+	return status, nil
+}
+
 // Migrate runs all pending migrations
 func (m *Migrator) Migrate(ctx context.Context) error {
 	// Ensure the migrations table exists
@@ -144,21 +180,21 @@ func (m *Migrator) Migrate(ctx context.Context) error {
 	}
 
 	if len(pending) == 0 {
-		log.Println("No pending migrations")
+		slog.InfoContext(ctx, "No pending migrations")
 		return nil
 	}
 
-	log.Printf("Applying %d pending migrations", len(pending))
+	slog.InfoContext(ctx, "Applying pending migrations", slog.Int("count", len(pending)))
 
 	// Apply each pending migration in a transaction
 	for _, migration := range pending {
 		if err := m.applyMigration(ctx, migration); err != nil {
 			return fmt.Errorf("failed to apply migration %d (%s): %w", migration.Version, migration.Name, err)
 		}
-		log.Printf("Applied migration %d: %s", migration.Version, migration.Name)
+		slog.InfoContext(ctx, "Applied migration", slog.Int("version", migration.Version), slog.String("name", migration.Name))
 	}
 
-	log.Println("All migrations applied successfully")
+	slog.InfoContext(ctx, "All migrations applied successfully")
 	return nil
 }
 
@@ -170,7 +206,7 @@ func (m *Migrator) applyMigration(ctx context.Context, migration Migration) erro
 	}
 	defer func() {
 		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
-			log.Printf("Failed to rollback migration transaction: %v", err)
+			slog.ErrorContext(ctx, "Failed to rollback migration transaction", slog.Any("error", err))
 		}
 	}()
 
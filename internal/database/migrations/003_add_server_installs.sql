@@ -0,0 +1,22 @@
+-- Add install tracking, aggregated per server version and day
+
+-- Install counts per server version (server_extensions.id is the registry metadata ID) and day
+CREATE TABLE server_installs (
+    server_id UUID NOT NULL REFERENCES server_extensions(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (server_id, day)
+);
+
+CREATE INDEX idx_server_installs_day ON server_installs(day);
+
+-- Hashed client identifiers, used only to count each client once per version and day.
+-- Rows older than a day are pruned when installs are recorded.
+CREATE TABLE server_install_clients (
+    server_id UUID NOT NULL REFERENCES server_extensions(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    client_hash VARCHAR(64) NOT NULL,
+    PRIMARY KEY (server_id, day, client_hash)
+);
+
+CREATE INDEX idx_server_install_clients_day ON server_install_clients(day);
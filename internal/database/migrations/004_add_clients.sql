@@ -0,0 +1,33 @@
+-- Add MCP clients, stored alongside servers
+
+-- Clients table - one row per published client version, with its registry metadata
+CREATE TABLE clients (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    website_url TEXT,
+    repository JSONB,
+    version VARCHAR(255) NOT NULL,
+
+    -- Supported MCP features and transports stored as JSONB arrays of strings
+    features JSONB NOT NULL DEFAULT '[]'::jsonb,
+    transports JSONB NOT NULL DEFAULT '[]'::jsonb,
+
+    -- Registry metadata
+    published_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    is_latest BOOLEAN NOT NULL DEFAULT true,
+    release_date TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- Publisher extensions as flexible JSONB
+    publisher_extensions JSONB DEFAULT '{}'::jsonb,
+
+    UNIQUE(name, version)
+);
+
+CREATE INDEX idx_clients_name ON clients(name);
+CREATE INDEX idx_clients_latest ON clients(is_latest) WHERE is_latest = true;
+
+-- GIN indexes to find clients by supported feature or transport
+CREATE INDEX idx_clients_features_gin ON clients USING GIN(features);
+CREATE INDEX idx_clients_transports_gin ON clients USING GIN(transports);
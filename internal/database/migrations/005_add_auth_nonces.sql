@@ -0,0 +1,10 @@
+-- Add the replay cache for challenge-response authentication
+
+-- Nonces that have been used to authenticate, kept until they expire so that every replica
+-- rejects a signed challenge that is sent again
+CREATE TABLE auth_nonces (
+    nonce VARCHAR(255) PRIMARY KEY,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_auth_nonces_expires_at ON auth_nonces(expires_at);
@@ -0,0 +1,9 @@
+-- Add GitHub OIDC policies, which restrict the workflows that can publish an owner's servers
+
+-- One row per GitHub owner, keyed by the lower-cased owner name, with the owner's policies as
+-- a JSONB array
+CREATE TABLE github_oidc_policies (
+    owner VARCHAR(255) PRIMARY KEY,
+    policies JSONB NOT NULL DEFAULT '[]'::jsonb,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
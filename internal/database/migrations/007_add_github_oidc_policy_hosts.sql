@@ -0,0 +1,6 @@
+-- Scope GitHub OIDC policies to a GitHub instance, since the same owner name can exist on
+-- github.com and on GitHub Enterprise Server instances
+
+ALTER TABLE github_oidc_policies ADD COLUMN host VARCHAR(255) NOT NULL DEFAULT 'github.com';
+ALTER TABLE github_oidc_policies DROP CONSTRAINT github_oidc_policies_pkey;
+ALTER TABLE github_oidc_policies ADD PRIMARY KEY (host, owner);
@@ -0,0 +1,11 @@
+-- Track the server versions written by the mirror worker, so it can tell upstream changes to
+-- them apart from local edits
+
+-- One row per mirrored server version, keyed by its registry metadata ID, with a hash of the
+-- upstream server.json last applied to it
+CREATE TABLE mirror_origins (
+    server_id UUID PRIMARY KEY REFERENCES server_extensions(id) ON DELETE CASCADE,
+    upstream TEXT NOT NULL,
+    hash VARCHAR(64) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
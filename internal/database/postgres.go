@@ -5,39 +5,61 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
 // PostgreSQL is an implementation of the Database interface using PostgreSQL
 type PostgreSQL struct {
-	conn *pgx.Conn
+	pool *pgxpool.Pool
 }
 
 // NewPostgreSQL creates a new instance of the PostgreSQL database
 func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, error) {
-	conn, err := pgx.Connect(ctx, connectionURI)
+	poolConfig, err := pgxpool.ParseConfig(connectionURI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PostgreSQL connection URI: %w", err)
+	}
+
+	// Trace every query issued on pooled connections
+	poolConfig.ConnConfig.Tracer = queryTracer{}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
 	}
 
 	// Test the connection
-	if err = conn.Ping(ctx); err != nil {
+	if err = pool.Ping(ctx); err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("failed to ping PostgreSQL: %w", err)
 	}
 
-	// Run migrations
-	migrator := NewMigrator(conn)
+	// Run migrations on a dedicated connection
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to acquire connection for migrations: %w", err)
+	}
+	defer conn.Release()
+
+	migrator := NewMigrator(conn.Conn())
 	if err := migrator.Migrate(ctx); err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("failed to run database migrations: %w", err)
 	}
 
 	return &PostgreSQL{
-		conn: conn,
+		pool: pool,
 	}, nil
 }
 
@@ -56,8 +78,12 @@ func (db *PostgreSQL) List(
 		return nil, "", ctx.Err()
 	}
 
-	// Build WHERE clause for server_extensions filtering
+	// Build WHERE clause for server_extensions filtering, only including the latest
+	// versions unless a specific version was requested
 	whereClause := "WHERE se.is_latest = true"
+	if _, ok := filter["version"]; ok {
+		whereClause = "WHERE true"
+	}
 	args := []any{}
 	argIndex := 1
 
@@ -76,33 +102,64 @@ func (db *PostgreSQL) List(
 			whereClause += fmt.Sprintf(" AND s.status = $%d", argIndex)
 			args = append(args, v)
 			argIndex++
+		case FilterMinInstalls:
+			whereClause += fmt.Sprintf(" AND COALESCE(it.total, 0) >= $%d", argIndex)
+			args = append(args, v)
+			argIndex++
 		}
 	}
 
+	orderBy := "se.id"
+	sortByInstalls := filter[FilterSort] == SortInstalls
+	if sortByInstalls {
+		orderBy = "COALESCE(it.total, 0) DESC, se.id"
+	}
+
 	// Add cursor pagination using registry metadata ID
 	if cursor != "" {
 		if _, err := uuid.Parse(cursor); err != nil {
 			return nil, "", fmt.Errorf("invalid cursor format: %w", err)
 		}
-		whereClause += fmt.Sprintf(" AND se.id > $%d", argIndex)
+		if sortByInstalls {
+			// Continue after the cursor's position in the install ordering
+			cursorInstalls := fmt.Sprintf(`(
+				SELECT COALESCE(ct.total, 0)
+				FROM server_extensions cse
+				JOIN servers cs ON cs.id = cse.server_id
+				LEFT JOIN install_totals ct ON ct.name = cs.name
+				WHERE cse.id = $%d
+			)`, argIndex)
+			whereClause += fmt.Sprintf(" AND (COALESCE(it.total, 0) < %s OR (COALESCE(it.total, 0) = %s AND se.id > $%d))",
+				cursorInstalls, cursorInstalls, argIndex)
+		} else {
+			whereClause += fmt.Sprintf(" AND se.id > $%d", argIndex)
+		}
 		args = append(args, cursor)
 		argIndex++
 	}
 
-	// Build JOIN query between servers and server_extensions
+	// Build JOIN query between servers and server_extensions, with installs summed across versions
 	query := fmt.Sprintf(`
+		WITH install_totals AS (
+			SELECT s.name, SUM(si.count)::bigint AS total
+			FROM server_installs si
+			JOIN server_extensions se ON se.id = si.server_id
+			JOIN servers s ON s.id = se.server_id
+			GROUP BY s.name
+		)
 		SELECT
 			s.name, s.description, s.status, s.repository, s.version, s.packages, s.remotes,
 			se.id, se.published_at, se.updated_at, se.is_latest, se.release_date, se.publisher_extensions
 		FROM servers s
 		JOIN server_extensions se ON s.id = se.server_id
+		LEFT JOIN install_totals it ON it.name = s.name
 		%s
-		ORDER BY se.id
+		ORDER BY %s
 		LIMIT $%d
-	`, whereClause, argIndex)
+	`, whereClause, orderBy, argIndex)
 	args = append(args, limit)
 
-	rows, err := db.conn.Query(ctx, query, args...)
+	rows, err := db.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to query servers with extensions: %w", err)
 	}
@@ -211,7 +268,7 @@ func (db *PostgreSQL) GetByID(ctx context.Context, id string) (*model.ServerReco
 	var repositoryJSON, packagesJSON, remotesJSON, publisherExtensionsJSON []byte
 	var publishedAt, updatedAt, releaseDate time.Time
 
-	err := db.conn.QueryRow(ctx, query, id).Scan(
+	err := db.pool.QueryRow(ctx, query, id).Scan(
 		// Server fields
 		&record.ServerJSON.Name,
 		&record.ServerJSON.Description,
@@ -272,19 +329,29 @@ func (db *PostgreSQL) GetByID(ctx context.Context, id string) (*model.ServerReco
 	return &record, nil
 }
 
+// uniqueViolation is the PostgreSQL error code for a unique constraint violation
+const uniqueViolation = "23505"
+
+// isUniqueViolation reports whether err is a unique constraint violation, such as from two
+// publishes of the same version racing each other
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
+
 // Publish adds a new server to the database with separated server.json and extensions
 func (db *PostgreSQL) Publish(ctx context.Context, serverDetail model.ServerDetail, publisherExtensions map[string]interface{}) (*model.ServerRecord, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
-	tx, err := db.conn.Begin(ctx)
+	tx, err := db.pool.Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() {
 		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
-			log.Printf("Failed to rollback transaction: %v", err)
+			slog.ErrorContext(ctx, "Failed to rollback transaction", slog.Any("error", err))
 		}
 	}()
 
@@ -302,8 +369,8 @@ func (db *PostgreSQL) Publish(ctx context.Context, serverDetail model.ServerDeta
 	}
 
 	// Validate version ordering
-	if existingVersion != "" && serverDetail.VersionDetail.Version <= existingVersion {
-		return nil, ErrInvalidVersion
+	if existingVersion != "" && CompareSemanticVersions(serverDetail.VersionDetail.Version, existingVersion) <= 0 {
+		return nil, fmt.Errorf("%w: version must be greater than existing version %s", ErrInvalidVersion, existingVersion)
 	}
 
 	// Prepare JSON data for server table
@@ -364,6 +431,9 @@ func (db *PostgreSQL) Publish(ctx context.Context, serverDetail model.ServerDeta
 		remotesJSON,
 	)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: server %s version %s", ErrAlreadyExists, serverDetail.Name, serverDetail.VersionDetail.Version)
+		}
 		return nil, fmt.Errorf("failed to insert server: %w", err)
 	}
 
@@ -407,21 +477,21 @@ func (db *PostgreSQL) Publish(ctx context.Context, serverDetail model.ServerDeta
 }
 
 // ImportSeed imports initial data from a seed file into PostgreSQL
-func (db *PostgreSQL) ImportSeed(ctx context.Context, seedFilePath string) error {
+func (db *PostgreSQL) ImportSeed(ctx context.Context, client *http.Client, seedFilePath string) error {
 	// Read seed data using the shared ReadSeedFile function
-	seedData, err := ReadSeedFile(ctx, seedFilePath)
+	seedData, err := ReadSeedFile(ctx, client, seedFilePath)
 	if err != nil {
 		return fmt.Errorf("failed to read seed file: %w", err)
 	}
 
 	// Start a transaction for batch import
-	tx, err := db.conn.Begin(ctx)
+	tx, err := db.pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to start transaction: %w", err)
 	}
 	defer func() {
 		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil && !errors.Is(rollbackErr, pgx.ErrTxClosed) {
-			log.Printf("Failed to rollback transaction: %v", rollbackErr)
+			slog.ErrorContext(ctx, "Failed to rollback transaction", slog.Any("error", rollbackErr))
 		}
 	}()
 
@@ -548,7 +618,7 @@ func (db *PostgreSQL) publishWithTransaction(ctx context.Context, tx pgx.Tx, ser
 // Update updates an existing ServerDetail in the database
 func (db *PostgreSQL) Update(ctx context.Context, id string, serverDetail *model.ServerDetail) error {
 	// Start transaction
-	tx, err := db.conn.Begin(ctx)
+	tx, err := db.pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to start transaction: %w", err)
 	}
@@ -577,8 +647,8 @@ func (db *PostgreSQL) Update(ctx context.Context, id string, serverDetail *model
 
 	// Validate version if provided
 	if serverDetail.VersionDetail.Version != "" {
-		if compareSemanticVersions(serverDetail.VersionDetail.Version, existingVersion) < 0 {
-			return fmt.Errorf("invalid version: cannot update to an older version")
+		if CompareSemanticVersions(serverDetail.VersionDetail.Version, existingVersion) < 0 {
+			return fmt.Errorf("%w: cannot update to an older version", ErrInvalidVersion)
 		}
 	}
 
@@ -618,6 +688,9 @@ func (db *PostgreSQL) Update(ctx context.Context, id string, serverDetail *model
 		now,
 	)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: server %s version %s", ErrAlreadyExists, serverDetail.Name, serverDetail.VersionDetail.Version)
+		}
 		return fmt.Errorf("failed to update server: %w", err)
 	}
 
@@ -638,7 +711,7 @@ func (db *PostgreSQL) Update(ctx context.Context, id string, serverDetail *model
 // Delete removes a ServerDetail from the database by ID
 func (db *PostgreSQL) Delete(ctx context.Context, id string) error {
 	// Start transaction
-	tx, err := db.conn.Begin(ctx)
+	tx, err := db.pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to start transaction: %w", err)
 	}
@@ -686,24 +759,518 @@ func (db *PostgreSQL) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// Close closes the database connection
+// clientColumns are the columns read by scanClientRecord
+const clientColumns = `
+	id, name, description, website_url, repository, version, features, transports,
+	published_at, updated_at, is_latest, release_date, publisher_extensions`
+
+// scanClientRecord scans a row of clientColumns into a ClientRecord
+func scanClientRecord(row pgx.Row) (*model.ClientRecord, error) {
+	var record model.ClientRecord
+	var websiteURL *string
+	var repositoryJSON, featuresJSON, transportsJSON, publisherExtensionsJSON []byte
+	var publishedAt, updatedAt, releaseDate time.Time
+
+	err := row.Scan(
+		&record.RegistryMetadata.ID,
+		&record.ClientJSON.Name,
+		&record.ClientJSON.Description,
+		&websiteURL,
+		&repositoryJSON,
+		&record.ClientJSON.VersionDetail.Version,
+		&featuresJSON,
+		&transportsJSON,
+		&publishedAt,
+		&updatedAt,
+		&record.RegistryMetadata.IsLatest,
+		&releaseDate,
+		&publisherExtensionsJSON,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if websiteURL != nil {
+		record.ClientJSON.WebsiteURL = *websiteURL
+	}
+	if len(repositoryJSON) > 0 {
+		if err := json.Unmarshal(repositoryJSON, &record.ClientJSON.Repository); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal repository: %w", err)
+		}
+	}
+	if err := json.Unmarshal(featuresJSON, &record.ClientJSON.Features); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal features: %w", err)
+	}
+	if err := json.Unmarshal(transportsJSON, &record.ClientJSON.Transports); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transports: %w", err)
+	}
+	record.PublisherExtensions = make(map[string]interface{})
+	if len(publisherExtensionsJSON) > 0 {
+		if err := json.Unmarshal(publisherExtensionsJSON, &record.PublisherExtensions); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal publisher extensions: %w", err)
+		}
+	}
+
+	record.RegistryMetadata.PublishedAt = publishedAt
+	record.RegistryMetadata.UpdatedAt = updatedAt
+	record.RegistryMetadata.ReleaseDate = releaseDate.Format(time.RFC3339)
+
+	return &record, nil
+}
+
+// ListClients retrieves the latest version of every ClientRecord with optional filtering and pagination
+func (db *PostgreSQL) ListClients(ctx context.Context, filter map[string]any, cursor string, limit int) ([]*model.ClientRecord, string, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+
+	whereClause := "WHERE is_latest = true"
+	args := []any{}
+	argIndex := 1
+
+	if name, ok := filter["name"]; ok {
+		whereClause += fmt.Sprintf(" AND name = $%d", argIndex)
+		args = append(args, name)
+		argIndex++
+	}
+
+	if cursor != "" {
+		if _, err := uuid.Parse(cursor); err != nil {
+			return nil, "", fmt.Errorf("invalid cursor format: %w", err)
+		}
+		whereClause += fmt.Sprintf(" AND id > $%d", argIndex)
+		args = append(args, cursor)
+		argIndex++
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM clients %s ORDER BY id LIMIT $%d`, clientColumns, whereClause, argIndex)
+	args = append(args, limit)
+
+	rows, err := db.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query clients: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*model.ClientRecord
+	for rows.Next() {
+		record, err := scanClientRecord(rows)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to scan client record row: %w", err)
+		}
+		results = append(results, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	nextCursor := ""
+	if len(results) > 0 && len(results) >= limit {
+		nextCursor = results[len(results)-1].RegistryMetadata.ID
+	}
+
+	return results, nextCursor, nil
+}
+
+// GetClientByID retrieves a single ClientRecord by its registry metadata ID
+func (db *PostgreSQL) GetClientByID(ctx context.Context, id string) (*model.ClientRecord, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM clients WHERE id = $1`, clientColumns)
+	record, err := scanClientRecord(db.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get client record by ID: %w", err)
+	}
+
+	return record, nil
+}
+
+// PublishClient adds a new client version, which must be greater than the latest published version
+func (db *PostgreSQL) PublishClient(ctx context.Context, clientDetail model.ClientDetail, publisherExtensions map[string]interface{}) (*model.ClientRecord, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.ErrorContext(ctx, "Failed to rollback transaction", slog.Any("error", err))
+		}
+	}()
+
+	// Check if there's an existing latest version for this client
+	var existingVersion string
+	err = tx.QueryRow(ctx, `SELECT version FROM clients WHERE name = $1 AND is_latest = true`, clientDetail.Name).Scan(&existingVersion)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check existing version: %w", err)
+	}
+
+	// Validate version ordering
+	if existingVersion != "" && CompareSemanticVersions(clientDetail.VersionDetail.Version, existingVersion) <= 0 {
+		return nil, fmt.Errorf("%w: version must be greater than existing version %s", ErrInvalidVersion, existingVersion)
+	}
+
+	repositoryJSON, err := json.Marshal(clientDetail.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal repository: %w", err)
+	}
+
+	featuresJSON, err := json.Marshal(clientDetail.Features)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal features: %w", err)
+	}
+
+	transportsJSON, err := json.Marshal(clientDetail.Transports)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal transports: %w", err)
+	}
+
+	publisherExtensionsJSON, err := json.Marshal(publisherExtensions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal publisher extensions: %w", err)
+	}
+
+	// Update existing latest version to not be latest
+	if existingVersion != "" {
+		_, err = tx.Exec(ctx, `UPDATE clients SET is_latest = false WHERE name = $1 AND is_latest = true`, clientDetail.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update existing latest version: %w", err)
+		}
+	}
+
+	var websiteURL *string
+	if clientDetail.WebsiteURL != "" {
+		websiteURL = &clientDetail.WebsiteURL
+	}
+
+	now := time.Now()
+	insertQuery := fmt.Sprintf(`
+		INSERT INTO clients (id, name, description, website_url, repository, version, features, transports,
+			published_at, updated_at, is_latest, release_date, publisher_extensions)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9, true, $9, $10)
+		RETURNING %s
+	`, clientColumns)
+	record, err := scanClientRecord(tx.QueryRow(ctx, insertQuery,
+		uuid.New().String(),
+		clientDetail.Name,
+		clientDetail.Description,
+		websiteURL,
+		repositoryJSON,
+		clientDetail.VersionDetail.Version,
+		featuresJSON,
+		transportsJSON,
+		now,
+		publisherExtensionsJSON,
+	))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: client %s version %s", ErrAlreadyExists, clientDetail.Name, clientDetail.VersionDetail.Version)
+		}
+		return nil, fmt.Errorf("failed to insert client: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return record, nil
+}
+
+// RecordInstall counts an install of a server version, once per client and day
+func (db *PostgreSQL) RecordInstall(ctx context.Context, id string, day time.Time, clientHashes ...string) (_ bool, err error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			_ = tx.Commit(ctx)
+		}
+	}()
+
+	var exists bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM server_extensions WHERE id = $1)`, id).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check server: %w", err)
+	}
+	if !exists {
+		return false, ErrNotFound
+	}
+
+	date := day.UTC().Format(time.DateOnly)
+
+	// Client hashes are only needed to de-duplicate installs within a day, so forget older ones
+	_, err = tx.Exec(ctx, `DELETE FROM server_install_clients WHERE day < $1::date - 1`, date)
+	if err != nil {
+		return false, fmt.Errorf("failed to prune install clients: %w", err)
+	}
+
+	// Every hash is remembered, so that a client seen under one of them isn't counted under another
+	seen := false
+	for _, clientHash := range clientHashes {
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO server_install_clients (server_id, day, client_hash)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING`, id, date, clientHash)
+		if err != nil {
+			return false, fmt.Errorf("failed to record install client: %w", err)
+		}
+		seen = seen || tag.RowsAffected() == 0
+	}
+	if seen {
+		// This client was already counted today
+		return false, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO server_installs (server_id, day, count)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (server_id, day)
+		DO UPDATE SET count = server_installs.count + 1`, id, date)
+	if err != nil {
+		return false, fmt.Errorf("failed to record install: %w", err)
+	}
+
+	return true, nil
+}
+
+// InstallStats returns install statistics for the given server versions
+func (db *PostgreSQL) InstallStats(ctx context.Context, ids []string, day time.Time) (map[string]*model.InstallStats, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	result := make(map[string]*model.InstallStats, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT
+			se.id,
+			COALESCE(SUM(si.count), 0)::bigint,
+			COALESCE(SUM(si.count) FILTER (WHERE si.day > $2::date - 7), 0)::bigint,
+			COALESCE(SUM(si.count) FILTER (WHERE si.day > $2::date - 30), 0)::bigint,
+			COALESCE(SUM(si.count) FILTER (WHERE si.day > $2::date - 14 AND si.day <= $2::date - 7), 0)::bigint,
+			(
+				SELECT COALESCE(SUM(ai.count), 0)::bigint
+				FROM server_installs ai
+				JOIN server_extensions ase ON ase.id = ai.server_id
+				JOIN servers a ON a.id = ase.server_id
+				WHERE a.name = s.name
+			)
+		FROM server_extensions se
+		JOIN servers s ON s.id = se.server_id
+		LEFT JOIN server_installs si ON si.server_id = se.id
+		WHERE se.id = ANY($1::uuid[])
+		GROUP BY se.id, s.name
+	`
+
+	rows, err := db.pool.Query(ctx, query, ids, day.UTC().Format(time.DateOnly))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query install stats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var stats model.InstallStats
+		if err := rows.Scan(&id, &stats.Total, &stats.Last7Days, &stats.Last30Days, &stats.Previous7Days, &stats.AllVersions); err != nil {
+			return nil, fmt.Errorf("failed to scan install stats row: %w", err)
+		}
+		stats.Trend = model.NewInstallTrend(stats.Last7Days, stats.Previous7Days)
+		result[id] = &stats
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// ConsumeNonce marks an authentication nonce as used until it expires
+func (db *PostgreSQL) ConsumeNonce(ctx context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	// Expired nonces are rejected before they get here, so there is no need to remember them
+	_, err := db.pool.Exec(ctx, `DELETE FROM auth_nonces WHERE expires_at < NOW()`)
+	if err != nil {
+		return false, fmt.Errorf("failed to prune nonces: %w", err)
+	}
+
+	tag, err := db.pool.Exec(ctx, `
+		INSERT INTO auth_nonces (nonce, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`, nonce, expiresAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to record nonce: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// GitHubOIDCPolicies returns the GitHub OIDC policies of an owner on a GitHub instance
+func (db *PostgreSQL) GitHubOIDCPolicies(ctx context.Context, host, owner string) ([]model.GitHubOIDCPolicy, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var policiesJSON []byte
+	err := db.pool.QueryRow(ctx, `SELECT policies FROM github_oidc_policies WHERE host = $1 AND owner = $2`,
+		strings.ToLower(host), strings.ToLower(owner)).Scan(&policiesJSON)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return []model.GitHubOIDCPolicy{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub OIDC policies: %w", err)
+	}
+
+	var policies []model.GitHubOIDCPolicy
+	if err := json.Unmarshal(policiesJSON, &policies); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal GitHub OIDC policies: %w", err)
+	}
+	return policies, nil
+}
+
+// SetGitHubOIDCPolicies replaces the GitHub OIDC policies of an owner on a GitHub instance
+func (db *PostgreSQL) SetGitHubOIDCPolicies(ctx context.Context, host, owner string, policies []model.GitHubOIDCPolicy) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if len(policies) == 0 {
+		if _, err := db.pool.Exec(ctx, `DELETE FROM github_oidc_policies WHERE host = $1 AND owner = $2`,
+			strings.ToLower(host), strings.ToLower(owner)); err != nil {
+			return fmt.Errorf("failed to delete GitHub OIDC policies: %w", err)
+		}
+		return nil
+	}
+
+	policiesJSON, err := json.Marshal(policies)
+	if err != nil {
+		return fmt.Errorf("failed to marshal GitHub OIDC policies: %w", err)
+	}
+	_, err = db.pool.Exec(ctx, `
+		INSERT INTO github_oidc_policies (host, owner, policies, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (host, owner) DO UPDATE SET policies = EXCLUDED.policies, updated_at = EXCLUDED.updated_at`,
+		strings.ToLower(host), strings.ToLower(owner), policiesJSON)
+	if err != nil {
+		return fmt.Errorf("failed to set GitHub OIDC policies: %w", err)
+	}
+	return nil
+}
+
+// MirroredHash returns the hash of the upstream copy the mirror last applied to a server version
+func (db *PostgreSQL) MirroredHash(ctx context.Context, id string) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	var hash string
+	err := db.pool.QueryRow(ctx, `SELECT hash FROM mirror_origins WHERE server_id = $1`, id).Scan(&hash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get mirrored hash: %w", err)
+	}
+	return hash, nil
+}
+
+// SetMirroredHash records the hash of the upstream copy the mirror applied to a server version
+func (db *PostgreSQL) SetMirroredHash(ctx context.Context, id, upstream, hash string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	_, err := db.pool.Exec(ctx, `
+		INSERT INTO mirror_origins (server_id, upstream, hash, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (server_id) DO UPDATE SET upstream = EXCLUDED.upstream, hash = EXCLUDED.hash, updated_at = EXCLUDED.updated_at`,
+		id, upstream, hash)
+	if err != nil {
+		return fmt.Errorf("failed to set mirrored hash: %w", err)
+	}
+	return nil
+}
+
+// Stats returns the number of distinct servers and published versions
+func (db *PostgreSQL) Stats(ctx context.Context) (*CatalogStats, error) {
+	var stats CatalogStats
+	query := `
+		SELECT COUNT(DISTINCT s.name), COUNT(*)
+		FROM servers s
+		JOIN server_extensions se ON s.id = se.server_id
+	`
+	if err := db.pool.QueryRow(ctx, query).Scan(&stats.Servers, &stats.Versions); err != nil {
+		return nil, fmt.Errorf("failed to count servers: %w", err)
+	}
+	return &stats, nil
+}
+
+// PoolStats returns a snapshot of connection pool usage
+func (db *PostgreSQL) PoolStats() telemetry.DBPoolStats {
+	stat := db.pool.Stat()
+	return telemetry.DBPoolStats{
+		Acquired: int64(stat.AcquiredConns()),
+		Idle:     int64(stat.IdleConns()),
+		Max:      int64(stat.MaxConns()),
+		Waits:    stat.EmptyAcquireCount(),
+	}
+}
+
+// Close closes all connections in the pool
 func (db *PostgreSQL) Close() error {
-	return db.conn.Close(context.Background())
+	db.pool.Close()
+	return nil
 }
 
 // Connection returns information about the database connection
 func (db *PostgreSQL) Connection() *ConnectionInfo {
 	isConnected := false
-	if db.conn != nil {
+	if db.pool != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 		defer cancel()
-		err := db.conn.Ping(ctx)
+		err := db.pool.Ping(ctx)
 		isConnected = (err == nil)
 	}
 
 	return &ConnectionInfo{
 		Type:        ConnectionTypePostgreSQL,
 		IsConnected: isConnected,
-		Raw:         db.conn,
+		Raw:         db.pool,
 	}
-}
\ No newline at end of file
+}
+
+// MigrationStatus reports whether all embedded migrations have been applied
+func (db *PostgreSQL) MigrationStatus(ctx context.Context) (*MigrationStatus, error) {
+	conn, err := db.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	return NewMigrator(conn.Conn()).Status(ctx)
+}
@@ -0,0 +1,18 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	violation := &pgconn.PgError{Code: uniqueViolation, ConstraintName: "idx_servers_name_version"}
+	assert.True(t, isUniqueViolation(violation))
+	assert.True(t, isUniqueViolation(fmt.Errorf("failed to insert server: %w", violation)))
+	assert.False(t, isUniqueViolation(&pgconn.PgError{Code: "23503"}))
+	assert.False(t, isUniqueViolation(errors.New("connection reset")))
+}
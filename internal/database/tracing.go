@@ -0,0 +1,40 @@
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+// queryTracer implements pgx.QueryTracer, creating a span for every PostgreSQL query
+type queryTracer struct{}
+
+// TraceQueryStart starts a span for the query
+func (queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	operation := queryOperation(data.SQL)
+	ctx, _ = telemetry.StartSpan(ctx, "postgresql "+operation,
+		semconv.DBSystemPostgreSQL,
+		semconv.DBOperation(operation),
+		semconv.DBStatement(strings.TrimSpace(data.SQL)),
+	)
+	return ctx
+}
+
+// TraceQueryEnd ends the span started in TraceQueryStart
+func (queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	telemetry.EndSpan(trace.SpanFromContext(ctx), data.Err)
+}
+
+// queryOperation returns the leading SQL keyword of a query (SELECT, INSERT, ...)
+func queryOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,108 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"sync"
+	"syscall"
+)
+
+// ErrBlockedAddress is returned when a request would connect to a loopback, private,
+// link-local or otherwise internal address
+var ErrBlockedAddress = errors.New("destination address is not allowed")
+
+// blockedPrefixes are internal ranges not covered by the netip.Addr predicates
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT, also used by some cloud metadata services
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, which can reach internal IPv4 addresses
+}
+
+// IsBlocked reports whether connections to addr are refused unless private networks are allowed
+func IsBlocked(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return true
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkHost refuses hosts that are literal blocked IP addresses. Hostnames are checked when
+// they are resolved and dialed, or by checkResolved when a proxy connects to them.
+func checkHost(host string) error {
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return nil
+	}
+	if IsBlocked(addr) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, addr)
+	}
+	return nil
+}
+
+// lookupNetIP resolves hostnames for checkResolved, and is replaced in tests
+var lookupNetIP = net.DefaultResolver.LookupNetIP
+
+// checkResolved refuses hosts that resolve to any blocked address. It is used for requests
+// sent through a proxy, which connects to the host itself.
+func checkResolved(ctx context.Context, host string) error {
+	if _, err := netip.ParseAddr(host); err == nil {
+		return nil
+	}
+	addrs, err := lookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("resolving %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if IsBlocked(addr) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrBlockedAddress, host, addr)
+		}
+	}
+	return nil
+}
+
+// dialer connects to destinations, refusing blocked addresses after DNS resolution so a
+// hostname can't be pointed at an internal address between a check and the connection
+type dialer struct {
+	net.Dialer
+	// proxies holds the host:port of proxies in use, which may themselves be internal
+	proxies sync.Map
+}
+
+func newDialer(blockPrivate bool) *dialer {
+	d := &dialer{Dialer: net.Dialer{Timeout: defaultDialTimeout, KeepAlive: defaultDialTimeout}}
+	if blockPrivate {
+		d.Control = func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrBlockedAddress, address)
+			}
+			if IsBlocked(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrBlockedAddress, addrPort.Addr())
+			}
+			return nil
+		}
+	}
+	return d
+}
+
+// DialContext connects to address, skipping the address check for configured proxies
+func (d *dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if _, ok := d.proxies.Load(address); ok {
+		proxyDialer := d.Dialer
+		proxyDialer.Control = nil
+		return proxyDialer.DialContext(ctx, network, address)
+	}
+	return d.Dialer.DialContext(ctx, network, address)
+}
@@ -0,0 +1,15 @@
+package httpclient
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+)
+
+// SetLookup replaces hostname resolution for the rest of the test
+func SetLookup(t *testing.T, lookup func(ctx context.Context, network, host string) ([]netip.Addr, error)) {
+	t.Helper()
+	previous := lookupNetIP
+	lookupNetIP = lookup
+	t.Cleanup(func() { lookupNetIP = previous })
+}
@@ -0,0 +1,313 @@
+// Package httpclient builds the HTTP clients the registry uses for outbound requests, such as
+// fetching HTTP authentication keys, calling the GitHub API and importing seed data.
+//
+// Every client has a timeout, caps the size of response bodies, retries idempotent requests
+// that fail transiently, and records per-destination metrics. Clients for destinations that
+// publishers choose also refuse to connect to loopback, private and link-local addresses, so
+// a publisher can't make the registry reach internal services or cloud metadata endpoints.
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/net/http/httpproxy"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+const (
+	defaultTimeout          = 10 * time.Second
+	defaultDialTimeout      = 5 * time.Second
+	defaultMaxResponseBytes = 1 << 20
+	retryBaseDelay          = 200 * time.Millisecond
+	retryMaxDelay           = 2 * time.Second
+)
+
+// ErrResponseTooLarge is returned when reading a response body larger than the client allows
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// Options configures an outbound HTTP client
+type Options struct {
+	// Destination names what the client talks to, such as "github" or "http_auth_key". It is
+	// the destination attribute of the outbound request metrics.
+	Destination string
+	// Timeout bounds each request, including reading the response body
+	Timeout time.Duration
+	// MaxResponseBytes caps the size of response bodies
+	MaxResponseBytes int64
+	// Retries is how many times to retry idempotent requests after a network error or a
+	// 429 or 5xx response
+	Retries int
+	// Proxy is the URL of the proxy for all requests. When empty, the HTTPS_PROXY, HTTP_PROXY
+	// and NO_PROXY environment variables apply.
+	Proxy string
+	// AllowPrivateNetworks allows connections to loopback, private and link-local addresses.
+	// Only set it for destinations the operator configures, never for ones publishers choose.
+	AllowPrivateNetworks bool
+	// FollowRedirects follows redirects, which are checked like any other destination
+	FollowRedirects bool
+}
+
+// FromConfig returns the options configured for outbound requests to destination. Private
+// networks are allowed only if the operator has allowed them for all outbound requests.
+func FromConfig(cfg *config.Config, destination string) Options {
+	return Options{
+		Destination:          destination,
+		Timeout:              cfg.OutboundTimeout,
+		MaxResponseBytes:     cfg.OutboundMaxResponseBytes,
+		Retries:              cfg.OutboundRetries,
+		Proxy:                cfg.OutboundProxy,
+		AllowPrivateNetworks: cfg.OutboundAllowPrivateNetworks,
+		FollowRedirects:      true,
+	}
+}
+
+// New creates an HTTP client with opts
+func New(opts Options) *http.Client {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.MaxResponseBytes <= 0 {
+		opts.MaxResponseBytes = defaultMaxResponseBytes
+	}
+
+	d := newDialer(!opts.AllowPrivateNetworks)
+	proxy := proxyFunc(opts.Proxy, d)
+	base := &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           d.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   defaultDialTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	client := &http.Client{
+		Timeout: opts.Timeout,
+		Transport: &transport{
+			base:         base,
+			proxy:        proxy,
+			opts:         opts,
+			requests:     instruments.requests,
+			durations:    instruments.durations,
+			blockPrivate: !opts.AllowPrivateNetworks,
+		},
+	}
+	if !opts.FollowRedirects {
+		client.CheckRedirect = func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	return client
+}
+
+// proxyFunc selects the proxy for each request and lets the dialer connect to it, since
+// proxies are configured by the operator and often run on internal addresses. The proxy
+// environment variables are read when the client is created.
+func proxyFunc(proxy string, d *dialer) func(*http.Request) (*url.URL, error) {
+	fromEnvironment := httpproxy.FromEnvironment().ProxyFunc()
+	selectProxy := func(req *http.Request) (*url.URL, error) {
+		return fromEnvironment(req.URL)
+	}
+	if proxy != "" {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil || proxyURL.Host == "" {
+			selectProxy = func(*http.Request) (*url.URL, error) {
+				return nil, fmt.Errorf("invalid outbound proxy URL %q", proxy)
+			}
+		} else {
+			selectProxy = http.ProxyURL(proxyURL)
+		}
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		proxyURL, err := selectProxy(req)
+		if proxyURL != nil {
+			port := proxyURL.Port()
+			if port == "" {
+				port = map[string]string{"https": "443", "socks5": "1080"}[proxyURL.Scheme]
+			}
+			if port == "" {
+				port = "80"
+			}
+			d.proxies.Store(net.JoinHostPort(proxyURL.Hostname(), port), struct{}{})
+		}
+		return proxyURL, err
+	}
+}
+
+// transport checks destinations, retries transient failures, caps response bodies and
+// records metrics around the standard transport
+type transport struct {
+	base         http.RoundTripper
+	proxy        func(*http.Request) (*url.URL, error)
+	opts         Options
+	requests     metric.Int64Counter
+	durations    metric.Float64Histogram
+	blockPrivate bool
+}
+
+// RoundTrip sends req, retrying idempotent requests that fail transiently
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.blockPrivate {
+		if err := t.checkDestination(req); err != nil {
+			t.record(req.Context(), 0, nil, err)
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		resp, err := t.base.RoundTrip(req)
+		t.record(req.Context(), time.Since(start), resp, err)
+
+		if attempt >= t.opts.Retries || !retryable(req, resp, err) {
+			if err != nil {
+				return nil, err
+			}
+			return t.limit(resp)
+		}
+
+		delay := retryDelay(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// checkDestination refuses requests for blocked hosts. A proxy resolves hostnames itself, so
+// the dialer never sees their addresses, and they are resolved and checked here instead.
+func (t *transport) checkDestination(req *http.Request) error {
+	host := req.URL.Hostname()
+	if err := checkHost(host); err != nil {
+		return err
+	}
+	proxyURL, err := t.proxy(req)
+	if err != nil || proxyURL == nil {
+		return nil
+	}
+	return checkResolved(req.Context(), host)
+}
+
+// limit fails responses that declare a body larger than the cap, and caps the rest as they are read
+func (t *transport) limit(resp *http.Response) (*http.Response, error) {
+	if resp.ContentLength > t.opts.MaxResponseBytes {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %d bytes, limit is %d", ErrResponseTooLarge, resp.ContentLength, t.opts.MaxResponseBytes)
+	}
+	resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: t.opts.MaxResponseBytes}
+	return resp, nil
+}
+
+// retryable reports whether a failed attempt is worth retrying
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, ErrBlockedAddress)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented)
+}
+
+// retryDelay backs off exponentially with jitter, or waits as long as a Retry-After header
+// asks, within retryMaxDelay
+func retryDelay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, retryMaxDelay)
+		}
+	}
+	delay := min(retryBaseDelay<<attempt, retryMaxDelay)
+	return delay/2 + rand.N(delay/2+1) // #nosec G404 -- jitter doesn't need a secure source
+}
+
+// record counts an attempt and its duration under the client's destination
+func (t *transport) record(ctx context.Context, duration time.Duration, resp *http.Response, err error) {
+	outcome := "error"
+	switch {
+	case errors.Is(err, ErrBlockedAddress):
+		outcome = "blocked"
+	case err == nil:
+		outcome = strconv.Itoa(resp.StatusCode/100) + "xx"
+	}
+	attrs := metric.WithAttributes(
+		attribute.String("destination", t.opts.Destination),
+		attribute.String("outcome", outcome),
+	)
+	t.requests.Add(ctx, 1, attrs)
+	if duration > 0 {
+		t.durations.Record(ctx, duration.Seconds(), attrs)
+	}
+}
+
+// limitedBody fails reads once more than remaining bytes have been read
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	exceeded  bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.exceeded {
+		return 0, ErrResponseTooLarge
+	}
+	// Read one byte past the limit to tell a body of exactly the limit from a longer one
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) > b.remaining {
+		b.exceeded = true
+		return int(b.remaining), ErrResponseTooLarge
+	}
+	b.remaining -= int64(n)
+	return n, err
+}
+
+// instruments are created from the global meter provider, which forwards to the registry's
+// provider once metrics are initialized, so clients can be built before that
+var instruments = func() (i struct {
+	requests  metric.Int64Counter
+	durations metric.Float64Histogram
+}) {
+	meter := otel.Meter(telemetry.TracerName)
+	i.requests, _ = meter.Int64Counter(
+		telemetry.Namespace+".outbound.requests",
+		metric.WithDescription("Total number of outbound HTTP request attempts by destination and outcome"),
+	)
+	i.durations, _ = meter.Float64Histogram(
+		telemetry.Namespace+".outbound.request.duration",
+		metric.WithDescription("Duration of outbound HTTP request attempts in seconds"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.01, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0, 10.0),
+	)
+	return i
+}()
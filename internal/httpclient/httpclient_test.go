@@ -0,0 +1,221 @@
+package httpclient_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/httpclient"
+)
+
+func get(t *testing.T, client *http.Client, url string) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	if resp != nil {
+		t.Cleanup(func() { resp.Body.Close() })
+	}
+	return resp, err
+}
+
+func TestIsBlocked(t *testing.T) {
+	blocked := []string{
+		"127.0.0.1", "::1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254",
+		"100.100.100.200", "0.0.0.0", "fd00:ec2::254", "fe80::1", "::ffff:127.0.0.1", "224.0.0.1",
+	}
+	for _, addr := range blocked {
+		assert.True(t, httpclient.IsBlocked(netip.MustParseAddr(addr)), addr)
+	}
+	for _, addr := range []string{"140.82.112.3", "8.8.8.8", "2606:4700::1111"} {
+		assert.False(t, httpclient.IsBlocked(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestPrivateNetworks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+
+	// The test server listens on loopback, which is blocked by default
+	_, err := get(t, httpclient.New(httpclient.Options{Destination: "test"}), server.URL)
+	assert.ErrorIs(t, err, httpclient.ErrBlockedAddress)
+
+	// Names that resolve to internal addresses are blocked when dialing
+	_, err = get(t, httpclient.New(httpclient.Options{Destination: "test"}), strings.Replace(server.URL, "127.0.0.1", "localhost", 1))
+	assert.ErrorIs(t, err, httpclient.ErrBlockedAddress)
+
+	resp, err := get(t, httpclient.New(httpclient.Options{Destination: "test", AllowPrivateNetworks: true}), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/target", http.StatusFound)
+	})
+	mux.HandleFunc("/target", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "ok")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	resp, err := get(t, httpclient.New(httpclient.Options{AllowPrivateNetworks: true}), server.URL+"/redirect")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+
+	resp, err = get(t, httpclient.New(httpclient.Options{AllowPrivateNetworks: true, FollowRedirects: true}), server.URL+"/redirect")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/declared", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, strings.Repeat("a", 100))
+	})
+	mux.HandleFunc("/streamed", func(w http.ResponseWriter, _ *http.Request) {
+		// Flushing before writing the body sends it chunked, without a Content-Length
+		w.(http.Flusher).Flush()
+		fmt.Fprint(w, strings.Repeat("a", 100))
+	})
+	mux.HandleFunc("/exact", func(w http.ResponseWriter, _ *http.Request) {
+		w.(http.Flusher).Flush()
+		fmt.Fprint(w, strings.Repeat("a", 10))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := httpclient.New(httpclient.Options{AllowPrivateNetworks: true, MaxResponseBytes: 10})
+
+	_, err := get(t, client, server.URL+"/declared")
+	assert.ErrorIs(t, err, httpclient.ErrResponseTooLarge)
+
+	resp, err := get(t, client, server.URL+"/streamed")
+	require.NoError(t, err)
+	_, err = io.ReadAll(resp.Body)
+	assert.ErrorIs(t, err, httpclient.ErrResponseTooLarge)
+
+	resp, err = get(t, client, server.URL+"/exact")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Len(t, body, 10)
+}
+
+func TestRetries(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, r.Method)
+	}))
+	defer server.Close()
+	client := httpclient.New(httpclient.Options{AllowPrivateNetworks: true, Retries: 2})
+
+	// GET requests are retried until they succeed
+	resp, err := get(t, client, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), attempts.Load())
+
+	// Other requests are not, since they may not be idempotent
+	attempts.Store(0)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, nil)
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), attempts.Load())
+
+	// Retries give up with the last response
+	attempts.Store(-10)
+	resp, err = get(t, client, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(-7), attempts.Load())
+}
+
+func TestProxy(t *testing.T) {
+	// An operator's proxy may itself run on an internal address
+	var proxied atomic.Bool
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Store(true)
+		fmt.Fprint(w, r.URL.Host)
+	}))
+	defer proxy.Close()
+	httpclient.SetLookup(t, lookup(map[string]string{"example.com": "93.184.215.14"}))
+	client := httpclient.New(httpclient.Options{Proxy: proxy.URL})
+
+	resp, err := get(t, client, "http://example.com/")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.True(t, proxied.Load())
+	assert.Equal(t, "example.com", string(body))
+
+	// Literal internal addresses are still refused, since the proxy would reach them
+	_, err = get(t, client, "http://169.254.169.254/latest/meta-data/")
+	assert.ErrorIs(t, err, httpclient.ErrBlockedAddress)
+}
+
+func TestEnvironmentProxy(t *testing.T) {
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Add(1)
+		fmt.Fprint(w, r.URL.Host)
+	}))
+	defer proxy.Close()
+	t.Setenv("HTTP_PROXY", proxy.URL)
+	t.Setenv("HTTPS_PROXY", proxy.URL)
+	t.Setenv("NO_PROXY", "")
+	httpclient.SetLookup(t, lookup(map[string]string{
+		"example.com":          "93.184.215.14",
+		"internal.example.com": "127.0.0.1",
+		"metadata.example.com": "169.254.169.254",
+	}))
+	client := httpclient.New(httpclient.Options{Destination: "test"})
+
+	resp, err := get(t, client, "http://example.com/")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(1), proxied.Load())
+
+	// The proxy would resolve these names itself, so they are checked before it gets them
+	for _, url := range []string{"http://internal.example.com/", "https://metadata.example.com/latest/meta-data/"} {
+		_, err = get(t, client, url)
+		assert.ErrorIs(t, err, httpclient.ErrBlockedAddress, url)
+	}
+	assert.Equal(t, int32(1), proxied.Load())
+
+	// Clients allowed to reach private networks send them to the proxy
+	resp, err = get(t, httpclient.New(httpclient.Options{Destination: "test", AllowPrivateNetworks: true}), "http://internal.example.com/")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), proxied.Load())
+}
+
+// lookup resolves the hostnames in hosts to their addresses
+func lookup(hosts map[string]string) func(context.Context, string, string) ([]netip.Addr, error) {
+	return func(_ context.Context, _, host string) ([]netip.Addr, error) {
+		addr, ok := hosts[host]
+		if !ok {
+			return nil, fmt.Errorf("no such host %s", host)
+		}
+		return []netip.Addr{netip.MustParseAddr(addr)}, nil
+	}
+}
@@ -0,0 +1,81 @@
+// Package logging configures structured logging for the registry
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request ID carried by ctx, or an empty string if there is none
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// ParseLevel converts a configured log level (debug, info, warn, error) to a slog.Level
+func ParseLevel(level string) (slog.Level, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(strings.TrimSpace(level))); err != nil {
+		return 0, fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+	return l, nil
+}
+
+// New creates a logger writing to w with the configured level and format.
+// Records logged with a context include its request ID and trace ID.
+func New(w io.Writer, cfg *config.Config) (*slog.Logger, error) {
+	level, err := ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch cfg.LogFormat {
+	case config.LogFormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	case config.LogFormatText, "":
+		handler = slog.NewTextHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("invalid log format %q; supported formats: %s, %s", cfg.LogFormat, config.LogFormatText, config.LogFormatJSON)
+	}
+
+	return slog.New(contextHandler{Handler: handler}), nil
+}
+
+// contextHandler adds request-scoped attributes from the context to every record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if requestID := RequestID(ctx); requestID != "" {
+		r.AddAttrs(slog.String("request_id", requestID))
+	}
+	if traceID := telemetry.TraceID(ctx); traceID != "" {
+		r.AddAttrs(slog.String("trace_id", traceID))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,48 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+)
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, &config.Config{LogLevel: "warn", LogFormat: config.LogFormatJSON})
+	require.NoError(t, err)
+
+	ctx := logging.WithRequestID(context.Background(), "req-123")
+	logger.InfoContext(ctx, "dropped")
+	logger.WarnContext(ctx, "kept", slog.Int("count", 2))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 1)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(lines[0], &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, "kept", record["msg"])
+	assert.Equal(t, "req-123", record["request_id"])
+	assert.InDelta(t, 2, record["count"], 0)
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	_, err := logging.New(&bytes.Buffer{}, &config.Config{LogLevel: "verbose"})
+	assert.ErrorContains(t, err, "invalid log level")
+
+	_, err = logging.New(&bytes.Buffer{}, &config.Config{LogLevel: "info", LogFormat: "xml"})
+	assert.ErrorContains(t, err, "invalid log format")
+}
+
+func TestRequestID(t *testing.T) {
+	assert.Empty(t, logging.RequestID(context.Background()))
+	assert.Equal(t, "abc", logging.RequestID(logging.WithRequestID(context.Background(), "abc")))
+}
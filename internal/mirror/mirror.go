@@ -0,0 +1,333 @@
+// Package mirror keeps the local registry in sync with one or more upstream registries
+package mirror
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+// Action describes what the mirror did with a single upstream server
+type Action string
+
+const (
+	// ActionCreated means the server did not exist locally and was published
+	ActionCreated Action = "created"
+	// ActionUpdated means a newer upstream version, an upstream change to a version that wasn't
+	// edited locally, or an upstream-wins conflict was applied
+	ActionUpdated Action = "updated"
+	// ActionUnchanged means the local record already matches upstream
+	ActionUnchanged Action = "unchanged"
+	// ActionSkippedLocal means the server belongs to a locally authoritative namespace
+	ActionSkippedLocal Action = "skipped_local"
+	// ActionConflict means the local record was edited or is ahead of upstream, and was kept
+	ActionConflict Action = "conflict"
+	// ActionFailed means the server couldn't be applied and is retried on the next pass
+	ActionFailed Action = "failed"
+)
+
+const (
+	// defaultInterval is used when no positive mirror interval is configured
+	defaultInterval = 15 * time.Minute
+	// syncTimeout bounds a single pass over one upstream registry
+	syncTimeout = 5 * time.Minute
+)
+
+// Worker periodically pulls servers from upstream registries into the local database
+type Worker struct {
+	db              database.Database
+	client          *http.Client
+	metrics         *telemetry.Metrics
+	upstreams       []string
+	interval        time.Duration
+	localNamespaces []string
+	policy          config.MirrorConflictPolicy
+
+	// started is when the worker was created, which lag is measured from until an upstream syncs
+	started time.Time
+
+	mu          sync.RWMutex
+	lastSuccess map[string]time.Time
+}
+
+// NewWorker creates a new mirror worker from the application configuration
+func NewWorker(cfg *config.Config, db database.Database, metrics *telemetry.Metrics) *Worker {
+	upstreams := make([]string, 0, len(cfg.MirrorUpstreams))
+	for _, upstream := range cfg.MirrorUpstreams {
+		if upstream = strings.TrimSpace(upstream); upstream != "" {
+			upstreams = append(upstreams, serversURL(upstream))
+		}
+	}
+
+	interval := cfg.MirrorInterval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	return &Worker{
+		db:              db,
+		client:          database.NewImportClient(cfg),
+		metrics:         metrics,
+		upstreams:       upstreams,
+		interval:        interval,
+		localNamespaces: cfg.MirrorLocalNamespaces,
+		policy:          cfg.MirrorConflictPolicy,
+		started:         time.Now(),
+		lastSuccess:     make(map[string]time.Time),
+	}
+}
+
+// Enabled reports whether any upstream registries are configured
+func (w *Worker) Enabled() bool {
+	return len(w.upstreams) > 0
+}
+
+// Run syncs all upstreams immediately and then on every interval until the context is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	if !w.Enabled() {
+		return
+	}
+
+	slog.InfoContext(ctx, "Mirroring upstream registries", slog.Int("upstreams", len(w.upstreams)), slog.Duration("interval", w.interval))
+
+	_ = w.SyncOnce(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = w.SyncOnce(ctx)
+		}
+	}
+}
+
+// SyncOnce performs a single pass over every upstream registry
+func (w *Worker) SyncOnce(ctx context.Context) error {
+	var errs []error
+	for _, upstream := range w.upstreams {
+		if err := w.syncUpstream(ctx, upstream); err != nil {
+			slog.ErrorContext(ctx, "Mirror sync failed", slog.String("upstream", upstream), slog.Any("error", err))
+			errs = append(errs, fmt.Errorf("%s: %w", upstream, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LastSuccess returns the time of the most recent successful sync across all upstreams
+func (w *Worker) LastSuccess() time.Time {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var latest time.Time
+	for _, t := range w.lastSuccess {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// syncUpstream pulls all servers from a single upstream and applies them locally. Only failing to
+// fetch the upstream fails the sync; servers that fail to apply are logged and counted, and don't
+// hold back the servers that apply.
+func (w *Worker) syncUpstream(ctx context.Context, upstream string) error {
+	ctx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+
+	attrs := metric.WithAttributes(attribute.String("upstream", upstream))
+	defer w.recordLag(ctx, upstream, attrs)
+
+	fetched := time.Now()
+	records, err := database.FetchFromRegistryAPI(ctx, w.client, upstream)
+	if err != nil {
+		if w.metrics != nil {
+			w.metrics.MirrorSyncErrors.Add(ctx, 1, attrs)
+		}
+		return fmt.Errorf("failed to fetch upstream servers: %w", err)
+	}
+
+	counts := make(map[Action]int)
+	for _, record := range records {
+		action, err := w.apply(ctx, upstream, record)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to mirror server",
+				slog.String("upstream", upstream),
+				slog.String("name", record.ServerJSON.Name),
+				slog.String("version", record.ServerJSON.VersionDetail.Version),
+				slog.Any("error", err))
+			action = ActionFailed
+		}
+		counts[action]++
+		if w.metrics != nil {
+			w.metrics.MirrorServersApplied.Add(ctx, 1, metric.WithAttributes(
+				attribute.String("upstream", upstream),
+				attribute.String("action", string(action)),
+			))
+		}
+	}
+
+	w.mu.Lock()
+	w.lastSuccess[upstream] = fetched
+	w.mu.Unlock()
+
+	slog.InfoContext(ctx, "Mirror sync complete",
+		slog.String("upstream", upstream),
+		slog.Int(string(ActionCreated), counts[ActionCreated]),
+		slog.Int(string(ActionUpdated), counts[ActionUpdated]),
+		slog.Int(string(ActionUnchanged), counts[ActionUnchanged]),
+		slog.Int(string(ActionSkippedLocal), counts[ActionSkippedLocal]),
+		slog.Int(string(ActionConflict), counts[ActionConflict]),
+		slog.Int(string(ActionFailed), counts[ActionFailed]),
+	)
+	return nil
+}
+
+// recordLag updates the lag gauge with the time since the upstream was last fetched, or since the
+// worker started if it never was
+func (w *Worker) recordLag(ctx context.Context, upstream string, attrs metric.MeasurementOption) {
+	if w.metrics == nil {
+		return
+	}
+
+	w.mu.RLock()
+	last, ok := w.lastSuccess[upstream]
+	w.mu.RUnlock()
+	if !ok {
+		last = w.started
+	}
+	w.metrics.MirrorSyncLag.Record(ctx, time.Since(last).Seconds(), attrs)
+}
+
+// apply reconciles a single upstream record with the local database. The hash of every
+// upstream copy applied is recorded, so that a local version that still matches it is known to be
+// unedited and follows later upstream changes to the same version without a conflict.
+func (w *Worker) apply(ctx context.Context, upstreamURL string, upstream *model.ServerRecord) (Action, error) {
+	name := upstream.ServerJSON.Name
+	if w.isLocalNamespace(name) {
+		return ActionSkippedLocal, nil
+	}
+
+	upstreamHash, err := serverHash(upstream.ServerJSON)
+	if err != nil {
+		return "", err
+	}
+
+	existing, _, err := w.db.List(ctx, map[string]any{"name": name}, "", 1)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up local server: %w", err)
+	}
+
+	if len(existing) == 0 {
+		return ActionCreated, w.publish(ctx, upstreamURL, upstream, upstreamHash)
+	}
+
+	local := existing[0]
+	switch cmp := database.CompareSemanticVersions(upstream.ServerJSON.VersionDetail.Version, local.ServerJSON.VersionDetail.Version); {
+	case cmp > 0:
+		return ActionUpdated, w.publish(ctx, upstreamURL, upstream, upstreamHash)
+	case cmp < 0:
+		// Local is ahead of upstream; versions cannot be rolled back so this is always kept
+		return ActionConflict, nil
+	}
+
+	localHash, err := serverHash(local.ServerJSON)
+	if err != nil {
+		return "", err
+	}
+	mirroredHash, err := w.db.MirroredHash(ctx, local.RegistryMetadata.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if localHash == upstreamHash {
+		if mirroredHash != upstreamHash {
+			if err := w.db.SetMirroredHash(ctx, local.RegistryMetadata.ID, upstreamURL, upstreamHash); err != nil {
+				return "", err
+			}
+		}
+		return ActionUnchanged, nil
+	}
+
+	// The local version differs from upstream. Unless it was edited locally since it was last
+	// mirrored, upstream changed it and the change is applied; otherwise it's a conflict.
+	edited := localHash != mirroredHash
+	if edited && w.policy != config.MirrorConflictPolicyUpstreamWins {
+		return ActionConflict, nil
+	}
+
+	serverDetail := upstream.ServerJSON
+	if err := w.db.Update(ctx, local.RegistryMetadata.ID, &serverDetail); err != nil {
+		return "", err
+	}
+	if err := w.db.SetMirroredHash(ctx, local.RegistryMetadata.ID, upstreamURL, upstreamHash); err != nil {
+		return "", err
+	}
+	return ActionUpdated, nil
+}
+
+// publish publishes a new version from upstream and records the hash of the upstream copy
+func (w *Worker) publish(ctx context.Context, upstreamURL string, upstream *model.ServerRecord, hash string) error {
+	record, err := w.db.Publish(ctx, upstream.ServerJSON, upstream.PublisherExtensions)
+	if err != nil {
+		return err
+	}
+	return w.db.SetMirroredHash(ctx, record.RegistryMetadata.ID, upstreamURL, hash)
+}
+
+// isLocalNamespace reports whether the server name belongs to a locally authoritative namespace
+func (w *Worker) isLocalNamespace(name string) bool {
+	for _, pattern := range w.localNamespaces {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if strings.HasSuffix(pattern, "*") {
+			if strings.HasPrefix(name, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+		} else if name == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// serverHash hashes a server.json document, ignoring registry-populated version fields
+func serverHash(server model.ServerDetail) (string, error) {
+	server.VersionDetail = model.VersionDetail{Version: server.VersionDetail.Version}
+
+	serverJSON, err := json.Marshal(server)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal server: %w", err)
+	}
+	sum := sha256.Sum256(serverJSON)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// serversURL turns a registry root URL into its /v0/servers endpoint
+func serversURL(upstream string) string {
+	if strings.Contains(upstream, "/v0/servers") {
+		return upstream
+	}
+	return strings.TrimSuffix(upstream, "/") + "/v0/servers"
+}
@@ -0,0 +1,224 @@
+package mirror_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/mirror"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+func upstreamServer(name, version, description string) model.ServerResponse {
+	return model.ServerResponse{
+		Server: model.ServerDetail{
+			Name:        name,
+			Description: description,
+			Repository: model.Repository{
+				URL:    "https://github.com/example/" + name,
+				Source: "github",
+			},
+			VersionDetail: model.VersionDetail{Version: version},
+		},
+		XIOModelContextProtocolRegistry: map[string]interface{}{
+			"id":        name + "-" + version,
+			"is_latest": true,
+		},
+	}
+}
+
+func newUpstream(t *testing.T, servers *[]model.ServerResponse) *httptest.Server {
+	t.Helper()
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v0/servers", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"servers": *servers})
+	}))
+	t.Cleanup(upstream.Close)
+	return upstream
+}
+
+func newWorker(t *testing.T, db database.Database, cfg *config.Config) *mirror.Worker {
+	t.Helper()
+	metrics, err := telemetry.NewMetrics(noop.NewMeterProvider().Meter("test"))
+	require.NoError(t, err)
+	return mirror.NewWorker(cfg, db, metrics)
+}
+
+func latestByName(t *testing.T, db database.Database, name string) *model.ServerRecord {
+	t.Helper()
+	records, _, err := db.List(context.Background(), map[string]any{"name": name}, "", 1)
+	require.NoError(t, err)
+	if len(records) == 0 {
+		return nil
+	}
+	return records[0]
+}
+
+func TestWorker_SyncOnce(t *testing.T) {
+	ctx := context.Background()
+	servers := []model.ServerResponse{
+		upstreamServer("io.github.example/weather", "1.0.0", "Weather"),
+		upstreamServer("com.internal/tools", "9.9.9", "Upstream copy of a private server"),
+	}
+	upstream := newUpstream(t, &servers)
+
+	db := database.NewMemoryDB(map[string]*model.ServerDetail{})
+	_, err := db.Publish(ctx, model.ServerDetail{
+		Name:          "com.internal/tools",
+		Description:   "Private server",
+		Repository:    model.Repository{URL: "https://git.internal/tools", Source: "gitlab"},
+		VersionDetail: model.VersionDetail{Version: "1.0.0"},
+	}, map[string]interface{}{})
+	require.NoError(t, err)
+
+	worker := newWorker(t, db, &config.Config{
+		MirrorUpstreams:       []string{upstream.URL},
+		MirrorLocalNamespaces: []string{"com.internal/*"},
+	})
+	assert.True(t, worker.Enabled())
+
+	// Initial sync creates the upstream server and leaves the local namespace alone
+	require.NoError(t, worker.SyncOnce(ctx))
+	assert.False(t, worker.LastSuccess().IsZero())
+
+	weather := latestByName(t, db, "io.github.example/weather")
+	require.NotNil(t, weather)
+	assert.Equal(t, "1.0.0", weather.ServerJSON.VersionDetail.Version)
+
+	internal := latestByName(t, db, "com.internal/tools")
+	require.NotNil(t, internal)
+	assert.Equal(t, "1.0.0", internal.ServerJSON.VersionDetail.Version)
+	assert.Equal(t, "Private server", internal.ServerJSON.Description)
+
+	// A newer upstream version is applied incrementally
+	servers[0] = upstreamServer("io.github.example/weather", "1.1.0", "Weather")
+	require.NoError(t, worker.SyncOnce(ctx))
+
+	weather = latestByName(t, db, "io.github.example/weather")
+	require.NotNil(t, weather)
+	assert.Equal(t, "1.1.0", weather.ServerJSON.VersionDetail.Version)
+}
+
+func TestWorker_ConflictPolicy(t *testing.T) {
+	tests := []struct {
+		name                string
+		policy              config.MirrorConflictPolicy
+		expectedDescription string
+	}{
+		{
+			name:                "local wins keeps the local record",
+			policy:              config.MirrorConflictPolicyLocalWins,
+			expectedDescription: "Local description",
+		},
+		{
+			name:                "upstream wins overwrites the local record",
+			policy:              config.MirrorConflictPolicyUpstreamWins,
+			expectedDescription: "Upstream description",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			servers := []model.ServerResponse{
+				upstreamServer("io.github.example/weather", "1.0.0", "Upstream description"),
+			}
+			upstream := newUpstream(t, &servers)
+
+			db := database.NewMemoryDB(map[string]*model.ServerDetail{})
+			local := servers[0].Server
+			local.Description = "Local description"
+			_, err := db.Publish(ctx, local, map[string]interface{}{})
+			require.NoError(t, err)
+
+			worker := newWorker(t, db, &config.Config{
+				MirrorUpstreams:      []string{upstream.URL + "/"},
+				MirrorConflictPolicy: tt.policy,
+			})
+			require.NoError(t, worker.SyncOnce(ctx))
+
+			record := latestByName(t, db, "io.github.example/weather")
+			require.NotNil(t, record)
+			assert.Equal(t, tt.expectedDescription, record.ServerJSON.Description)
+		})
+	}
+}
+
+func TestWorker_UpstreamChange(t *testing.T) {
+	ctx := context.Background()
+	servers := []model.ServerResponse{
+		upstreamServer("io.github.example/weather", "1.0.0", "Weather"),
+	}
+	upstream := newUpstream(t, &servers)
+
+	db := database.NewMemoryDB(map[string]*model.ServerDetail{})
+	worker := newWorker(t, db, &config.Config{
+		MirrorUpstreams:      []string{upstream.URL},
+		MirrorConflictPolicy: config.MirrorConflictPolicyLocalWins,
+	})
+	require.NoError(t, worker.SyncOnce(ctx))
+
+	// An upstream change to a version that wasn't edited locally is applied, even when local wins
+	servers[0] = upstreamServer("io.github.example/weather", "1.0.0", "Weather forecasts")
+	require.NoError(t, worker.SyncOnce(ctx))
+
+	record := latestByName(t, db, "io.github.example/weather")
+	require.NotNil(t, record)
+	assert.Equal(t, "Weather forecasts", record.ServerJSON.Description)
+
+	// Once edited locally, the version is kept
+	local := record.ServerJSON
+	local.Description = "Local description"
+	require.NoError(t, db.Update(ctx, record.RegistryMetadata.ID, &local))
+
+	servers[0] = upstreamServer("io.github.example/weather", "1.0.0", "Weather forecasts and alerts")
+	require.NoError(t, worker.SyncOnce(ctx))
+
+	record = latestByName(t, db, "io.github.example/weather")
+	require.NotNil(t, record)
+	assert.Equal(t, "Local description", record.ServerJSON.Description)
+}
+
+func TestWorker_ServerFailure(t *testing.T) {
+	ctx := context.Background()
+	invalid := upstreamServer("io.github.example/invalid", "1.0.0", "No repository")
+	invalid.Server.Repository = model.Repository{}
+	servers := []model.ServerResponse{
+		invalid,
+		upstreamServer("io.github.example/weather", "1.0.0", "Weather"),
+	}
+	upstream := newUpstream(t, &servers)
+
+	db := database.NewMemoryDB(map[string]*model.ServerDetail{})
+	worker := newWorker(t, db, &config.Config{MirrorUpstreams: []string{upstream.URL}})
+
+	// A server that fails to apply doesn't fail the sync of the others
+	require.NoError(t, worker.SyncOnce(ctx))
+	assert.False(t, worker.LastSuccess().IsZero())
+	assert.Nil(t, latestByName(t, db, "io.github.example/invalid"))
+	assert.NotNil(t, latestByName(t, db, "io.github.example/weather"))
+}
+
+func TestWorker_UpstreamFailure(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer upstream.Close()
+
+	db := database.NewMemoryDB(map[string]*model.ServerDetail{})
+	worker := newWorker(t, db, &config.Config{MirrorUpstreams: []string{upstream.URL}})
+
+	err := worker.SyncOnce(context.Background())
+	assert.Error(t, err)
+	assert.True(t, worker.LastSuccess().IsZero())
+}
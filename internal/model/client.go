@@ -0,0 +1,114 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ClientFeature represents an MCP feature a client supports
+type ClientFeature string
+
+const (
+	// Server features the client can use
+	ClientFeatureTools       ClientFeature = "tools"
+	ClientFeatureResources   ClientFeature = "resources"
+	ClientFeaturePrompts     ClientFeature = "prompts"
+	ClientFeatureCompletions ClientFeature = "completions"
+	// Client features the client offers to servers
+	ClientFeatureRoots       ClientFeature = "roots"
+	ClientFeatureSampling    ClientFeature = "sampling"
+	ClientFeatureElicitation ClientFeature = "elicitation"
+)
+
+// ClientTransport represents an MCP transport a client can connect to servers with
+type ClientTransport string
+
+const (
+	ClientTransportStdio          ClientTransport = "stdio"
+	ClientTransportSSE            ClientTransport = "sse"
+	ClientTransportStreamableHTTP ClientTransport = "streamable-http"
+)
+
+// ClientFeatures lists every valid ClientFeature
+var ClientFeatures = []ClientFeature{
+	ClientFeatureTools, ClientFeatureResources, ClientFeaturePrompts, ClientFeatureCompletions,
+	ClientFeatureRoots, ClientFeatureSampling, ClientFeatureElicitation,
+}
+
+// ClientTransports lists every valid ClientTransport
+var ClientTransports = []ClientTransport{ClientTransportStdio, ClientTransportSSE, ClientTransportStreamableHTTP}
+
+// ClientDetail represents an MCP client (a host application or agent that runs MCP servers)
+type ClientDetail struct {
+	Name          string            `json:"name"`
+	Description   string            `json:"description"`
+	WebsiteURL    string            `json:"website_url,omitempty"`
+	Repository    Repository        `json:"repository,omitempty"`
+	VersionDetail VersionDetail     `json:"version_detail"`
+	Features      []ClientFeature   `json:"features"`
+	Transports    []ClientTransport `json:"transports"`
+}
+
+// ClientRecord represents the storage model for a client, separating client.json from registry metadata
+type ClientRecord struct {
+	ClientJSON          ClientDetail           `json:"client"`
+	RegistryMetadata    RegistryMetadata       `json:"registry_metadata"`
+	PublisherExtensions map[string]interface{} `json:"publisher_extensions"`
+}
+
+// ClientResponse represents the API response format for clients with wrapper and extensions
+type ClientResponse struct {
+	Client                          ClientDetail `json:"client"`
+	XIOModelContextProtocolRegistry interface{}  `json:"x-io.modelcontextprotocol.registry,omitempty"`
+	XPublisher                      interface{}  `json:"x-publisher,omitempty"`
+}
+
+// PublishClientRequest represents the API request format for publishing clients
+type PublishClientRequest struct {
+	Client     ClientDetail `json:"client"`
+	XPublisher interface{}  `json:"x-publisher,omitempty"`
+}
+
+// ValidateClientDetail checks the required fields, the name and the features and transports of a client
+func ValidateClientDetail(client ClientDetail) error {
+	if client.Name == "" {
+		return fmt.Errorf("client name is required")
+	}
+	if err := ValidateName("client", client.Name); err != nil {
+		return err
+	}
+	if client.VersionDetail.Version == "" {
+		return fmt.Errorf("client version is required in version_detail")
+	}
+	for _, feature := range client.Features {
+		if !slices.Contains(ClientFeatures, feature) {
+			return fmt.Errorf("unknown client feature %q", feature)
+		}
+	}
+	if len(client.Transports) == 0 {
+		return fmt.Errorf("client must support at least one transport")
+	}
+	for _, transport := range client.Transports {
+		if !slices.Contains(ClientTransports, transport) {
+			return fmt.Errorf("unknown client transport %q", transport)
+		}
+	}
+	return nil
+}
+
+// ToClientResponse converts a ClientRecord to API response format
+func (cr *ClientRecord) ToClientResponse() ClientResponse {
+	response := ClientResponse{
+		Client: cr.ClientJSON,
+	}
+
+	// Add registry metadata extension
+	response.XIOModelContextProtocolRegistry = cr.RegistryMetadata.CreateRegistryExtensions()["x-io.modelcontextprotocol.registry"]
+
+	// Add publisher extensions directly
+	if len(cr.PublisherExtensions) > 0 {
+		response.XPublisher = cr.PublisherExtensions
+	}
+
+	return response
+}
@@ -3,6 +3,7 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"time"
 )
 
@@ -126,11 +127,43 @@ type ServerDetail struct {
 
 // RegistryMetadata represents registry-generated metadata
 type RegistryMetadata struct {
-	ID          string    `json:"id" bson:"_id"`
-	PublishedAt time.Time `json:"published_at" bson:"published_at"`
-	UpdatedAt   time.Time `json:"updated_at,omitempty" bson:"updated_at,omitempty"`
-	IsLatest    bool      `json:"is_latest" bson:"is_latest"`
-	ReleaseDate string    `json:"release_date" bson:"release_date"`
+	ID          string        `json:"id" bson:"_id"`
+	PublishedAt time.Time     `json:"published_at" bson:"published_at"`
+	UpdatedAt   time.Time     `json:"updated_at,omitempty" bson:"updated_at,omitempty"`
+	IsLatest    bool          `json:"is_latest" bson:"is_latest"`
+	ReleaseDate string        `json:"release_date" bson:"release_date"`
+	Installs    *InstallStats `json:"installs,omitempty" bson:"-"`
+}
+
+// InstallTrend describes whether installs in the last 7 days went up or down compared to the 7 days before
+type InstallTrend string
+
+const (
+	InstallTrendUp   InstallTrend = "up"
+	InstallTrendDown InstallTrend = "down"
+	InstallTrendFlat InstallTrend = "flat"
+)
+
+// InstallStats summarises the installs reported for a server version
+type InstallStats struct {
+	Total         int64        `json:"total"`           // All-time installs of this version
+	AllVersions   int64        `json:"all_versions"`    // All-time installs across every version of the server
+	Last7Days     int64        `json:"last_7_days"`     // Installs of this version in the last 7 days, including today
+	Last30Days    int64        `json:"last_30_days"`    // Installs of this version in the last 30 days, including today
+	Previous7Days int64        `json:"previous_7_days"` // Installs of this version in the 7 days before the last 7 days
+	Trend         InstallTrend `json:"trend"`
+}
+
+// NewInstallTrend compares installs in the last 7 days with the 7 days before
+func NewInstallTrend(last7Days, previous7Days int64) InstallTrend {
+	switch {
+	case last7Days > previous7Days:
+		return InstallTrendUp
+	case last7Days < previous7Days:
+		return InstallTrendDown
+	default:
+		return InstallTrendFlat
+	}
 }
 
 // ServerRecord represents the complete storage model that separates server.json from registry metadata
@@ -188,6 +221,18 @@ func ValidatePublisherExtensions(req PublishRequest) error {
 
 // ValidatePublishRequestExtensions validates that only allowed extension fields are present
 func ValidatePublishRequestExtensions(requestData []byte) error {
+	return validateRequestFields(requestData, "server")
+}
+
+// ValidatePublishClientRequestExtensions validates that only allowed extension fields are present
+// in a request to publish a client
+func ValidatePublishClientRequestExtensions(requestData []byte) error {
+	return validateRequestFields(requestData, "client")
+}
+
+// validateRequestFields validates that a publish request only has the published entity, a
+// "server" or "client", and the x-publisher extension
+func validateRequestFields(requestData []byte, entity string) error {
 	// Parse the raw JSON to check for unknown fields
 	var rawRequest map[string]interface{}
 	if err := json.Unmarshal(requestData, &rawRequest); err != nil {
@@ -196,7 +241,7 @@ func ValidatePublishRequestExtensions(requestData []byte) error {
 
 	// Define allowed top-level fields
 	allowedFields := map[string]bool{
-		"server":      true,
+		entity:        true,
 		"x-publisher": true,
 	}
 
@@ -209,7 +254,7 @@ func ValidatePublishRequestExtensions(requestData []byte) error {
 	}
 
 	if len(invalidFields) > 0 {
-		return fmt.Errorf("invalid extension fields: %v. Only 'server' and 'x-publisher' fields are allowed", invalidFields)
+		return fmt.Errorf("invalid extension fields: %v. Only '%s' and 'x-publisher' fields are allowed", invalidFields, entity)
 	}
 
 	return nil
@@ -231,14 +276,18 @@ func ExtractPublisherExtensions(req PublishRequest) map[string]interface{} {
 
 // CreateRegistryExtensions generates the x-io.modelcontextprotocol.registry extension from registry metadata
 func (rm *RegistryMetadata) CreateRegistryExtensions() map[string]interface{} {
+	registry := map[string]interface{}{
+		"id":           rm.ID,
+		"published_at": rm.PublishedAt,
+		"updated_at":   rm.UpdatedAt,
+		"is_latest":    rm.IsLatest,
+		"release_date": rm.ReleaseDate,
+	}
+	if rm.Installs != nil {
+		registry["installs"] = rm.Installs
+	}
 	return map[string]interface{}{
-		"x-io.modelcontextprotocol.registry": map[string]interface{}{
-			"id":           rm.ID,
-			"published_at": rm.PublishedAt,
-			"updated_at":   rm.UpdatedAt,
-			"is_latest":    rm.IsLatest,
-			"release_date": rm.ReleaseDate,
-		},
+		"x-io.modelcontextprotocol.registry": registry,
 	}
 }
 
@@ -251,6 +300,20 @@ func ParseServerName(serverDetail ServerDetail) (string, error) {
 	return name, nil
 }
 
+// namePattern matches names in a namespace, such as io.github.owner/repo, which every server name
+// in the registry's seed data has
+var namePattern = regexp.MustCompile(`^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$`)
+
+// ValidateName checks that the name of an entity, such as a "client", has the form namespace/name
+// that publish permissions are granted for. Server names aren't checked, as servers without a
+// namespace can still be published.
+func ValidateName(entity, name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("%s name %q must be in the form namespace/name, such as io.github.owner/repo", entity, name)
+	}
+	return nil
+}
+
 // ToServerResponse converts a ServerRecord to API response format
 func (sr *ServerRecord) ToServerResponse() ServerResponse {
 	response := ServerResponse{
@@ -262,4 +262,70 @@ func TestServerResponse_EmptyExtensions(t *testing.T) {
 	if exists {
 		assert.Nil(t, publisherValue)
 	}
-}
\ No newline at end of file
+}
+func TestValidateClientDetail(t *testing.T) {
+	valid := ClientDetail{
+		Name:          "com.example/agent",
+		VersionDetail: VersionDetail{Version: "1.0.0"},
+		Features:      []ClientFeature{ClientFeatureTools, ClientFeatureElicitation},
+		Transports:    []ClientTransport{ClientTransportStdio, ClientTransportStreamableHTTP},
+	}
+	require.NoError(t, ValidateClientDetail(valid))
+
+	tests := []struct {
+		name   string
+		modify func(*ClientDetail)
+		errMsg string
+	}{
+		{"missing name", func(c *ClientDetail) { c.Name = "" }, "client name is required"},
+		{"name without namespace", func(c *ClientDetail) { c.Name = "agent" }, "must be in the form namespace/name"},
+		{"name with spaces", func(c *ClientDetail) { c.Name = "com.example/my agent" }, "must be in the form namespace/name"},
+		{"missing version", func(c *ClientDetail) { c.VersionDetail.Version = "" }, "client version is required"},
+		{"unknown feature", func(c *ClientDetail) { c.Features = []ClientFeature{"telepathy"} }, `unknown client feature "telepathy"`},
+		{"no transports", func(c *ClientDetail) { c.Transports = nil }, "at least one transport"},
+		{"unknown transport", func(c *ClientDetail) { c.Transports = []ClientTransport{"carrier-pigeon"} }, `unknown client transport "carrier-pigeon"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := valid
+			tt.modify(&client)
+			err := ValidateClientDetail(client)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestValidateGitHubOIDCPolicy(t *testing.T) {
+	valid := GitHubOIDCPolicy{
+		Resource:   "io.github.octo-org/server",
+		Repository: "octo-org/octo-repo",
+		Refs:       []string{"refs/tags/*"},
+		Workflows:  []string{"octo-org/octo-repo/.github/workflows/release.yml"},
+	}
+	require.NoError(t, ValidateGitHubOIDCPolicy("io.github", "octo-org", valid))
+
+	tests := []struct {
+		name   string
+		modify func(*GitHubOIDCPolicy)
+		errMsg string
+	}{
+		{"resource in another namespace", func(p *GitHubOIDCPolicy) { p.Resource = "io.github.someone/server" }, "must be a server name or pattern in io.github.octo-org/*"},
+		{"resource without a name", func(p *GitHubOIDCPolicy) { p.Resource = "io.github.octo-org/" }, "must be a server name or pattern"},
+		{"repository of another owner", func(p *GitHubOIDCPolicy) { p.Repository = "someone/octo-repo" }, "must be a repository of octo-org"},
+		{"repository without a name", func(p *GitHubOIDCPolicy) { p.Repository = "octo-org" }, "must be a repository of octo-org"},
+		{"empty pattern", func(p *GitHubOIDCPolicy) { p.Environments = []string{""} }, `invalid pattern "" in environments`},
+		{"malformed pattern", func(p *GitHubOIDCPolicy) { p.Refs = []string{"refs/tags/["} }, `invalid pattern "refs/tags/[" in refs`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := valid
+			tt.modify(&policy)
+			err := ValidateGitHubOIDCPolicy("io.github", "octo-org", policy)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
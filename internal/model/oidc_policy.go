@@ -0,0 +1,56 @@
+package model
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// GitHubOIDCPolicy restricts which GitHub Actions workflows can publish part of a GitHub
+// owner's namespace. Once an owner has any policies, GitHub OIDC tokens for that owner only
+// grant the resources of the policies their workflow run matches.
+//
+// Refs, workflows and environments are path.Match patterns, where * doesn't match "/". An
+// empty list allows any value.
+type GitHubOIDCPolicy struct {
+	// Resource is the server name or pattern the policy grants, such as
+	// "io.github.octo-org/server" or "io.github.octo-org/*"
+	Resource string `json:"resource" doc:"Server name or pattern the policy grants" example:"io.github.octo-org/server"`
+	// Repository is the repository the workflow must run in
+	Repository string `json:"repository" doc:"Repository the workflow must run in" example:"octo-org/octo-repo"`
+	// Refs match the ref claim, the git ref that triggered the run
+	Refs []string `json:"refs,omitempty" doc:"Patterns for the git ref of the run"`
+	// Workflows match the workflow_ref claim without its @ref, the workflow file of the run
+	Workflows []string `json:"workflows,omitempty" doc:"Patterns for the workflow file, as owner/repo/.github/workflows/file.yml"`
+	// JobWorkflows match the job_workflow_ref claim without its @ref, the reusable workflow
+	// the job runs, or the workflow file itself if the job isn't in a reusable workflow
+	JobWorkflows []string `json:"job_workflows,omitempty" doc:"Patterns for the reusable workflow the job runs, as owner/repo/.github/workflows/file.yml"`
+	// Environments match the environment claim. A run without an environment only matches an
+	// empty list.
+	Environments []string `json:"environments,omitempty" doc:"Deployment environments the job must run in"`
+}
+
+// ValidateGitHubOIDCPolicy checks that a policy only covers the namespace and repositories of
+// owner, on the GitHub instance whose names start with namespace, such as "io.github"
+func ValidateGitHubOIDCPolicy(namespace, owner string, policy GitHubOIDCPolicy) error {
+	ownerNamespace := namespace + "." + owner + "/"
+	if !strings.HasPrefix(policy.Resource, ownerNamespace) || policy.Resource == ownerNamespace {
+		return fmt.Errorf("resource %q must be a server name or pattern in %s*", policy.Resource, ownerNamespace)
+	}
+
+	repoOwner, repo, found := strings.Cut(policy.Repository, "/")
+	if !found || !strings.EqualFold(repoOwner, owner) || repo == "" || strings.Contains(repo, "/") {
+		return fmt.Errorf("repository %q must be a repository of %s, as %s/<repo>", policy.Repository, owner, owner)
+	}
+
+	for field, patterns := range map[string][]string{
+		"refs": policy.Refs, "workflows": policy.Workflows, "job_workflows": policy.JobWorkflows, "environments": policy.Environments,
+	} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+				return fmt.Errorf("invalid pattern %q in %s", pattern, field)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+// Package policy evaluates publish policies: CEL expressions that every server must satisfy
+// when it is published, updated or deleted
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+// costLimit bounds the work of evaluating an expression, which may iterate over lists sent by publishers
+const costLimit = 1_000_000
+
+// Operation is what the caller is doing with a server
+type Operation string
+
+const (
+	OperationPublish Operation = "publish"
+	OperationUpdate  Operation = "update"
+	OperationDelete  Operation = "delete"
+)
+
+// Input is what policies are evaluated against
+type Input struct {
+	Operation Operation
+	// Server is the server being published or updated, or the server version being deleted
+	Server *model.ServerDetail
+	// Latest is the latest published version of the server, if there is one. For updates it is
+	// the version being updated.
+	Latest *model.ServerResponse
+	// Claims are the claims of the caller's Registry JWT, if they sent one
+	Claims *auth.JWTClaims
+}
+
+// Violation is a policy that a request doesn't satisfy
+type Violation struct {
+	Policy  string
+	Message string
+}
+
+// ViolationError is returned when a request violates one or more policies
+type ViolationError struct {
+	Violations []Violation
+}
+
+func (e *ViolationError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Policy+": "+violation.Message)
+	}
+	return "publish policy violated: " + strings.Join(messages, "; ")
+}
+
+// evaluations counts policy evaluations. It is created from the global meter provider, which
+// forwards to the registry's provider once metrics are initialized.
+var evaluations, _ = otel.Meter(telemetry.TracerName).Int64Counter(
+	telemetry.Namespace+".policy.evaluations",
+	metric.WithDescription("Total number of publish policy evaluations by policy, operation and outcome"),
+)
+
+// compiledPolicy is a policy with its expressions ready to evaluate
+type compiledPolicy struct {
+	config.PublishPolicy
+	match      cel.Program // nil if the policy applies to every request
+	expression cel.Program
+}
+
+// Engine evaluates the configured publish policies
+type Engine struct {
+	policies []compiledPolicy
+}
+
+// NewEngine compiles the configured publish policies
+func NewEngine(cfg *config.Config) (*Engine, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("operation", cel.StringType),
+		cel.Variable("server", cel.DynType),
+		cel.Variable("latest", cel.DynType),
+		cel.Variable("claims", cel.DynType),
+		// Optional fields, like server.?status.orValue("active"), for fields left out of the JSON
+		cel.OptionalTypes(),
+		ext.Strings(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create policy environment: %w", err)
+	}
+
+	engine := &Engine{}
+	for _, policy := range cfg.PublishPolicies {
+		compiled := compiledPolicy{PublishPolicy: policy}
+		if policy.Match != "" {
+			if compiled.match, err = compile(env, policy.Match); err != nil {
+				return nil, fmt.Errorf("publish policy %s: invalid match: %w", policy.Name, err)
+			}
+		}
+		if compiled.expression, err = compile(env, policy.Expression); err != nil {
+			return nil, fmt.Errorf("publish policy %s: invalid expression: %w", policy.Name, err)
+		}
+		engine.policies = append(engine.policies, compiled)
+	}
+	return engine, nil
+}
+
+// compile compiles a boolean expression
+func compile(env *cel.Env, expression string) (cel.Program, error) {
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if !ast.OutputType().IsExactType(cel.BoolType) && !ast.OutputType().IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("expression must evaluate to a bool, not %s", ast.OutputType())
+	}
+	return env.Program(ast, cel.CostLimit(costLimit))
+}
+
+// Applies reports whether any policy applies to operation, so callers can skip gathering input
+func (e *Engine) Applies(operation Operation) bool {
+	for _, policy := range e.policies {
+		if policy.Applies(string(operation)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate evaluates every policy that applies to the input, and returns a *ViolationError
+// listing all the policies it violates. A policy that can't be evaluated, for example because
+// it refers to a field the server doesn't have, counts as violated.
+func (e *Engine) Evaluate(ctx context.Context, input Input) error {
+	vars, err := variables(input)
+	if err != nil {
+		return err
+	}
+
+	var violations []Violation
+	for _, policy := range e.policies {
+		if !policy.Applies(string(input.Operation)) {
+			continue
+		}
+
+		if policy.match != nil {
+			matched, err := evaluate(ctx, policy.match, vars)
+			if err != nil {
+				violations = append(violations, Violation{Policy: policy.Name, Message: "match could not be evaluated: " + err.Error()})
+				recordEvaluation(ctx, policy.Name, input.Operation, "error")
+				continue
+			}
+			if !matched {
+				recordEvaluation(ctx, policy.Name, input.Operation, "skipped")
+				continue
+			}
+		}
+
+		allowed, err := evaluate(ctx, policy.expression, vars)
+		switch {
+		case err != nil:
+			violations = append(violations, Violation{Policy: policy.Name, Message: "expression could not be evaluated: " + err.Error()})
+			recordEvaluation(ctx, policy.Name, input.Operation, "error")
+		case !allowed:
+			message := policy.Message
+			if message == "" {
+				message = "expression " + policy.Expression + " is false"
+			}
+			violations = append(violations, Violation{Policy: policy.Name, Message: message})
+			recordEvaluation(ctx, policy.Name, input.Operation, "violated")
+		default:
+			recordEvaluation(ctx, policy.Name, input.Operation, "allowed")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &ViolationError{Violations: violations}
+	}
+	return nil
+}
+
+// evaluate evaluates a boolean expression
+func evaluate(ctx context.Context, program cel.Program, vars map[string]any) (bool, error) {
+	out, _, err := program.ContextEval(ctx, vars)
+	if err != nil {
+		return false, err
+	}
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression evaluated to %v, not a bool", out.Value())
+	}
+	return result, nil
+}
+
+// variables returns the expression variables for input, with the server and latest record in
+// their JSON form so expressions use the same field names as the API
+func variables(input Input) (map[string]any, error) {
+	server, err := toJSONValue(input.Server)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := toJSONValue(input.Latest)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims any
+	if input.Claims != nil {
+		permissions := make([]any, 0, len(input.Claims.Permissions))
+		for _, permission := range input.Claims.Permissions {
+			permissions = append(permissions, map[string]any{
+				"action":   string(permission.Action),
+				"resource": permission.ResourcePattern,
+			})
+		}
+		claims = map[string]any{
+			"auth_method": string(input.Claims.AuthMethod),
+			"subject":     input.Claims.AuthMethodSubject,
+			"permissions": permissions,
+		}
+	}
+
+	return map[string]any{
+		"operation": string(input.Operation),
+		"server":    server,
+		"latest":    latest,
+		"claims":    claims,
+	}, nil
+}
+
+// toJSONValue converts v to the maps and lists of its JSON form, or nil if v is nil
+func toJSONValue[T any](v *T) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode policy input: %w", err)
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to decode policy input: %w", err)
+	}
+	return value, nil
+}
+
+// recordEvaluation counts a policy evaluation by its outcome
+func recordEvaluation(ctx context.Context, policy string, operation Operation, outcome string) {
+	evaluations.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("policy", policy),
+		attribute.String("operation", string(operation)),
+		attribute.String("outcome", outcome),
+	))
+}
@@ -0,0 +1,177 @@
+package policy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/policy"
+)
+
+func newEngine(t *testing.T, policies string) *policy.Engine {
+	t.Helper()
+	cfg := &config.Config{}
+	require.NoError(t, cfg.PublishPolicies.UnmarshalText([]byte(policies)))
+	engine, err := policy.NewEngine(cfg)
+	require.NoError(t, err)
+	return engine
+}
+
+func TestEngine(t *testing.T) {
+	engine := newEngine(t, `[
+		{
+			"name": "acme-repositories",
+			"match": "server.name.startsWith('com.acme/')",
+			"expression": "server.repository.url.startsWith('https://github.com/acme/')",
+			"message": "servers under com.acme/* must have a repository under github.com/acme"
+		},
+		{
+			"name": "no-reactivation",
+			"operations": ["publish", "update"],
+			"expression": "latest == null || latest.server.?status.orValue('active') != 'deprecated' || server.?status.orValue('active') == 'deprecated'",
+			"message": "deprecated servers can't be re-activated"
+		},
+		{
+			"name": "https-remotes",
+			"expression": "server.?remotes.orValue([]).all(r, r.url.startsWith('https://'))",
+			"message": "remotes must use https"
+		},
+		{
+			"name": "github-deletes",
+			"operations": ["delete"],
+			"expression": "claims != null && claims.auth_method == 'github-at'"
+		}
+	]`)
+
+	acme := func() *model.ServerDetail {
+		return &model.ServerDetail{
+			Name:          "com.acme/server",
+			Repository:    model.Repository{URL: "https://github.com/acme/server", Source: "github"},
+			VersionDetail: model.VersionDetail{Version: "1.0.1"},
+			Remotes:       []model.Remote{{TransportType: "sse", URL: "https://mcp.acme.com/sse"}},
+		}
+	}
+	deprecated := &model.ServerResponse{Server: model.ServerDetail{Name: "com.acme/server", Status: model.ServerStatusDeprecated}}
+
+	tests := []struct {
+		name       string
+		input      func() policy.Input
+		violations []string
+	}{
+		{
+			name: "compliant server",
+			input: func() policy.Input {
+				return policy.Input{Operation: policy.OperationPublish, Server: acme()}
+			},
+		},
+		{
+			name: "repository outside the organization",
+			input: func() policy.Input {
+				server := acme()
+				server.Repository.URL = "https://github.com/someone/server"
+				return policy.Input{Operation: policy.OperationPublish, Server: server}
+			},
+			violations: []string{"acme-repositories: servers under com.acme/* must have a repository under github.com/acme"},
+		},
+		{
+			name: "match limits which servers a policy applies to",
+			input: func() policy.Input {
+				server := acme()
+				server.Name = "com.example/server"
+				server.Repository.URL = "https://github.com/example/server"
+				return policy.Input{Operation: policy.OperationPublish, Server: server}
+			},
+		},
+		{
+			name: "re-activating a deprecated server",
+			input: func() policy.Input {
+				return policy.Input{Operation: policy.OperationPublish, Server: acme(), Latest: deprecated}
+			},
+			violations: []string{"no-reactivation: deprecated servers can't be re-activated"},
+		},
+		{
+			name: "deprecated servers can stay deprecated",
+			input: func() policy.Input {
+				server := acme()
+				server.Status = model.ServerStatusDeprecated
+				return policy.Input{Operation: policy.OperationUpdate, Server: server, Latest: deprecated}
+			},
+		},
+		{
+			name: "every violation is reported",
+			input: func() policy.Input {
+				server := acme()
+				server.Repository.URL = "https://gitlab.com/acme/server"
+				server.Remotes = append(server.Remotes, model.Remote{TransportType: "sse", URL: "http://mcp.acme.com/sse"})
+				return policy.Input{Operation: policy.OperationUpdate, Server: server}
+			},
+			violations: []string{
+				"acme-repositories: servers under com.acme/* must have a repository under github.com/acme",
+				"https-remotes: remotes must use https",
+			},
+		},
+		{
+			name: "claims",
+			input: func() policy.Input {
+				return policy.Input{Operation: policy.OperationDelete, Server: acme(), Claims: &auth.JWTClaims{AuthMethod: model.AuthMethodGitHubAT}}
+			},
+		},
+		{
+			name: "anonymous callers have no claims",
+			input: func() policy.Input {
+				return policy.Input{Operation: policy.OperationDelete, Server: acme()}
+			},
+			violations: []string{"github-deletes: expression claims != null && claims.auth_method == 'github-at' is false"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := engine.Evaluate(context.Background(), tt.input())
+			if len(tt.violations) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			var violation *policy.ViolationError
+			require.ErrorAs(t, err, &violation)
+			var messages []string
+			for _, v := range violation.Violations {
+				messages = append(messages, v.Policy+": "+v.Message)
+			}
+			assert.Equal(t, tt.violations, messages)
+		})
+	}
+
+	assert.True(t, engine.Applies(policy.OperationDelete))
+	assert.False(t, new(policy.Engine).Applies(policy.OperationPublish))
+}
+
+func TestEngineErrors(t *testing.T) {
+	t.Run("expressions that can't be evaluated are violations", func(t *testing.T) {
+		engine := newEngine(t, `[{"name": "status", "expression": "server.status == 'active'"}]`)
+		err := engine.Evaluate(context.Background(), policy.Input{Operation: policy.OperationPublish, Server: &model.ServerDetail{Name: "com.acme/server"}})
+		var violation *policy.ViolationError
+		require.ErrorAs(t, err, &violation)
+		assert.Contains(t, violation.Violations[0].Message, "could not be evaluated")
+	})
+
+	for name, policies := range map[string]string{
+		"syntax error":  `[{"name": "broken", "expression": "server.name.startsWith("}]`,
+		"unknown field": `[{"name": "broken", "expression": "request.name == 'x'"}]`,
+		"not a bool":    `[{"name": "broken", "expression": "'yes'"}]`,
+		"invalid match": `[{"name": "broken", "match": "1 + ", "expression": "true"}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config.Config{}
+			require.NoError(t, cfg.PublishPolicies.UnmarshalText([]byte(policies)))
+			_, err := policy.NewEngine(cfg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "publish policy broken")
+		})
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -71,32 +72,24 @@ func NewFakeRegistryService() RegistryService {
 }
 
 // List retrieves servers with extension wrapper format
-func (s *fakeRegistryService) List(cursor string, limit int) ([]model.ServerResponse, string, error) {
-	// Create a timeout context for the database operation
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
+func (s *fakeRegistryService) List(ctx context.Context, filter map[string]any, cursor string, limit int) ([]model.ServerResponse, string, error) {
 	// Use the database's List method to get ServerRecord entries
-	serverRecords, nextCursor, err := s.db.List(ctx, nil, cursor, limit)
+	serverRecords, nextCursor, err := s.db.List(ctx, filter, cursor, limit)
 	if err != nil {
 		return nil, "", err
 	}
 	
 	// Convert ServerRecord to ServerResponse format
-	result := make([]model.ServerResponse, len(serverRecords))
-	for i, record := range serverRecords {
-		result[i] = record.ToServerResponse()
+	result, err := toServerResponses(ctx, s.db, serverRecords)
+	if err != nil {
+		return nil, "", err
 	}
 
 	return result, nextCursor, nil
 }
 
 // GetByID retrieves a specific server by its registry metadata ID in extension wrapper format
-func (s *fakeRegistryService) GetByID(id string) (*model.ServerResponse, error) {
-	// Create a timeout context for the database operation
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
+func (s *fakeRegistryService) GetByID(ctx context.Context, id string) (*model.ServerResponse, error) {
 	// Use the database's GetByID method to retrieve the server record
 	serverRecord, err := s.db.GetByID(ctx, id)
 	if err != nil {
@@ -104,24 +97,23 @@ func (s *fakeRegistryService) GetByID(id string) (*model.ServerResponse, error)
 	}
 
 	// Convert ServerRecord to ServerResponse format
-	response := serverRecord.ToServerResponse()
-	return &response, nil
+	result, err := toServerResponses(ctx, s.db, []*model.ServerRecord{serverRecord})
+	if err != nil {
+		return nil, err
+	}
+	return &result[0], nil
 }
 
 // Publish publishes a server with separated extensions
-func (s *fakeRegistryService) Publish(req model.PublishRequest) (*model.ServerResponse, error) {
-	// Create a timeout context for the database operation
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
+func (s *fakeRegistryService) Publish(ctx context.Context, req model.PublishRequest) (*model.ServerResponse, error) {
 	// Validate the request
 	if err := model.ValidatePublisherExtensions(req); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", database.ErrInvalidInput, err)
 	}
 
 	// Validate server name exists
 	if _, err := model.ParseServerName(req.Server); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", database.ErrInvalidInput, err)
 	}
 
 	// Extract publisher extensions from request
@@ -139,25 +131,75 @@ func (s *fakeRegistryService) Publish(req model.PublishRequest) (*model.ServerRe
 }
 
 // Update updates an existing server
-func (s *fakeRegistryService) Update(id string, serverDetail *model.ServerDetail) error {
-	// Create a timeout context for the database operation
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
+func (s *fakeRegistryService) Update(ctx context.Context, id string, serverDetail *model.ServerDetail) error {
 	// Use the database's Update method
 	return s.db.Update(ctx, id, serverDetail)
 }
 
 // Delete removes a server from the registry by ID
-func (s *fakeRegistryService) Delete(id string) error {
-	// Create a timeout context for the database operation
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
+func (s *fakeRegistryService) Delete(ctx context.Context, id string) error {
 	// Use the database's Delete method
 	return s.db.Delete(ctx, id)
 }
 
+// RecordInstall counts an install of a server version
+func (s *fakeRegistryService) RecordInstall(ctx context.Context, id string, clients ...string) (bool, error) {
+	now := time.Now()
+	return s.db.RecordInstall(ctx, id, now, installClientHashes(nil, now, id, clients)...)
+}
+
+// ConsumeAuthNonce marks an authentication challenge nonce as used
+func (s *fakeRegistryService) ConsumeAuthNonce(ctx context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	return s.db.ConsumeNonce(ctx, nonce, expiresAt)
+}
+
+// GitHubOIDCPolicies returns the GitHub OIDC policies of an owner on a GitHub instance
+func (s *fakeRegistryService) GitHubOIDCPolicies(ctx context.Context, host, owner string) ([]model.GitHubOIDCPolicy, error) {
+	return s.db.GitHubOIDCPolicies(ctx, host, owner)
+}
+
+// SetGitHubOIDCPolicies replaces the GitHub OIDC policies of an owner on a GitHub instance
+func (s *fakeRegistryService) SetGitHubOIDCPolicies(ctx context.Context, host, owner string, policies []model.GitHubOIDCPolicy) error {
+	return s.db.SetGitHubOIDCPolicies(ctx, host, owner, policies)
+}
+
+// ListClients retrieves the latest version of every client
+func (s *fakeRegistryService) ListClients(ctx context.Context, filter map[string]any, cursor string, limit int) ([]model.ClientResponse, string, error) {
+	clientRecords, nextCursor, err := s.db.ListClients(ctx, filter, cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result := make([]model.ClientResponse, len(clientRecords))
+	for i, record := range clientRecords {
+		result[i] = record.ToClientResponse()
+	}
+
+	return result, nextCursor, nil
+}
+
+// GetClientByID retrieves a specific client version by its registry metadata ID
+func (s *fakeRegistryService) GetClientByID(ctx context.Context, id string) (*model.ClientResponse, error) {
+	clientRecord, err := s.db.GetClientByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := clientRecord.ToClientResponse()
+	return &response, nil
+}
+
+// PublishClient publishes a client with separated extensions
+func (s *fakeRegistryService) PublishClient(ctx context.Context, req model.PublishClientRequest) (*model.ClientResponse, error) {
+	clientRecord, err := publishClient(ctx, s.db, req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := clientRecord.ToClientResponse()
+	return &response, nil
+}
+
 // Close closes the in-memory database connection
 func (s *fakeRegistryService) Close() error {
 	return s.db.Close()
@@ -2,58 +2,143 @@ package service
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
+// defaultOperationTimeout is used when no database timeout is configured
+const defaultOperationTimeout = 5 * time.Second
+
 // registryServiceImpl implements the RegistryService interface using our Database
 type registryServiceImpl struct {
 	db database.Database
+	// readTimeout and writeTimeout bound each database operation, in addition to any deadline
+	// of the caller's context, so a slow database can't hold requests open indefinitely
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+	installSalt  []byte
 }
 
 // NewRegistryServiceWithDB creates a new registry service with the provided database
 //
 //nolint:ireturn // Factory function intentionally returns interface for dependency injection
-func NewRegistryServiceWithDB(db database.Database) RegistryService {
+func NewRegistryServiceWithDB(db database.Database, cfg *config.Config) RegistryService {
 	return &registryServiceImpl{
-		db: db,
+		db:           db,
+		readTimeout:  operationTimeout(cfg.DatabaseReadTimeout),
+		writeTimeout: operationTimeout(cfg.DatabaseWriteTimeout),
+		installSalt:  installSalt(cfg.InstallHashSalt),
 	}
 }
 
+// installSalt returns the configured install hash salt, or a random one if it is not set. A random
+// salt isn't shared across restarts or replicas, so the registry warns about it at startup.
+func installSalt(configured string) []byte {
+	if configured != "" {
+		return []byte(configured)
+	}
+	salt := make([]byte, 32)
+	_, _ = rand.Read(salt)
+	return salt
+}
+
+// installClientHash identifies a client for install de-duplication without storing the client itself.
+// The day is part of the hash so that the same client cannot be linked across days.
+func installClientHash(salt []byte, day time.Time, id, client string) string {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(day.UTC().Format(time.DateOnly) + "\x00" + id + "\x00" + client))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// installClientHashes hashes each of the keys identifying a client
+func installClientHashes(salt []byte, day time.Time, id string, clients []string) []string {
+	hashes := make([]string, 0, len(clients))
+	for _, client := range clients {
+		hashes = append(hashes, installClientHash(salt, day, id, client))
+	}
+	return hashes
+}
+
+// toServerResponses converts records to the extension wrapper format, including their install statistics
+func toServerResponses(ctx context.Context, db database.Database, records []*model.ServerRecord) ([]model.ServerResponse, error) {
+	ids := make([]string, len(records))
+	for i, record := range records {
+		ids[i] = record.RegistryMetadata.ID
+	}
+
+	installs, err := db.InstallStats(ctx, ids, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.ServerResponse, len(records))
+	for i, record := range records {
+		// Copy the record so that the database's own copy is not modified
+		withInstalls := *record
+		withInstalls.RegistryMetadata.Installs = installs[record.RegistryMetadata.ID]
+		result[i] = withInstalls.ToServerResponse()
+	}
+	return result, nil
+}
+
+// operationTimeout returns the configured timeout, or the default if it is not set
+func operationTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return defaultOperationTimeout
+	}
+	return timeout
+}
+
 // List returns registry entries with cursor-based pagination in extension wrapper format
-func (s *registryServiceImpl) List(cursor string, limit int) ([]model.ServerResponse, string, error) {
-	// Create a timeout context for the database operation
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (s *registryServiceImpl) List(ctx context.Context, filter map[string]any, cursor string, limit int) (_ []model.ServerResponse, _ string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.readTimeout)
 	defer cancel()
 
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.List",
+		attribute.String("cursor", cursor),
+		attribute.Int("limit", limit),
+	)
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	// If limit is not set or negative, use a default limit
 	if limit <= 0 {
 		limit = 30
 	}
 
 	// Use the database's List method with pagination
-	serverRecords, nextCursor, err := s.db.List(ctx, nil, cursor, limit)
+	serverRecords, nextCursor, err := s.db.List(ctx, filter, cursor, limit)
 	if err != nil {
 		return nil, "", err
 	}
 
 	// Convert ServerRecord to ServerResponse format
-	result := make([]model.ServerResponse, len(serverRecords))
-	for i, record := range serverRecords {
-		result[i] = record.ToServerResponse()
+	result, err := toServerResponses(ctx, s.db, serverRecords)
+	if err != nil {
+		return nil, "", err
 	}
 
 	return result, nextCursor, nil
 }
 
 // GetByID retrieves a specific server by its registry metadata ID in extension wrapper format
-func (s *registryServiceImpl) GetByID(id string) (*model.ServerResponse, error) {
-	// Create a timeout context for the database operation
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (s *registryServiceImpl) GetByID(ctx context.Context, id string) (_ *model.ServerResponse, err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.readTimeout)
 	defer cancel()
 
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.GetByID", attribute.String("server.id", id))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	// Use the database's GetByID method to retrieve the server record
 	serverRecord, err := s.db.GetByID(ctx, id)
 	if err != nil {
@@ -61,24 +146,32 @@ func (s *registryServiceImpl) GetByID(id string) (*model.ServerResponse, error)
 	}
 
 	// Convert ServerRecord to ServerResponse format
-	response := serverRecord.ToServerResponse()
-	return &response, nil
+	result, err := toServerResponses(ctx, s.db, []*model.ServerRecord{serverRecord})
+	if err != nil {
+		return nil, err
+	}
+	return &result[0], nil
 }
 
 // Publish publishes a server with separated extensions
-func (s *registryServiceImpl) Publish(req model.PublishRequest) (*model.ServerResponse, error) {
-	// Create a timeout context for the database operation
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (s *registryServiceImpl) Publish(ctx context.Context, req model.PublishRequest) (_ *model.ServerResponse, err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.writeTimeout)
 	defer cancel()
 
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.Publish",
+		attribute.String("server.name", req.Server.Name),
+		attribute.String("server.version", req.Server.VersionDetail.Version),
+	)
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	// Validate the request
 	if err := model.ValidatePublisherExtensions(req); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", database.ErrInvalidInput, err)
 	}
 
 	// Validate server name exists
 	if _, err := model.ParseServerName(req.Server); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", database.ErrInvalidInput, err)
 	}
 
 	// Extract publisher extensions from request
@@ -96,21 +189,151 @@ func (s *registryServiceImpl) Publish(req model.PublishRequest) (*model.ServerRe
 }
 
 // Update updates an existing server
-func (s *registryServiceImpl) Update(id string, serverDetail *model.ServerDetail) error {
-	// Create a timeout context for the database operation
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (s *registryServiceImpl) Update(ctx context.Context, id string, serverDetail *model.ServerDetail) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.writeTimeout)
 	defer cancel()
 
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.Update", attribute.String("server.id", id))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	// Use the database's Update method
 	return s.db.Update(ctx, id, serverDetail)
 }
 
 // Delete removes a server from the registry by ID
-func (s *registryServiceImpl) Delete(id string) error {
-	// Create a timeout context for the database operation
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (s *registryServiceImpl) Delete(ctx context.Context, id string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.writeTimeout)
 	defer cancel()
 
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.Delete", attribute.String("server.id", id))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	// Use the database's Delete method
 	return s.db.Delete(ctx, id)
-}
\ No newline at end of file
+}
+
+// RecordInstall counts an install of a server version, identifying the client only by salted daily hashes
+func (s *registryServiceImpl) RecordInstall(ctx context.Context, id string, clients ...string) (_ bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.writeTimeout)
+	defer cancel()
+
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.RecordInstall", attribute.String("server.id", id))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	now := time.Now()
+	return s.db.RecordInstall(ctx, id, now, installClientHashes(s.installSalt, now, id, clients)...)
+}
+
+// ConsumeAuthNonce marks an authentication challenge nonce as used, in the database so that
+// every replica sees it
+func (s *registryServiceImpl) ConsumeAuthNonce(ctx context.Context, nonce string, expiresAt time.Time) (_ bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.writeTimeout)
+	defer cancel()
+
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.ConsumeAuthNonce")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.db.ConsumeNonce(ctx, nonce, expiresAt)
+}
+
+// GitHubOIDCPolicies returns the GitHub OIDC policies of an owner on a GitHub instance
+func (s *registryServiceImpl) GitHubOIDCPolicies(ctx context.Context, host, owner string) (_ []model.GitHubOIDCPolicy, err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.readTimeout)
+	defer cancel()
+
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.GitHubOIDCPolicies")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.db.GitHubOIDCPolicies(ctx, host, owner)
+}
+
+// SetGitHubOIDCPolicies replaces the GitHub OIDC policies of an owner on a GitHub instance
+func (s *registryServiceImpl) SetGitHubOIDCPolicies(ctx context.Context, host, owner string, policies []model.GitHubOIDCPolicy) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.writeTimeout)
+	defer cancel()
+
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.SetGitHubOIDCPolicies")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return s.db.SetGitHubOIDCPolicies(ctx, host, owner, policies)
+}
+
+// ListClients returns the latest version of every client with cursor-based pagination
+func (s *registryServiceImpl) ListClients(ctx context.Context, filter map[string]any, cursor string, limit int) (_ []model.ClientResponse, _ string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.readTimeout)
+	defer cancel()
+
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.ListClients",
+		attribute.String("cursor", cursor),
+		attribute.Int("limit", limit),
+	)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	if limit <= 0 {
+		limit = 30
+	}
+
+	clientRecords, nextCursor, err := s.db.ListClients(ctx, filter, cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result := make([]model.ClientResponse, len(clientRecords))
+	for i, record := range clientRecords {
+		result[i] = record.ToClientResponse()
+	}
+
+	return result, nextCursor, nil
+}
+
+// GetClientByID retrieves a specific client version by its registry metadata ID
+func (s *registryServiceImpl) GetClientByID(ctx context.Context, id string) (_ *model.ClientResponse, err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.readTimeout)
+	defer cancel()
+
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.GetClientByID", attribute.String("client.id", id))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	clientRecord, err := s.db.GetClientByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := clientRecord.ToClientResponse()
+	return &response, nil
+}
+
+// PublishClient publishes a client with separated extensions
+func (s *registryServiceImpl) PublishClient(ctx context.Context, req model.PublishClientRequest) (_ *model.ClientResponse, err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.writeTimeout)
+	defer cancel()
+
+	ctx, span := telemetry.StartSpan(ctx, "RegistryService.PublishClient",
+		attribute.String("client.name", req.Client.Name),
+		attribute.String("client.version", req.Client.VersionDetail.Version),
+	)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	clientRecord, err := publishClient(ctx, s.db, req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := clientRecord.ToClientResponse()
+	return &response, nil
+}
+
+// publishClient validates a client publish request and stores it
+func publishClient(ctx context.Context, db database.Database, req model.PublishClientRequest) (*model.ClientRecord, error) {
+	if err := model.ValidateClientDetail(req.Client); err != nil {
+		return nil, fmt.Errorf("%w: %w", database.ErrInvalidInput, err)
+	}
+
+	// Publisher extensions follow the same rules as for servers
+	extensions := model.PublishRequest{XPublisher: req.XPublisher}
+	if err := model.ValidatePublisherExtensions(extensions); err != nil {
+		return nil, fmt.Errorf("%w: %w", database.ErrInvalidInput, err)
+	}
+
+	return db.PublishClient(ctx, req.Client, model.ExtractPublisherExtensions(extensions))
+}
@@ -0,0 +1,50 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/model"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// deadlineDB records the deadline of the context passed to List
+type deadlineDB struct {
+	*database.MemoryDB
+	deadline time.Time
+}
+
+func (db *deadlineDB) List(ctx context.Context, filter map[string]any, cursor string, limit int) ([]*model.ServerRecord, string, error) {
+	db.deadline, _ = ctx.Deadline()
+	return db.MemoryDB.List(ctx, filter, cursor, limit)
+}
+
+func TestRegistryServiceUsesCallerContext(t *testing.T) {
+	db := database.NewMemoryDB(map[string]*model.ServerDetail{})
+	registry := service.NewRegistryServiceWithDB(db, &config.Config{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := registry.List(ctx, nil, "", 10)
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = registry.GetByID(ctx, "00000000-0000-0000-0000-000000000000")
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRegistryServiceOperationTimeout(t *testing.T) {
+	db := &deadlineDB{MemoryDB: database.NewMemoryDB(map[string]*model.ServerDetail{})}
+	registry := service.NewRegistryServiceWithDB(db, &config.Config{DatabaseReadTimeout: 200 * time.Millisecond})
+
+	start := time.Now()
+	_, _, err := registry.List(context.Background(), nil, "", 10)
+	require.NoError(t, err)
+	assert.WithinDuration(t, start.Add(200*time.Millisecond), db.deadline, 100*time.Millisecond)
+}
@@ -1,17 +1,39 @@
 package service
 
-import "github.com/modelcontextprotocol/registry/internal/model"
+import (
+	"context"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/model"
+)
 
 // RegistryService defines the interface for registry operations with extension wrapper architecture
 type RegistryService interface {
-	// List retrieves servers with extension wrapper format
-	List(cursor string, limit int) ([]model.ServerResponse, string, error)
+	// List retrieves servers with extension wrapper format, using the database filter keys
+	List(ctx context.Context, filter map[string]any, cursor string, limit int) ([]model.ServerResponse, string, error)
 	// GetByID retrieves a single server by registry metadata ID with extension wrapper format  
-	GetByID(id string) (*model.ServerResponse, error)
+	GetByID(ctx context.Context, id string) (*model.ServerResponse, error)
 	// Publish publishes a server with separated extensions
-	Publish(req model.PublishRequest) (*model.ServerResponse, error)
+	Publish(ctx context.Context, req model.PublishRequest) (*model.ServerResponse, error)
 	// Update updates an existing server
-	Update(id string, serverDetail *model.ServerDetail) error
+	Update(ctx context.Context, id string, serverDetail *model.ServerDetail) error
 	// Delete removes a server from the registry by ID
-	Delete(id string) error
+	Delete(ctx context.Context, id string) error
+	// ListClients retrieves the latest version of every client with extension wrapper format
+	ListClients(ctx context.Context, filter map[string]any, cursor string, limit int) ([]model.ClientResponse, string, error)
+	// GetClientByID retrieves a single client version by registry metadata ID with extension wrapper format
+	GetClientByID(ctx context.Context, id string) (*model.ClientResponse, error)
+	// PublishClient publishes a client with separated extensions
+	PublishClient(ctx context.Context, req model.PublishClientRequest) (*model.ClientResponse, error)
+	// RecordInstall counts an install of a server version by a client, identified by one or more keys such as its
+	// ID and address, at most once per key and day. It returns false if the install was not counted because one of
+	// the keys was already counted today.
+	RecordInstall(ctx context.Context, id string, clients ...string) (bool, error)
+	// ConsumeAuthNonce marks an authentication challenge nonce as used until it expires.
+	// It returns false if the nonce was already used.
+	ConsumeAuthNonce(ctx context.Context, nonce string, expiresAt time.Time) (bool, error)
+	// GitHubOIDCPolicies returns the GitHub OIDC policies of an owner on a GitHub instance, or an empty list if it has none
+	GitHubOIDCPolicies(ctx context.Context, host, owner string) ([]model.GitHubOIDCPolicy, error)
+	// SetGitHubOIDCPolicies replaces the GitHub OIDC policies of an owner on a GitHub instance. An empty list removes them.
+	SetGitHubOIDCPolicies(ctx context.Context, host, owner string, policies []model.GitHubOIDCPolicy) error
 }
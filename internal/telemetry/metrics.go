@@ -32,6 +32,52 @@ type Metrics struct {
 
 	// Up tracks the health of the service
 	Up metric.Int64Gauge
+
+	// MirrorSyncLag tracks the seconds since the last successful sync of an upstream registry
+	MirrorSyncLag metric.Float64Gauge
+
+	// MirrorSyncErrors tracks the number of failed upstream syncs
+	MirrorSyncErrors metric.Int64Counter
+
+	// MirrorServersApplied tracks the upstream servers processed by the mirror, by action
+	MirrorServersApplied metric.Int64Counter
+
+	// Publishes tracks publish attempts by namespace, status and outcome
+	Publishes metric.Int64Counter
+
+	// AuthExchanges tracks token exchanges by auth method, outcome and failure reason
+	AuthExchanges metric.Int64Counter
+
+	// VersionConflicts tracks publishes rejected because the version is not newer than the latest
+	VersionConflicts metric.Int64Counter
+
+	// DBOperationDuration tracks the latency of database operations by operation name
+	DBOperationDuration metric.Float64Histogram
+
+	meter           metric.Meter
+	catalogServers  metric.Int64ObservableGauge
+	catalogVersions metric.Int64ObservableGauge
+	poolAcquired    metric.Int64ObservableGauge
+	poolIdle        metric.Int64ObservableGauge
+	poolMax         metric.Int64ObservableGauge
+	poolWaits       metric.Int64ObservableCounter
+}
+
+// CatalogStats is a snapshot of the size of the registry catalog
+type CatalogStats struct {
+	// Servers is the number of distinct server names
+	Servers int64
+	// Versions is the number of published server versions
+	Versions int64
+}
+
+// DBPoolStats is a snapshot of database connection pool usage
+type DBPoolStats struct {
+	Acquired int64
+	Idle     int64
+	Max      int64
+	// Waits is the cumulative number of acquires that had to wait for a free connection
+	Waits int64
 }
 
 // ShutdownFunc is a delegate that shuts down the OpenTelemetry components.
@@ -73,14 +119,164 @@ func NewMetrics(meter metric.Meter) (*Metrics, error) {
 		return nil, fmt.Errorf("failed to create service up gauge: %w", err)
 	}
 
+	mirrorLag, err := meter.Float64Gauge(
+		Namespace+".mirror.sync.lag",
+		metric.WithDescription("Seconds since the last successful sync of an upstream registry"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mirror sync lag gauge: %w", err)
+	}
+
+	mirrorErrors, err := meter.Int64Counter(
+		Namespace+".mirror.sync.errors",
+		metric.WithDescription("Total number of failed upstream registry syncs"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mirror sync error counter: %w", err)
+	}
+
+	mirrorApplied, err := meter.Int64Counter(
+		Namespace+".mirror.servers",
+		metric.WithDescription("Total number of upstream servers processed by the mirror, by action"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mirror servers counter: %w", err)
+	}
+
+	publishes, err := meter.Int64Counter(
+		Namespace+".publishes",
+		metric.WithDescription("Total number of publish attempts by namespace, status and outcome"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create publish counter: %w", err)
+	}
+
+	authExchanges, err := meter.Int64Counter(
+		Namespace+".auth.exchanges",
+		metric.WithDescription("Total number of token exchanges by auth method, outcome and failure reason"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create auth exchange counter: %w", err)
+	}
+
+	versionConflicts, err := meter.Int64Counter(
+		Namespace+".publish.version_conflicts",
+		metric.WithDescription("Total number of publishes rejected because the version is not newer than the latest"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create version conflict counter: %w", err)
+	}
+
+	dbDuration, err := meter.Float64Histogram(
+		Namespace+".db.operation.duration",
+		metric.WithDescription("Duration of database operations in seconds"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(
+			0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0,
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database duration histogram: %w", err)
+	}
+
+	catalogServers, err := meter.Int64ObservableGauge(
+		Namespace+".catalog.servers",
+		metric.WithDescription("Number of distinct servers in the registry"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create catalog servers gauge: %w", err)
+	}
+
+	catalogVersions, err := meter.Int64ObservableGauge(
+		Namespace+".catalog.versions",
+		metric.WithDescription("Number of published server versions in the registry"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create catalog versions gauge: %w", err)
+	}
+
+	poolAcquired, err := meter.Int64ObservableGauge(
+		Namespace+".db.pool.acquired",
+		metric.WithDescription("Number of database connections currently in use"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pool acquired gauge: %w", err)
+	}
+
+	poolIdle, err := meter.Int64ObservableGauge(
+		Namespace+".db.pool.idle",
+		metric.WithDescription("Number of idle database connections"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pool idle gauge: %w", err)
+	}
+
+	poolMax, err := meter.Int64ObservableGauge(
+		Namespace+".db.pool.max",
+		metric.WithDescription("Maximum size of the database connection pool"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pool max gauge: %w", err)
+	}
+
+	poolWaits, err := meter.Int64ObservableCounter(
+		Namespace+".db.pool.waits",
+		metric.WithDescription("Total number of connection acquires that had to wait for a free connection"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pool waits counter: %w", err)
+	}
+
 	return &Metrics{
-		Requests:        req,
-		RequestDuration: reqDuration,
-		ErrorCount:      errCount,
-		Up:              up,
+		Requests:             req,
+		RequestDuration:      reqDuration,
+		ErrorCount:           errCount,
+		Up:                   up,
+		MirrorSyncLag:        mirrorLag,
+		MirrorSyncErrors:     mirrorErrors,
+		MirrorServersApplied: mirrorApplied,
+		Publishes:            publishes,
+		AuthExchanges:        authExchanges,
+		VersionConflicts:     versionConflicts,
+		DBOperationDuration:  dbDuration,
+		meter:                meter,
+		catalogServers:       catalogServers,
+		catalogVersions:      catalogVersions,
+		poolAcquired:         poolAcquired,
+		poolIdle:             poolIdle,
+		poolMax:              poolMax,
+		poolWaits:            poolWaits,
 	}, nil
 }
 
+// ObserveCatalog reports the catalog size gauges from stats on every metrics collection
+func (m *Metrics) ObserveCatalog(stats func(ctx context.Context) (*CatalogStats, error)) error {
+	_, err := m.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		catalog, err := stats(ctx)
+		if err != nil {
+			return err
+		}
+		o.ObserveInt64(m.catalogServers, catalog.Servers)
+		o.ObserveInt64(m.catalogVersions, catalog.Versions)
+		return nil
+	}, m.catalogServers, m.catalogVersions)
+	return err
+}
+
+// ObserveDBPool reports the connection pool gauges from stats on every metrics collection
+func (m *Metrics) ObserveDBPool(stats func() DBPoolStats) error {
+	_, err := m.meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		pool := stats()
+		o.ObserveInt64(m.poolAcquired, pool.Acquired)
+		o.ObserveInt64(m.poolIdle, pool.Idle)
+		o.ObserveInt64(m.poolMax, pool.Max)
+		o.ObserveInt64(m.poolWaits, pool.Waits)
+		return nil
+	}, m.poolAcquired, m.poolIdle, m.poolMax, m.poolWaits)
+	return err
+}
+
 func NewPrometheusMeterProvider(res *resource.Resource, exp *prometheus.Exporter) (*sdkmetric.MeterProvider, error) {
 	if exp == nil {
 		return nil, errors.New("exporter cannot be nil")
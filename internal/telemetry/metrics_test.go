@@ -1,14 +1,17 @@
 package telemetry_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
@@ -82,3 +85,42 @@ func TestNewPrometheusMeterProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestMetricsObservers(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")
+
+	metrics, err := telemetry.NewMetrics(meter)
+	require.NoError(t, err)
+
+	require.NoError(t, metrics.ObserveCatalog(func(_ context.Context) (*telemetry.CatalogStats, error) {
+		return &telemetry.CatalogStats{Servers: 3, Versions: 7}, nil
+	}))
+	require.NoError(t, metrics.ObserveDBPool(func() telemetry.DBPoolStats {
+		return telemetry.DBPoolStats{Acquired: 2, Idle: 1, Max: 4, Waits: 5}
+	}))
+	metrics.VersionConflicts.Add(context.Background(), 1)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	values := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Gauge[int64]:
+				values[m.Name] = data.DataPoints[0].Value
+			case metricdata.Sum[int64]:
+				values[m.Name] = data.DataPoints[0].Value
+			}
+		}
+	}
+
+	assert.Equal(t, int64(3), values["mcp_registry.catalog.servers"])
+	assert.Equal(t, int64(7), values["mcp_registry.catalog.versions"])
+	assert.Equal(t, int64(2), values["mcp_registry.db.pool.acquired"])
+	assert.Equal(t, int64(1), values["mcp_registry.db.pool.idle"])
+	assert.Equal(t, int64(4), values["mcp_registry.db.pool.max"])
+	assert.Equal(t, int64(5), values["mcp_registry.db.pool.waits"])
+	assert.Equal(t, int64(1), values["mcp_registry.publish.version_conflicts"])
+}
@@ -0,0 +1,89 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// TracerName is the instrumentation scope used for all registry spans
+const TracerName = "github.com/modelcontextprotocol/registry"
+
+// InitTracing installs the global tracer provider and W3C trace context propagator.
+// Spans are always created so trace IDs can be surfaced in responses and logs,
+// but they are only exported when an OTLP endpoint is configured.
+func InitTracing(ctx context.Context, cfg *config.Config) (ShutdownFunc, error) {
+	// Initialized the returned shutdownFunc to no-op.
+	shutdown := func(_ context.Context) error { return nil }
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(Namespace),
+			semconv.ServiceVersion(cfg.Version),
+		),
+	)
+	if err != nil {
+		return shutdown, fmt.Errorf("failed to create resource: %w", err)
+	}
+
+	res, err = resource.Merge(resource.Default(), res)
+	if err != nil {
+		return shutdown, fmt.Errorf("failed to merge resources: %w", err)
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TraceSampleRatio))),
+	}
+
+	if cfg.OTLPEndpoint != "" {
+		exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+		if err != nil {
+			return shutdown, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	tp := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return tp.Shutdown, nil
+}
+
+// StartSpan starts a new span with the registry tracer
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records err on the span, if any, and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceID returns the trace ID of the span in the context, or an empty string if there is none
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
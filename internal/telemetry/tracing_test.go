@@ -0,0 +1,35 @@
+package telemetry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+func TestInitTracing(t *testing.T) {
+	shutdown, err := telemetry.InitTracing(context.Background(), &config.Config{
+		Version:          "test",
+		TraceSampleRatio: 1,
+	})
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, shutdown(context.Background())) }()
+
+	assert.Empty(t, telemetry.TraceID(context.Background()))
+
+	ctx, span := telemetry.StartSpan(context.Background(), "test-span")
+	traceID := telemetry.TraceID(ctx)
+	assert.Len(t, traceID, 32)
+
+	// Child spans share the parent's trace ID
+	childCtx, child := telemetry.StartSpan(ctx, "child-span")
+	assert.Equal(t, traceID, telemetry.TraceID(childCtx))
+
+	telemetry.EndSpan(child, errors.New("boom"))
+	telemetry.EndSpan(span, nil)
+}
@@ -79,17 +79,11 @@ func run() error {
 
 	log.Printf("Found %d examples in %q\n", len(examples), examplesPath)
 
-	// Get anonymous token from the none endpoint
-	token, err := getAnonymousToken()
-	if err != nil {
+	// Check anonymous tokens are available; the publisher gets its own with --auth-method none
+	if _, err := getAnonymousToken(); err != nil {
 		log.Fatalf("failed to get anonymous token: %v", err)
 	}
 
-	if err := os.WriteFile(".mcpregistry_registry_token", []byte(token), 0600); err != nil {
-		log.Fatalf("failed to write token: %v", err)
-	}
-	defer os.Remove(".mcpregistry_registry_token")
-
 	return publish(examples)
 }
 
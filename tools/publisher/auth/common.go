@@ -3,20 +3,22 @@ package auth
 import (
 	"bytes"
 	"context"
-	"crypto/ed25519"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/auth/keyalg"
 )
 
 // CryptoProvider provides common functionality for DNS and HTTP authentication
 type CryptoProvider struct {
 	registryURL string
 	domain      string
-	hexSeed     string
+	signer      Signer
 	authMethod  string
 }
 
@@ -26,31 +28,21 @@ func (c *CryptoProvider) GetToken(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("%s domain is required", c.authMethod)
 	}
 
-	if c.hexSeed == "" {
-		return "", fmt.Errorf("%s private key (hex seed) is required", c.authMethod)
+	if c.signer == nil {
+		return "", fmt.Errorf("%s private key is required", c.authMethod)
 	}
 
-	// Decode hex seed to private key
-	seedBytes, err := hex.DecodeString(c.hexSeed)
-	if err != nil {
-		return "", fmt.Errorf("invalid hex seed format: %w", err)
+	// Sign a challenge from the registry, or a timestamp if the registry predates challenges
+	payload, err := c.signChallenge(ctx)
+	if errors.Is(err, errChallengesUnsupported) {
+		payload, err = c.signTimestamp(ctx)
 	}
-
-	if len(seedBytes) != ed25519.SeedSize {
-		return "", fmt.Errorf("invalid seed length: expected %d bytes, got %d", ed25519.SeedSize, len(seedBytes))
+	if err != nil {
+		return "", err
 	}
 
-	privateKey := ed25519.NewKeyFromSeed(seedBytes)
-
-	// Generate current timestamp
-	timestamp := time.Now().UTC().Format(time.RFC3339)
-
-	// Sign the timestamp
-	signature := ed25519.Sign(privateKey, []byte(timestamp))
-	signedTimestamp := hex.EncodeToString(signature)
-
 	// Exchange signature for registry token
-	registryToken, err := c.exchangeTokenForRegistry(ctx, c.domain, timestamp, signedTimestamp)
+	registryToken, err := c.exchangeTokenForRegistry(ctx, payload)
 	if err != nil {
 		return "", fmt.Errorf("failed to exchange %s signature: %w", c.authMethod, err)
 	}
@@ -68,17 +60,84 @@ func (c *CryptoProvider) Login(_ context.Context) error {
 	return nil
 }
 
-// exchangeTokenForRegistry exchanges signature for a registry JWT token
-func (c *CryptoProvider) exchangeTokenForRegistry(ctx context.Context, domain, timestamp, signedTimestamp string) (string, error) {
+// errChallengesUnsupported is returned by signChallenge when the registry has no challenge endpoint
+var errChallengesUnsupported = errors.New("registry does not issue authentication challenges")
+
+// signChallenge requests a nonce for the domain from the registry and signs it
+func (c *CryptoProvider) signChallenge(ctx context.Context) (map[string]string, error) {
 	if c.registryURL == "" {
-		return "", fmt.Errorf("registry URL is required for token exchange")
+		return nil, fmt.Errorf("registry URL is required for token exchange")
+	}
+
+	jsonData, err := json.Marshal(map[string]string{"domain": c.domain})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.registryURL+"/v0/auth/challenge", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request challenge: %w", err)
 	}
+	defer resp.Body.Close()
 
-	// Prepare the request body
-	payload := map[string]string{
-		"domain":           domain,
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errChallengesUnsupported
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("challenge request failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var challenge struct {
+		Nonce string `json:"nonce"`
+	}
+	if err := json.Unmarshal(body, &challenge); err != nil || challenge.Nonce == "" {
+		return nil, fmt.Errorf("invalid challenge response: %s", body)
+	}
+
+	signature, err := c.signer.Sign(ctx, keyalg.ChallengeMessage(c.domain, challenge.Nonce))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign challenge: %w", err)
+	}
+
+	return map[string]string{
+		"domain":    c.domain,
+		"nonce":     challenge.Nonce,
+		"signature": hex.EncodeToString(signature),
+	}, nil
+}
+
+// signTimestamp signs the current time, for registries that don't issue challenges
+func (c *CryptoProvider) signTimestamp(ctx context.Context) (map[string]string, error) {
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+
+	signature, err := c.signer.Sign(ctx, []byte(timestamp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign timestamp: %w", err)
+	}
+
+	return map[string]string{
+		"domain":           c.domain,
 		"timestamp":        timestamp,
-		"signed_timestamp": signedTimestamp,
+		"signed_timestamp": hex.EncodeToString(signature),
+	}, nil
+}
+
+// exchangeTokenForRegistry exchanges a signed challenge or timestamp for a registry JWT token
+func (c *CryptoProvider) exchangeTokenForRegistry(ctx context.Context, payload map[string]string) (string, error) {
+	if c.registryURL == "" {
+		return "", fmt.Errorf("registry URL is required for token exchange")
 	}
 
 	jsonData, err := json.Marshal(payload)
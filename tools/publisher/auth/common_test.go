@@ -0,0 +1,78 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/auth/keyalg"
+	"github.com/modelcontextprotocol/registry/tools/publisher/auth"
+)
+
+// fakeRegistry serves the DNS token exchange, recording the body it receives
+func fakeRegistry(t *testing.T, challenges bool, received *map[string]string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	if challenges {
+		mux.HandleFunc("POST /v0/auth/challenge", func(w http.ResponseWriter, _ *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"nonce": "abc123"})
+		})
+	}
+	mux.HandleFunc("POST /v0/auth/dns", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(received)
+		_ = json.NewEncoder(w).Encode(map[string]any{"registry_token": "token", "expires_at": 0})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCryptoProviderGetToken(t *testing.T) {
+	seed, err := auth.GenerateSeed()
+	require.NoError(t, err)
+	privateKey, err := auth.ParseSeed(seed)
+	require.NoError(t, err)
+	publicKey, err := keyalg.ParseRecord(mustFormatRecord(t, privateKey.Public()))
+	require.NoError(t, err)
+
+	t.Run("signs the registry's challenge", func(t *testing.T) {
+		var received map[string]string
+		server := fakeRegistry(t, true, &received)
+
+		token, err := auth.NewDNSProvider(server.URL, "Example.com", auth.NewKeySigner(privateKey)).GetToken(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "token", token)
+
+		assert.Equal(t, "abc123", received["nonce"])
+		assert.Empty(t, received["timestamp"])
+		signature, err := hex.DecodeString(received["signature"])
+		require.NoError(t, err)
+		assert.NoError(t, publicKey.Verify(keyalg.ChallengeMessage("example.com", "abc123"), signature))
+	})
+
+	t.Run("falls back to a timestamp for older registries", func(t *testing.T) {
+		var received map[string]string
+		server := fakeRegistry(t, false, &received)
+
+		_, err := auth.NewDNSProvider(server.URL, "example.com", auth.NewKeySigner(privateKey)).GetToken(context.Background())
+		require.NoError(t, err)
+
+		assert.Empty(t, received["nonce"])
+		signature, err := hex.DecodeString(received["signed_timestamp"])
+		require.NoError(t, err)
+		assert.NoError(t, publicKey.Verify([]byte(received["timestamp"]), signature))
+	})
+}
+
+func mustFormatRecord(t *testing.T, publicKey any) string {
+	t.Helper()
+	record, err := keyalg.FormatRecord(publicKey)
+	require.NoError(t, err)
+	return record
+}
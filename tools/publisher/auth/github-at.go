@@ -8,18 +8,39 @@ import (
 	"io"
 	"log"
 	"net/http"
-	"os"
+	"strings"
 	"time"
 )
 
 const (
-	gitHubTokenFilePath   = ".mcpregistry_github_token"   // #nosec:G101
-	registryTokenFilePath = ".mcpregistry_registry_token" // #nosec:G101
-	// GitHub OAuth URLs
-	GitHubDeviceCodeURL  = "https://github.com/login/device/code"        // #nosec:G101
-	GitHubAccessTokenURL = "https://github.com/login/oauth/access_token" // #nosec:G101
+	// MethodGitHubAT is the auth method the github.com token is stored under in the CredentialStore
+	MethodGitHubAT = "github-at"
+	// GitHubHost is the host of public GitHub
+	GitHubHost = "github.com"
+	// GitHubOAuthURL is the base URL of public GitHub's OAuth endpoints
+	GitHubOAuthURL = "https://github.com"
+	// GitHub OAuth device flow paths, relative to the OAuth URL of the GitHub instance
+	GitHubDeviceCodePath  = "/login/device/code"        // #nosec:G101
+	GitHubAccessTokenPath = "/login/oauth/access_token" // #nosec:G101
 )
 
+// GitHubInstance is the GitHub host to log in to. OAuthURL and ClientID are looked up from the
+// registry when empty.
+type GitHubInstance struct {
+	Host     string
+	OAuthURL string
+	ClientID string
+}
+
+// GitHubATMethod is the auth method the token of a GitHub host is stored under in the
+// CredentialStore, so logins to different hosts are kept apart
+func GitHubATMethod(host string) string {
+	if host == "" || host == GitHubHost {
+		return MethodGitHubAT
+	}
+	return MethodGitHubAT + "@" + host
+}
+
 // DeviceCodeResponse represents the response from GitHub's device code endpoint
 type DeviceCodeResponse struct {
 	DeviceCode      string `json:"device_code"`
@@ -43,57 +64,67 @@ type RegistryTokenResponse struct {
 	ExpiresAt     int64  `json:"expires_at"`
 }
 
-// StoredRegistryToken represents the registry token with expiration stored locally
-type StoredRegistryToken struct {
-	Token     string `json:"token"`
-	ExpiresAt int64  `json:"expires_at"`
-}
-
 // GitHubATProvider implements the Provider interface using GitHub's device flow
 type GitHubATProvider struct {
 	clientID    string
+	host        string
+	oauthURL    string
 	forceLogin  bool
 	registryURL string
+	store       *CredentialStore
 }
 
 // ServerHealthResponse represents the response from the health endpoint
 type ServerHealthResponse struct {
-	Status         string `json:"status"`
-	GitHubClientID string `json:"github_client_id"`
+	Status           string `json:"status"`
+	GitHubClientID   string `json:"github_client_id"`
+	GitHubEnterprise []struct {
+		Host     string `json:"host"`
+		OAuthURL string `json:"oauth_url"`
+		ClientID string `json:"client_id"`
+	} `json:"github_enterprise"`
 }
 
-// NewGitHubATProvider creates a new GitHub OAuth provider
+// NewGitHubATProvider creates a new GitHub OAuth provider for the GitHub instance that keeps its
+// GitHub token in store
 //nolint:ireturn // Factory function returns interface by design
-func NewGitHubATProvider(forceLogin bool, registryURL string) Provider {
+func NewGitHubATProvider(forceLogin bool, registryURL string, instance GitHubInstance, store *CredentialStore) Provider {
+	if instance.Host == "" {
+		instance.Host = GitHubHost
+	}
+	if instance.Host == GitHubHost && instance.OAuthURL == "" {
+		instance.OAuthURL = GitHubOAuthURL
+	}
 	return &GitHubATProvider{
+		clientID:    instance.ClientID,
+		host:        instance.Host,
+		oauthURL:    strings.TrimSuffix(instance.OAuthURL, "/"),
 		forceLogin:  forceLogin,
 		registryURL: registryURL,
+		store:       store,
 	}
 }
 
-// GetToken retrieves the registry JWT token (exchanges GitHub token if needed)
+// GetToken exchanges the stored GitHub token for a new registry JWT token
 func (g *GitHubATProvider) GetToken(ctx context.Context) (string, error) {
-	// Check if we have a valid registry token
-	registryToken, err := readRegistryToken()
-	if err == nil && registryToken != "" {
-		return registryToken, nil
-	}
-
-	// If no valid registry token, exchange GitHub token for registry token
-	githubToken, err := readToken()
+	credential, _, err := g.store.Get(g.registryURL, GitHubATMethod(g.host))
 	if err != nil {
 		return "", fmt.Errorf("failed to read GitHub token: %w", err)
 	}
+	if credential.GitHubToken == "" {
+		return "", fmt.Errorf("not logged in to %s; run 'mcp-publisher login'", g.registryURL)
+	}
 
 	// Exchange GitHub token for registry token
-	registryToken, expiresAt, err := g.exchangeTokenForRegistry(ctx, githubToken)
+	registryToken, expiresAt, err := g.exchangeTokenForRegistry(ctx, credential.GitHubToken)
 	if err != nil {
-		return "", fmt.Errorf("failed to exchange token: %w", err)
+		return "", fmt.Errorf("failed to exchange token (run 'mcp-publisher login' if the GitHub token was revoked): %w", err)
 	}
 
 	// Store the registry token
-	err = saveRegistryToken(registryToken, expiresAt)
-	if err != nil {
+	credential.RegistryToken = registryToken
+	credential.ExpiresAt = expiresAt
+	if err := g.store.Put(g.registryURL, GitHubATMethod(g.host), credential); err != nil {
 		return "", fmt.Errorf("failed to save registry token: %w", err)
 	}
 
@@ -106,32 +137,25 @@ func (g *GitHubATProvider) NeedsLogin() bool {
 		return true
 	}
 
-	// Check if GitHub token exists
-	_, statErr := os.Stat(gitHubTokenFilePath)
-	if os.IsNotExist(statErr) {
-		return true
-	}
-
-	// Check if valid registry token exists
-	_, err := readRegistryToken()
-	if err != nil {
-		// No valid registry token, but we have GitHub token
-		// We don't need to login, just exchange tokens
-		return false
-	}
-
-	return false
+	// A stored GitHub token can be exchanged for a registry token without logging in again
+	credential, _, err := g.store.Get(g.registryURL, GitHubATMethod(g.host))
+	return err != nil || credential.GitHubToken == ""
 }
 
 // Login performs the GitHub device flow authentication
 func (g *GitHubATProvider) Login(ctx context.Context) error {
 	// If clientID is not set, try to retrieve it from the server's health endpoint
-	if g.clientID == "" {
-		clientID, err := getClientID(ctx, g.registryURL)
+	if g.clientID == "" || g.oauthURL == "" {
+		clientID, oauthURL, err := getGitHubInstance(ctx, g.registryURL, g.host)
 		if err != nil {
 			return fmt.Errorf("error getting GitHub Client ID: %w", err)
 		}
-		g.clientID = clientID
+		if g.clientID == "" {
+			g.clientID = clientID
+		}
+		if g.oauthURL == "" {
+			g.oauthURL = oauthURL
+		}
 	}
 
 	// Device flow login logic using GitHub's device flow
@@ -154,8 +178,8 @@ func (g *GitHubATProvider) Login(ctx context.Context) error {
 		return fmt.Errorf("error polling for token: %w", err)
 	}
 
-	// Store the token locally
-	err = saveToken(token)
+	// Store the token, replacing any registry token issued for a previous login
+	err = g.store.Put(g.registryURL, GitHubATMethod(g.host), Credential{GitHubToken: token})
 	if err != nil {
 		return fmt.Errorf("error saving token: %w", err)
 	}
@@ -185,7 +209,7 @@ func (g *GitHubATProvider) requestDeviceCode(ctx context.Context) (string, strin
 		return "", "", "", err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, GitHubDeviceCodeURL, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.oauthURL+GitHubDeviceCodePath, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", "", "", err
 	}
@@ -240,7 +264,7 @@ func (g *GitHubATProvider) pollForToken(ctx context.Context, deviceCode string)
 	deadline := time.Now().Add(time.Duration(expiresIn) * time.Second)
 
 	for time.Now().Before(deadline) {
-		req, err := http.NewRequestWithContext(ctx, http.MethodPost, GitHubAccessTokenURL, bytes.NewBuffer(jsonData))
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.oauthURL+GitHubAccessTokenPath, bytes.NewBuffer(jsonData))
 		if err != nil {
 			return "", err
 		}
@@ -286,62 +310,65 @@ func (g *GitHubATProvider) pollForToken(ctx context.Context, deviceCode string)
 	return "", fmt.Errorf("device code authorization timed out")
 }
 
-// saveToken saves the GitHub access token to a local file
-func saveToken(token string) error {
-	return os.WriteFile(gitHubTokenFilePath, []byte(token), 0600)
-}
-
-// readToken reads the GitHub access token from a local file
-func readToken() (string, error) {
-	tokenData, err := os.ReadFile(gitHubTokenFilePath)
+// getGitHubInstance looks up the client ID and OAuth URL the registry uses for a GitHub host
+func getGitHubInstance(ctx context.Context, registryURL, host string) (string, string, error) {
+	if registryURL == "" {
+		return "", "", fmt.Errorf("registry URL is required to get GitHub Client ID")
+	}
+	health, err := getHealth(ctx, registryURL)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
-	return string(tokenData), nil
-}
 
-func getClientID(ctx context.Context, registryURL string) (string, error) {
-	// This function should retrieve the GitHub Client ID from the registry URL
-	// For now, we will return a placeholder value
-	// In a real implementation, this would likely involve querying the registry or configuration
-	if registryURL == "" {
-		return "", fmt.Errorf("registry URL is required to get GitHub Client ID")
+	if host == GitHubHost {
+		if health.GitHubClientID == "" {
+			log.Println("GitHub Client ID is not set in the server's health response.")
+			return "", "", fmt.Errorf("GitHub Client ID is not set in the server's health response")
+		}
+		return health.GitHubClientID, GitHubOAuthURL, nil
 	}
+
+	for _, instance := range health.GitHubEnterprise {
+		if strings.EqualFold(instance.Host, host) {
+			if instance.ClientID == "" {
+				return "", "", fmt.Errorf("the registry has no GitHub Client ID for %s; pass --github-client-id", host)
+			}
+			return instance.ClientID, instance.OAuthURL, nil
+		}
+	}
+	return "", "", fmt.Errorf("the registry does not trust the GitHub instance %s", host)
+}
+
+// getHealth fetches the server's health endpoint, which describes how to log in
+func getHealth(ctx context.Context, registryURL string) (*ServerHealthResponse, error) {
 	// get the clientID from the server's health endpoint
 	healthURL := registryURL + "/v0/health"
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthURL, nil)
 	if err != nil {
 		log.Printf("Error creating request: %s\n", err.Error())
-		return "", err
+		return nil, err
 	}
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
 		log.Printf("Error fetching health endpoint: %s\n", err.Error())
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		log.Printf("Health endpoint returned status %d: %s\n", resp.StatusCode, body)
-		return "", fmt.Errorf("health endpoint returned status %d: %s", resp.StatusCode, body)
+		return nil, fmt.Errorf("health endpoint returned status %d: %s", resp.StatusCode, body)
 	}
 
 	var healthResponse ServerHealthResponse
 	err = json.NewDecoder(resp.Body).Decode(&healthResponse)
 	if err != nil {
 		log.Printf("Error decoding health response: %s\n", err.Error())
-		return "", err
+		return nil, err
 	}
-	if healthResponse.GitHubClientID == "" {
-		log.Println("GitHub Client ID is not set in the server's health response.")
-		return "", fmt.Errorf("GitHub Client ID is not set in the server's health response")
-	}
-
-	githubClientID := healthResponse.GitHubClientID
-
-	return githubClientID, nil
+	return &healthResponse, nil
 }
 
 // exchangeTokenForRegistry exchanges a GitHub token for a registry JWT token
@@ -354,6 +381,9 @@ func (g *GitHubATProvider) exchangeTokenForRegistry(ctx context.Context, githubT
 	payload := map[string]string{
 		"github_token": githubToken,
 	}
+	if g.host != GitHubHost {
+		payload["github_host"] = g.host
+	}
 
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
@@ -393,41 +423,3 @@ func (g *GitHubATProvider) exchangeTokenForRegistry(ctx context.Context, githubT
 
 	return tokenResp.RegistryToken, tokenResp.ExpiresAt, nil
 }
-
-// saveRegistryToken saves the registry JWT token to a local file with expiration
-func saveRegistryToken(token string, expiresAt int64) error {
-	storedToken := StoredRegistryToken{
-		Token:     token,
-		ExpiresAt: expiresAt,
-	}
-
-	data, err := json.Marshal(storedToken)
-	if err != nil {
-		return fmt.Errorf("failed to marshal token: %w", err)
-	}
-
-	return os.WriteFile(registryTokenFilePath, data, 0600)
-}
-
-// readRegistryToken reads the registry JWT token from a local file
-func readRegistryToken() (string, error) {
-	data, err := os.ReadFile(registryTokenFilePath)
-	if err != nil {
-		return "", err
-	}
-
-	var storedToken StoredRegistryToken
-	err = json.Unmarshal(data, &storedToken)
-	if err != nil {
-		return "", fmt.Errorf("failed to unmarshal token: %w", err)
-	}
-
-	// Check if token has expired
-	if time.Now().Unix() >= storedToken.ExpiresAt {
-		// Token has expired, remove the file
-		os.Remove(registryTokenFilePath)
-		return "", fmt.Errorf("registry token has expired")
-	}
-
-	return storedToken.Token, nil
-}
@@ -0,0 +1,77 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/tools/publisher/auth"
+)
+
+func TestGitHubATProviderEnterprise(t *testing.T) {
+	// The GitHub Enterprise Server instance, which completes the device flow immediately
+	var clientIDs []string
+	github := http.NewServeMux()
+	github.HandleFunc("POST /login/device/code", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		clientIDs = append(clientIDs, body["client_id"])
+		_ = json.NewEncoder(w).Encode(auth.DeviceCodeResponse{DeviceCode: "device", UserCode: "ABCD-1234"})
+	})
+	github.HandleFunc("POST /login/oauth/access_token", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(auth.AccessTokenResponse{AccessToken: "enterprise-token"})
+	})
+	githubServer := httptest.NewServer(github)
+	defer githubServer.Close()
+
+	// The registry, which tells the publisher how to log in to the instance
+	var exchanged map[string]string
+	registry := http.NewServeMux()
+	registry.HandleFunc("GET /v0/health", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status":           "ok",
+			"github_client_id": "public-client",
+			"github_enterprise": []map[string]string{
+				{"host": "github.example.com", "oauth_url": githubServer.URL, "client_id": "enterprise-client"},
+			},
+		})
+	})
+	registry.HandleFunc("POST /v0/auth/github-at", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&exchanged)
+		_ = json.NewEncoder(w).Encode(auth.RegistryTokenResponse{RegistryToken: "registry-token"})
+	})
+	registryServer := httptest.NewServer(registry)
+	defer registryServer.Close()
+
+	store := auth.NewCredentialStore(filepath.Join(t.TempDir(), "credentials.json"))
+	provider := auth.NewGitHubATProvider(false, registryServer.URL, auth.GitHubInstance{Host: "github.example.com"}, store)
+	require.True(t, provider.NeedsLogin())
+	require.NoError(t, provider.Login(context.Background()))
+	assert.Equal(t, []string{"enterprise-client"}, clientIDs)
+
+	// The token is stored apart from any github.com login, and exchanged for its host
+	credential, ok, err := store.Get(registryServer.URL, "github-at@github.example.com")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "enterprise-token", credential.GitHubToken)
+	_, ok, err = store.Get(registryServer.URL, auth.MethodGitHubAT)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	token, err := provider.GetToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "registry-token", token)
+	assert.Equal(t, map[string]string{"github_token": "enterprise-token", "github_host": "github.example.com"}, exchanged)
+
+	// Hosts the registry doesn't trust fail before starting the device flow
+	untrusted := auth.NewGitHubATProvider(false, registryServer.URL, auth.GitHubInstance{Host: "github.evil.com"}, store)
+	err = untrusted.Login(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not trust")
+}
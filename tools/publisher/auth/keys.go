@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/modelcontextprotocol/registry/internal/auth/keyalg"
+)
+
+// GenerateSeed creates a new Ed25519 private key, returned as a 64-character hex seed
+func GenerateSeed() (string, error) {
+	seed := make([]byte, ed25519.SeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	return hex.EncodeToString(seed), nil
+}
+
+// ParseSeed decodes a hex-encoded Ed25519 seed into a private key
+func ParseSeed(hexSeed string) (ed25519.PrivateKey, error) {
+	seedBytes, err := hex.DecodeString(strings.TrimSpace(hexSeed))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex seed format: %w", err)
+	}
+
+	if len(seedBytes) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid seed length: expected %d bytes, got %d", ed25519.SeedSize, len(seedBytes))
+	}
+
+	return ed25519.NewKeyFromSeed(seedBytes), nil
+}
+
+// GenerateKey creates a new private key for a key algorithm, and returns it along with the
+// contents of its key file: a hex seed for ed25519, as --dns-private-key has always taken,
+// and PKCS#8 PEM for the other algorithms
+//
+//nolint:ireturn // crypto.Signer covers every key algorithm
+func GenerateKey(algorithm string) (crypto.Signer, []byte, error) {
+	var privateKey crypto.Signer
+	var err error
+	switch algorithm {
+	case "ed25519":
+		seed, err := GenerateSeed()
+		if err != nil {
+			return nil, nil, err
+		}
+		privateKey, err := ParseSeed(seed)
+		if err != nil {
+			return nil, nil, err
+		}
+		return privateKey, []byte(seed + "\n"), nil
+	case "ecdsap256":
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "rsa":
+		privateKey, err = rsa.GenerateKey(rand.Reader, 3072)
+	default:
+		return nil, nil, fmt.Errorf("unsupported key algorithm %q: supported algorithms are %s", algorithm, strings.Join(keyalg.Names(), ", "))
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+	return privateKey, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// ParsePrivateKey reads a private key for one of the registry's key algorithms, in any of
+// the formats the publisher accepts: a hex Ed25519 seed as written by keygen, PEM (PKCS#8,
+// SEC1 EC or PKCS#1 RSA) as written by openssl, or an unencrypted OpenSSH key as written
+// by ssh-keygen
+//
+//nolint:ireturn // crypto.Signer covers every key algorithm
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		privateKey, err := ParseSeed(string(data))
+		if err != nil {
+			return nil, err
+		}
+		return privateKey, nil
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "OPENSSH PRIVATE KEY":
+		key, err = ssh.ParseRawPrivateKey(data)
+		var passphraseErr *ssh.PassphraseMissingError
+		if errors.As(err, &passphraseErr) {
+			return nil, errors.New("OpenSSH private key is encrypted; decrypt it, or use a signer command")
+		}
+		// OpenSSH Ed25519 keys are returned by pointer
+		if edKey, ok := key.(*ed25519.PrivateKey); ok {
+			key = *edKey
+		}
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q: expected PRIVATE KEY, EC PRIVATE KEY, RSA PRIVATE KEY or OPENSSH PRIVATE KEY", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", strings.ToLower(block.Type), err)
+	}
+
+	privateKey, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	if _, err := keyalg.ForPublicKey(privateKey.Public()); err != nil {
+		return nil, err
+	}
+	return privateKey, nil
+}
+
+// LoadPrivateKeyFile reads a private key from a file, in any format ParsePrivateKey accepts
+//
+//nolint:ireturn // crypto.Signer covers every key algorithm
+func LoadPrivateKeyFile(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key file: %w", err)
+	}
+	privateKey, err := ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return privateKey, nil
+}
@@ -0,0 +1,134 @@
+package auth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/modelcontextprotocol/registry/tools/publisher/auth"
+)
+
+func TestParsePrivateKey(t *testing.T) {
+	seed, err := auth.GenerateSeed()
+	require.NoError(t, err)
+	expected, err := auth.ParseSeed(seed)
+	require.NoError(t, err)
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(expected)
+	require.NoError(t, err)
+	openSSH, err := ssh.MarshalPrivateKey(expected, "")
+	require.NoError(t, err)
+	encrypted, err := ssh.MarshalPrivateKeyWithPassphrase(expected, "", []byte("secret"))
+	require.NoError(t, err)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	sec1, err := x509.MarshalECPrivateKey(ecdsaKey)
+	require.NoError(t, err)
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	p384, err := x509.MarshalPKCS8PrivateKey(p384Key)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected crypto.Signer
+		error    string
+	}{
+		{name: "hex seed", data: []byte(seed + "\n"), expected: expected},
+		{name: "PKCS#8 PEM", data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), expected: expected},
+		{name: "OpenSSH", data: pem.EncodeToMemory(openSSH), expected: expected},
+		{name: "SEC1 ECDSA P-256", data: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}), expected: ecdsaKey},
+		{name: "PKCS#1 RSA", data: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}), expected: rsaKey},
+		{name: "ECDSA P-384", data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: p384}), error: "unsupported key type"},
+		{name: "encrypted OpenSSH", data: pem.EncodeToMemory(encrypted), error: "encrypted"},
+		{name: "other PEM", data: pem.EncodeToMemory(&pem.Block{Type: "DSA PRIVATE KEY", Bytes: []byte{1}}), error: "unsupported PEM block"},
+		{name: "short seed", data: []byte("abcd"), error: "invalid seed length"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privateKey, err := auth.ParsePrivateKey(tt.data)
+			if tt.error != "" {
+				assert.ErrorContains(t, err, tt.error)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, privateKey.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(tt.expected.Public()))
+		})
+	}
+}
+
+func TestCommandSigner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the signer command")
+	}
+
+	seed, err := auth.GenerateSeed()
+	require.NoError(t, err)
+	privateKey, err := auth.ParseSeed(seed)
+	require.NoError(t, err)
+	signature := ed25519.Sign(privateKey, []byte("2025-01-01T00:00:00Z"))
+
+	dir := t.TempDir()
+	writeScript := func(name, body string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0700))
+		return path
+	}
+
+	signer, err := auth.NewCommandSigner(writeScript("good", "cat >/dev/null; echo "+hex.EncodeToString(signature)))
+	require.NoError(t, err)
+	got, err := signer.Sign(context.Background(), []byte("2025-01-01T00:00:00Z"))
+	require.NoError(t, err)
+	assert.Equal(t, signature, got)
+
+	signer, err = auth.NewCommandSigner(writeScript("silent", "true"))
+	require.NoError(t, err)
+	_, err = signer.Sign(context.Background(), []byte("message"))
+	assert.ErrorContains(t, err, "no signature")
+
+	signer, err = auth.NewCommandSigner(writeScript("failing", "echo 'vault is sealed' >&2; exit 1"))
+	require.NoError(t, err)
+	_, err = signer.Sign(context.Background(), []byte("message"))
+	assert.ErrorContains(t, err, "vault is sealed")
+
+	_, err = auth.NewCommandSigner(strings.Repeat(" ", 3))
+	assert.Error(t, err)
+}
+
+func TestGenerateKey(t *testing.T) {
+	for _, algorithm := range []string{"ed25519", "ecdsap256", "rsa"} {
+		t.Run(algorithm, func(t *testing.T) {
+			privateKey, keyFile, err := auth.GenerateKey(algorithm)
+			require.NoError(t, err)
+
+			// The key file reads back as the same key
+			parsed, err := auth.ParsePrivateKey(keyFile)
+			require.NoError(t, err)
+			assert.True(t, parsed.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(privateKey.Public()))
+		})
+	}
+
+	_, _, err := auth.GenerateKey("dsa")
+	assert.ErrorContains(t, err, "unsupported key algorithm")
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/auth/keyalg"
+)
+
+// Signer signs the challenges sent to the registry for DNS and HTTP authentication
+type Signer interface {
+	Sign(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// keySigner signs with a private key held in memory, using the key algorithm for its type
+type keySigner struct {
+	privateKey crypto.Signer
+}
+
+// NewKeySigner creates a signer for a private key
+//
+//nolint:ireturn // Factory function returns interface by design
+func NewKeySigner(privateKey crypto.Signer) Signer {
+	return &keySigner{privateKey: privateKey}
+}
+
+// Sign signs message with the private key
+func (s *keySigner) Sign(_ context.Context, message []byte) ([]byte, error) {
+	return keyalg.Sign(s.privateKey, message)
+}
+
+// commandSigner asks an external command for signatures, so the private key can stay in a
+// hardware-backed or vault-backed signer
+type commandSigner struct {
+	args []string
+}
+
+// NewCommandSigner creates a signer that runs command, split on whitespace, for each
+// signature. The command gets the message on stdin and must print the signature, hex or
+// base64 encoded, on stdout, made with the algorithm of the domain's key record.
+//
+//nolint:ireturn // Factory function returns interface by design
+func NewCommandSigner(command string) (Signer, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, errors.New("signer command is empty")
+	}
+	return &commandSigner{args: args}, nil
+}
+
+// Sign runs the signer command with message on stdin and decodes the signature it prints
+func (s *commandSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.args[0], s.args[1:]...) // #nosec G204 -- the command comes from the user's own flags
+	cmd.Stdin = bytes.NewReader(message)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("signer command failed: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("signer command failed: %w", err)
+	}
+
+	output := strings.TrimSpace(stdout.String())
+	signature, err := hex.DecodeString(output)
+	if err != nil {
+		signature, err = base64.StdEncoding.DecodeString(output)
+	}
+	if err != nil {
+		return nil, errors.New("signer command must print the signature in hex or base64")
+	}
+	if len(signature) == 0 {
+		return nil, errors.New("signer command printed no signature")
+	}
+	return signature, nil
+}
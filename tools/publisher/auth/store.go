@@ -0,0 +1,316 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// ConfigDirEnv overrides the directory the credential store is kept in
+	ConfigDirEnv = "MCP_PUBLISHER_CONFIG_DIR"
+	// credentialsFileName is the name of the credential store file in the config directory
+	credentialsFileName = "credentials.json" // #nosec:G101
+	// refreshMargin is how long before it expires a stored registry token is replaced
+	refreshMargin = time.Minute
+)
+
+// Credential holds the tokens stored for one registry and authentication method
+type Credential struct {
+	// GitHubToken is the GitHub access token from the device flow (github-at only)
+	GitHubToken string `json:"github_token,omitempty"`
+	// RegistryToken is the last registry JWT issued
+	RegistryToken string `json:"registry_token,omitempty"`
+	// ExpiresAt is when RegistryToken expires (Unix seconds)
+	ExpiresAt int64     `json:"expires_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ValidRegistryToken returns the stored registry token if it is not about to expire
+func (c Credential) ValidRegistryToken() (string, bool) {
+	if c.RegistryToken == "" || time.Now().Add(refreshMargin).Unix() >= c.ExpiresAt {
+		return "", false
+	}
+	return c.RegistryToken, true
+}
+
+// StoredCredential is a Credential together with the registry and method it is stored under
+type StoredCredential struct {
+	RegistryURL string
+	Method      string
+	Credential
+}
+
+// credentialsFile is the on-disk format of the credential store
+type credentialsFile struct {
+	// Registries maps normalized registry URLs to credentials by authentication method
+	Registries map[string]map[string]Credential `json:"registries"`
+}
+
+// CredentialStore keeps tokens in a file under the user's config directory, keyed by
+// registry URL and authentication method, so that registries and checkouts don't share them
+type CredentialStore struct {
+	path string
+}
+
+// NewCredentialStore creates a credential store backed by the file at path
+func NewCredentialStore(path string) *CredentialStore {
+	return &CredentialStore{path: path}
+}
+
+// DefaultCredentialStore returns the credential store in $MCP_PUBLISHER_CONFIG_DIR, or else
+// in mcp-publisher under the user's config directory (e.g. ~/.config/mcp-publisher)
+func DefaultCredentialStore() (*CredentialStore, error) {
+	dir := os.Getenv(ConfigDirEnv)
+	if dir == "" {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user config directory (set %s): %w", ConfigDirEnv, err)
+		}
+		dir = filepath.Join(configDir, "mcp-publisher")
+	}
+	return NewCredentialStore(filepath.Join(dir, credentialsFileName)), nil
+}
+
+// Path returns the path of the credential store file
+func (s *CredentialStore) Path() string {
+	return s.path
+}
+
+// Get returns the credential stored for a registry and authentication method
+func (s *CredentialStore) Get(registryURL, method string) (Credential, bool, error) {
+	file, err := s.read()
+	if err != nil {
+		return Credential{}, false, err
+	}
+	credential, ok := file.Registries[normalizeRegistryURL(registryURL)][method]
+	return credential, ok, nil
+}
+
+// Put stores the credential for a registry and authentication method
+func (s *CredentialStore) Put(registryURL, method string, credential Credential) error {
+	file, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	key := normalizeRegistryURL(registryURL)
+	if file.Registries[key] == nil {
+		file.Registries[key] = make(map[string]Credential)
+	}
+	credential.UpdatedAt = time.Now().UTC()
+	file.Registries[key][method] = credential
+	return s.write(file)
+}
+
+// Delete removes the credentials for a registry, for one authentication method (including the
+// credentials of every host or domain stored under it) or for all of them if method is empty,
+// and reports how many were removed
+func (s *CredentialStore) Delete(registryURL, method string) (int, error) {
+	file, err := s.read()
+	if err != nil {
+		return 0, err
+	}
+
+	key := normalizeRegistryURL(registryURL)
+	removed := 0
+	for storedMethod := range file.Registries[key] {
+		if method == "" || storedMethod == method || strings.HasPrefix(storedMethod, method+"@") {
+			delete(file.Registries[key], storedMethod)
+			removed++
+		}
+	}
+	if len(file.Registries[key]) == 0 {
+		delete(file.Registries, key)
+	}
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, s.write(file)
+}
+
+// List returns every stored credential, sorted by registry and method
+func (s *CredentialStore) List() ([]StoredCredential, error) {
+	file, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var credentials []StoredCredential
+	for registryURL, methods := range file.Registries {
+		for method, credential := range methods {
+			credentials = append(credentials, StoredCredential{RegistryURL: registryURL, Method: method, Credential: credential})
+		}
+	}
+	sort.Slice(credentials, func(i, j int) bool {
+		if credentials[i].RegistryURL != credentials[j].RegistryURL {
+			return credentials[i].RegistryURL < credentials[j].RegistryURL
+		}
+		return credentials[i].Method < credentials[j].Method
+	})
+	return credentials, nil
+}
+
+func (s *CredentialStore) read() (*credentialsFile, error) {
+	file := &credentialsFile{}
+	data, err := os.ReadFile(s.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read credential store: %w", err)
+	default:
+		if err := json.Unmarshal(data, file); err != nil {
+			return nil, fmt.Errorf("failed to parse credential store %s: %w", s.path, err)
+		}
+	}
+	if file.Registries == nil {
+		file.Registries = make(map[string]map[string]Credential)
+	}
+	return file, nil
+}
+
+// write replaces the store file atomically, so concurrent runs never see a partial file
+func (s *CredentialStore) write(file *credentialsFile) error {
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create credential store directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, credentialsFileName+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write credential store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write credential store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write credential store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write credential store: %w", err)
+	}
+	return nil
+}
+
+// normalizeRegistryURL makes equivalent spellings of a registry URL share credentials
+func normalizeRegistryURL(registryURL string) string {
+	registryURL = strings.TrimRight(strings.TrimSpace(registryURL), "/")
+	if scheme, rest, found := strings.Cut(registryURL, "://"); found {
+		host, path, _ := strings.Cut(rest, "/")
+		registryURL = strings.ToLower(scheme) + "://" + strings.ToLower(host)
+		if path != "" {
+			registryURL += "/" + path
+		}
+	}
+	return registryURL
+}
+
+// tokenExpiry reads the expiry of a registry JWT without verifying it; the registry does that
+func tokenExpiry(token string) (int64, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return 0, false
+	}
+	var claims struct {
+		ExpiresAt int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.ExpiresAt == 0 {
+		return 0, false
+	}
+	return claims.ExpiresAt, true
+}
+
+// DomainMethod is the auth method the token of a DNS or HTTP domain is stored under in the
+// CredentialStore, since a registry token only grants the namespace of the domain it was issued for
+func DomainMethod(method, domain string) string {
+	return method + "@" + strings.ToLower(domain)
+}
+
+// StoredProvider wraps a Provider to reuse its registry token from a CredentialStore until
+// it expires, after which a new one is requested from the wrapped provider
+type StoredProvider struct {
+	Provider
+	store       *CredentialStore
+	registryURL string
+	method      string
+	refresh     bool
+}
+
+// NewStoredProvider wraps provider so its registry tokens are kept in store. With refresh, as
+// for --login, the stored registry token is replaced rather than reused.
+//
+//nolint:ireturn // Factory function returns interface by design
+func NewStoredProvider(provider Provider, store *CredentialStore, registryURL, method string, refresh bool) Provider {
+	return &StoredProvider{
+		Provider:    provider,
+		store:       store,
+		registryURL: registryURL,
+		method:      method,
+		refresh:     refresh,
+	}
+}
+
+// GetToken returns the stored registry token, refreshing it if it has expired
+func (p *StoredProvider) GetToken(ctx context.Context) (string, error) {
+	credential, _, err := p.store.Get(p.registryURL, p.method)
+	if err != nil {
+		return "", err
+	}
+	if token, ok := credential.ValidRegistryToken(); ok && !p.refresh {
+		return token, nil
+	}
+
+	token, err := p.Provider.GetToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// Tokens whose expiry can't be read are used once rather than cached
+	expiresAt, ok := tokenExpiry(token)
+	if !ok {
+		return token, nil
+	}
+
+	// Reread the credential, as the wrapped provider may have updated it
+	credential, _, err = p.store.Get(p.registryURL, p.method)
+	if err != nil {
+		return "", err
+	}
+	credential.RegistryToken = token
+	credential.ExpiresAt = expiresAt
+	if err := p.store.Put(p.registryURL, p.method, credential); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// NeedsLogin reports whether the wrapped provider needs a login, unless a stored token is still valid
+func (p *StoredProvider) NeedsLogin() bool {
+	if p.refresh {
+		return p.Provider.NeedsLogin()
+	}
+	if credential, _, err := p.store.Get(p.registryURL, p.method); err == nil {
+		if _, ok := credential.ValidRegistryToken(); ok {
+			return false
+		}
+	}
+	return p.Provider.NeedsLogin()
+}
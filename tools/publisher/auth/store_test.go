@@ -0,0 +1,127 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/tools/publisher/auth"
+)
+
+// fakeProvider issues unsigned JWTs that expire after ttl
+type fakeProvider struct {
+	ttl    time.Duration
+	issued int
+}
+
+func (f *fakeProvider) GetToken(_ context.Context) (string, error) {
+	f.issued++
+	payload := fmt.Sprintf(`{"exp":%d,"n":%d}`, time.Now().Add(f.ttl).Unix(), f.issued)
+	return "e30." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".sig", nil
+}
+
+func (f *fakeProvider) NeedsLogin() bool              { return true }
+func (f *fakeProvider) Login(_ context.Context) error { return nil }
+func (f *fakeProvider) Name() string                  { return "fake" }
+
+func TestCredentialStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mcp-publisher", "credentials.json")
+	store := auth.NewCredentialStore(path)
+
+	_, ok, err := store.Get("https://registry.example.com", "dns")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// Credentials are keyed by registry and method, ignoring trailing slashes and host case
+	require.NoError(t, store.Put("https://Registry.example.com/", "dns", auth.Credential{RegistryToken: "a"}))
+	require.NoError(t, store.Put("https://registry.example.com", "github-at", auth.Credential{GitHubToken: "b"}))
+	require.NoError(t, store.Put("http://localhost:8080", "dns", auth.Credential{RegistryToken: "c"}))
+
+	credential, ok, err := store.Get("https://registry.example.com", "dns")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "a", credential.RegistryToken)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	credentials, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, credentials, 3)
+	assert.Equal(t, "http://localhost:8080", credentials[0].RegistryURL)
+	assert.Equal(t, "github-at", credentials[2].Method)
+
+	removed, err := store.Delete("https://registry.example.com", "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	credentials, err = store.List()
+	require.NoError(t, err)
+	assert.Len(t, credentials, 1)
+}
+
+func TestStoredProvider(t *testing.T) {
+	store := auth.NewCredentialStore(filepath.Join(t.TempDir(), "credentials.json"))
+	fake := &fakeProvider{ttl: time.Hour}
+	provider := auth.NewStoredProvider(fake, store, "https://registry.example.com", "dns", false)
+
+	// The first token is stored and reused until it expires
+	assert.True(t, provider.NeedsLogin())
+	token, err := provider.GetToken(context.Background())
+	require.NoError(t, err)
+	assert.False(t, provider.NeedsLogin())
+
+	again, err := provider.GetToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, token, again)
+	assert.Equal(t, 1, fake.issued)
+
+	// Tokens that are about to expire are refreshed
+	fake.ttl = 30 * time.Second
+	require.NoError(t, store.Put("https://registry.example.com", "dns", auth.Credential{}))
+	first, err := provider.GetToken(context.Background())
+	require.NoError(t, err)
+	second, err := provider.GetToken(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, 3, fake.issued)
+}
+
+func TestStoredProviderKeys(t *testing.T) {
+	store := auth.NewCredentialStore(filepath.Join(t.TempDir(), "credentials.json"))
+	fake := &fakeProvider{ttl: time.Hour}
+	registryURL := "https://registry.example.com"
+
+	// Tokens for one domain are never used for another
+	a, err := auth.NewStoredProvider(fake, store, registryURL, auth.DomainMethod("dns", "A.com"), false).GetToken(context.Background())
+	require.NoError(t, err)
+	b, err := auth.NewStoredProvider(fake, store, registryURL, auth.DomainMethod("dns", "b.com"), false).GetToken(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, a, b)
+	assert.Equal(t, 2, fake.issued)
+
+	// --login replaces the stored token
+	refreshed := auth.NewStoredProvider(fake, store, registryURL, auth.DomainMethod("dns", "a.com"), true)
+	assert.True(t, refreshed.NeedsLogin())
+	again, err := refreshed.GetToken(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, a, again)
+	assert.Equal(t, 3, fake.issued)
+
+	credential, ok, err := store.Get(registryURL, "dns@a.com")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, again, credential.RegistryToken)
+
+	// Logging out of a method removes every domain stored under it
+	removed, err := store.Delete(registryURL, "dns")
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/tools/publisher/auth"
+)
+
+func loginCommand() error {
+	loginFlags := flag.NewFlagSet("login", flag.ExitOnError)
+
+	var authOpts authOptions
+	authOpts.register(loginFlags)
+
+	// Set custom usage function
+	loginFlags.Usage = func() {
+		fmt.Fprint(os.Stdout, "Usage: mcp-publisher login [flags]\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Log in to a registry and store the credentials for later commands\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Flags:\n")
+		fmt.Fprint(os.Stdout, authOpts.usage())
+	}
+
+	if err := loginFlags.Parse(os.Args[2:]); err != nil {
+		log.Fatalf("Error parsing flags: %v", err)
+	}
+
+	if authOpts.registryURL == "" {
+		loginFlags.Usage()
+		return errors.New("registry-url is required")
+	}
+
+	// Logging in explicitly always starts afresh
+	authOpts.forceLogin = true
+	if _, err := authOpts.token(context.Background()); err != nil {
+		return err
+	}
+
+	log.Printf("Logged in to %s with %s", authOpts.registryURL, authOpts.method)
+	return nil
+}
+
+func logoutCommand() error {
+	logoutFlags := flag.NewFlagSet("logout", flag.ExitOnError)
+
+	var registryURL string
+	var authMethod string
+	logoutFlags.StringVar(&registryURL, "registry-url", "", "URL of the registry (required)")
+	logoutFlags.StringVar(&authMethod, "auth-method", "", "only remove the credentials for this authentication method")
+
+	// Set custom usage function
+	logoutFlags.Usage = func() {
+		fmt.Fprint(os.Stdout, "Usage: mcp-publisher logout [flags]\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Remove the stored credentials for a registry\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Flags:\n")
+		fmt.Fprint(os.Stdout, "  --registry-url string       URL of the registry (required)\n")
+		fmt.Fprint(os.Stdout, "  --auth-method string        only remove the credentials for this authentication method\n")
+	}
+
+	if err := logoutFlags.Parse(os.Args[2:]); err != nil {
+		log.Fatalf("Error parsing flags: %v", err)
+	}
+
+	if registryURL == "" {
+		logoutFlags.Usage()
+		return errors.New("registry-url is required")
+	}
+
+	store, err := auth.DefaultCredentialStore()
+	if err != nil {
+		return err
+	}
+	removed, err := store.Delete(registryURL, authMethod)
+	if err != nil {
+		return err
+	}
+
+	if removed == 0 {
+		log.Printf("No stored credentials for %s", registryURL)
+		return nil
+	}
+	log.Printf("Removed %d stored credential(s) for %s", removed, registryURL)
+	return nil
+}
+
+func authCommand() error {
+	if len(os.Args) < 3 || os.Args[2] != "status" {
+		fmt.Fprint(os.Stdout, "Usage: mcp-publisher auth status\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Show the credentials stored for each registry and authentication method\n")
+		return nil
+	}
+
+	store, err := auth.DefaultCredentialStore()
+	if err != nil {
+		return err
+	}
+	credentials, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Credential store: %s\n\n", store.Path())
+	if len(credentials) == 0 {
+		fmt.Fprint(os.Stdout, "Not logged in to any registry. Use 'mcp-publisher login' to log in.\n")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprint(w, "REGISTRY\tAUTH METHOD\tREGISTRY TOKEN\n")
+	for _, stored := range credentials {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", stored.RegistryURL, stored.Method, credentialStatus(stored.Credential))
+	}
+	return w.Flush()
+}
+
+// credentialStatus describes whether a stored credential can be used without logging in again
+func credentialStatus(credential auth.Credential) string {
+	if _, ok := credential.ValidRegistryToken(); ok {
+		return "valid until " + time.Unix(credential.ExpiresAt, 0).Local().Format(time.DateTime)
+	}
+	if credential.GitHubToken != "" {
+		return "expired, will be refreshed with the stored GitHub token"
+	}
+	if credential.RegistryToken != "" {
+		return "expired, will be refreshed on next use"
+	}
+	return "none"
+}
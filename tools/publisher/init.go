@@ -0,0 +1,574 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// candidate is a value for a server.json field found in a project file
+type candidate struct {
+	value  string
+	source string
+}
+
+// projectInfo is the server metadata detected in a project directory
+type projectInfo struct {
+	names        []candidate
+	descriptions []candidate
+	versions     []candidate
+	repoURL      string
+	packages     []Package
+	dockerfile   bool
+}
+
+func (p *projectInfo) add(list *[]candidate, value, source string) {
+	if value = strings.TrimSpace(value); value != "" {
+		*list = append(*list, candidate{value: value, source: source})
+	}
+}
+
+func initCommand() error {
+	initFlags := flag.NewFlagSet("init", flag.ExitOnError)
+
+	var dir string
+	var output string
+	var yes bool
+	var force bool
+
+	initFlags.StringVar(&dir, "dir", ".", "project directory to inspect")
+	initFlags.StringVar(&output, "output", "server.json", "output file path")
+	initFlags.StringVar(&output, "o", "server.json", "output file path (shorthand)")
+	initFlags.BoolVar(&yes, "yes", false, "accept the detected values without asking")
+	initFlags.BoolVar(&force, "force", false, "overwrite an existing output file")
+
+	// Set custom usage function
+	initFlags.Usage = func() {
+		fmt.Fprint(os.Stdout, "Usage: mcp-publisher init [flags]\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Create a server.json file from the metadata of the project in the current\n")
+		fmt.Fprint(os.Stdout, "directory: package.json, pyproject.toml, *.csproj, Dockerfile and the git remote.\n")
+		fmt.Fprint(os.Stdout, "Asks for anything that is missing or ambiguous.\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Flags:\n")
+		fmt.Fprint(os.Stdout, "  --dir string                project directory to inspect (default: .)\n")
+		fmt.Fprint(os.Stdout, "  --output/-o string          output file path (default: server.json)\n")
+		fmt.Fprint(os.Stdout, "  --yes                       accept the detected values without asking\n")
+		fmt.Fprint(os.Stdout, "  --force                     overwrite an existing output file\n")
+	}
+
+	if err := initFlags.Parse(os.Args[2:]); err != nil {
+		log.Fatalf("Error parsing flags: %v", err)
+	}
+
+	if !force {
+		if _, err := os.Stat(output); err == nil {
+			return fmt.Errorf("%s already exists (use --force to overwrite it)", output)
+		}
+	}
+
+	info, err := detectProject(dir)
+	if err != nil {
+		return err
+	}
+
+	p := &prompter{in: bufio.NewReader(os.Stdin), out: os.Stdout, interactive: !yes}
+	server, err := buildServerJSON(info, p)
+	if err != nil {
+		return err
+	}
+
+	jsonData, err := json.MarshalIndent(server, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling JSON: %w", err)
+	}
+	if err := os.WriteFile(output, jsonData, 0600); err != nil {
+		return fmt.Errorf("error writing file: %w", err)
+	}
+	log.Printf("Successfully created %s", output)
+
+	// Point out anything that still needs attention before publishing
+	issues, err := validateServerJSON(jsonData)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		log.Printf("%s:%d:%d: %s: %s", output, issue.Line, issue.Column, issue.Severity, issue.Message)
+	}
+	log.Println("You may need to edit the file to add arguments and environment variables, then run 'mcp-publisher validate'")
+	return nil
+}
+
+// detectProject reads the project files in dir that describe the server
+func detectProject(dir string) (*projectInfo, error) {
+	info := &projectInfo{}
+
+	detectors := []func(string, *projectInfo) error{
+		detectGitRemote, detectPackageJSON, detectPyproject, detectCsproj, detectDockerfile,
+	}
+	for _, detect := range detectors {
+		if err := detect(dir, info); err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
+// readProjectFile returns the contents of a project file, or nil if it does not exist
+func readProjectFile(dir, name string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// detectGitRemote uses the origin remote as the repository, and its owner and name for the
+// server name. A git config that can't be found or read just means no remote is detected.
+func detectGitRemote(dir string, info *projectInfo) error {
+	data, err := os.ReadFile(filepath.Join(gitDir(dir), "config"))
+	if err != nil {
+		return nil
+	}
+
+	section := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			section = line
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if found && section == `[remote "origin"]` && strings.TrimSpace(key) == "url" {
+			info.repoURL = normalizeRepoURL(strings.TrimSpace(value))
+			break
+		}
+	}
+
+	if owner, repo, ok := githubOwnerRepo(info.repoURL); ok {
+		info.add(&info.names, fmt.Sprintf("io.github.%s/%s", owner, repo), "git remote")
+	}
+	return nil
+}
+
+// gitDir returns the directory holding the shared git config of the repository in dir. In a
+// worktree or submodule, .git is a file pointing to the actual git directory, and a worktree's
+// git directory in turn names the common directory of its repository.
+func gitDir(dir string) string {
+	path := filepath.Join(dir, ".git")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return path
+	}
+	target, found := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !found {
+		return path
+	}
+	path = strings.TrimSpace(target)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	if common, err := os.ReadFile(filepath.Join(path, "commondir")); err == nil {
+		commonDir := strings.TrimSpace(string(common))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(path, commonDir)
+		}
+		return commonDir
+	}
+	return path
+}
+
+func detectPackageJSON(dir string, info *projectInfo) error {
+	data, err := readProjectFile(dir, "package.json")
+	if err != nil || data == nil {
+		return err
+	}
+
+	var pkg struct {
+		Name        string          `json:"name"`
+		Description string          `json:"description"`
+		Version     string          `json:"version"`
+		Repository  json.RawMessage `json:"repository"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return fmt.Errorf("error parsing package.json: %w", err)
+	}
+
+	info.add(&info.descriptions, pkg.Description, "package.json")
+	info.add(&info.versions, pkg.Version, "package.json")
+	if info.repoURL == "" && len(pkg.Repository) > 0 {
+		// The repository is either a string or an object with a url
+		var repository struct {
+			URL string `json:"url"`
+		}
+		if err := json.Unmarshal(pkg.Repository, &repository.URL); err != nil {
+			_ = json.Unmarshal(pkg.Repository, &repository)
+		}
+		info.repoURL = normalizeRepoURL(repository.URL)
+	}
+	if pkg.Name != "" {
+		info.packages = append(info.packages, Package{
+			RegistryName: "npm",
+			Name:         pkg.Name,
+			Version:      pkg.Version,
+			RuntimeHint:  "npx",
+		})
+	}
+	return nil
+}
+
+func detectPyproject(dir string, info *projectInfo) error {
+	data, err := readProjectFile(dir, "pyproject.toml")
+	if err != nil || data == nil {
+		return err
+	}
+
+	// Only the simple string keys of [project] (or Poetry's [tool.poetry]) are needed,
+	// so this reads them directly rather than parsing all of TOML
+	values := make(map[string]string)
+	table := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			table = strings.Trim(line, "[] ")
+			continue
+		}
+		if table != "project" && table != "tool.poetry" {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if _, exists := values[key]; !exists {
+			values[key] = unquoteTOML(strings.TrimSpace(value))
+		}
+	}
+
+	info.add(&info.descriptions, values["description"], "pyproject.toml")
+	info.add(&info.versions, values["version"], "pyproject.toml")
+	if name := values["name"]; name != "" {
+		info.packages = append(info.packages, Package{
+			RegistryName: "pypi",
+			Name:         name,
+			Version:      values["version"],
+			RuntimeHint:  "uvx",
+		})
+	}
+	return nil
+}
+
+// unquoteTOML returns the value of a basic or literal TOML string
+func unquoteTOML(value string) string {
+	if strings.HasPrefix(value, `"`) {
+		if end := strings.LastIndex(value, `"`); end > 0 {
+			if unquoted, err := strconv.Unquote(value[:end+1]); err == nil {
+				return unquoted
+			}
+		}
+	}
+	if strings.HasPrefix(value, "'") {
+		if end := strings.LastIndex(value, "'"); end > 0 {
+			return value[1:end]
+		}
+	}
+	return ""
+}
+
+func detectCsproj(dir string, info *projectInfo) error {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.csproj"))
+	if err != nil {
+		return fmt.Errorf("error finding .csproj files: %w", err)
+	}
+
+	for _, path := range matches {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", filepath.Base(path), err)
+		}
+
+		var project struct {
+			PropertyGroups []struct {
+				PackageID     string `xml:"PackageId"`
+				AssemblyName  string `xml:"AssemblyName"`
+				Version       string `xml:"Version"`
+				Description   string `xml:"Description"`
+				RepositoryURL string `xml:"RepositoryUrl"`
+			} `xml:"PropertyGroup"`
+		}
+		if err := xml.Unmarshal(data, &project); err != nil {
+			return fmt.Errorf("error parsing %s: %w", filepath.Base(path), err)
+		}
+
+		// Properties may be spread over several groups
+		var packageID, version, description, repoURL string
+		for _, group := range project.PropertyGroups {
+			packageID = cmp.Or(packageID, group.PackageID, group.AssemblyName)
+			version = cmp.Or(version, group.Version)
+			description = cmp.Or(description, group.Description)
+			repoURL = cmp.Or(repoURL, group.RepositoryURL)
+		}
+		if packageID == "" {
+			packageID = strings.TrimSuffix(filepath.Base(path), ".csproj")
+		}
+
+		source := filepath.Base(path)
+		info.add(&info.descriptions, description, source)
+		info.add(&info.versions, version, source)
+		if info.repoURL == "" {
+			info.repoURL = normalizeRepoURL(repoURL)
+		}
+		info.packages = append(info.packages, Package{
+			RegistryName: "nuget",
+			Name:         packageID,
+			Version:      version,
+			RuntimeHint:  "dnx",
+		})
+	}
+	return nil
+}
+
+// detectDockerfile notes a Dockerfile; the image name is only known once the repository is
+func detectDockerfile(dir string, info *projectInfo) error {
+	data, err := readProjectFile(dir, "Dockerfile")
+	if err != nil {
+		return err
+	}
+	info.dockerfile = data != nil
+	return nil
+}
+
+// normalizeRepoURL converts the common forms of git remote URLs to an https URL
+func normalizeRepoURL(raw string) string {
+	repoURL := strings.TrimSpace(raw)
+	if repoURL == "" {
+		return ""
+	}
+	repoURL = strings.TrimPrefix(repoURL, "git+")
+
+	switch {
+	case strings.HasPrefix(repoURL, "github:"):
+		repoURL = "https://github.com/" + strings.TrimPrefix(repoURL, "github:")
+	case strings.HasPrefix(repoURL, "git@"):
+		// git@host:owner/repo
+		host, path, _ := strings.Cut(strings.TrimPrefix(repoURL, "git@"), ":")
+		repoURL = "https://" + host + "/" + path
+	case strings.HasPrefix(repoURL, "ssh://git@"):
+		repoURL = "https://" + strings.TrimPrefix(repoURL, "ssh://git@")
+	case strings.HasPrefix(repoURL, "git://"):
+		repoURL = "https://" + strings.TrimPrefix(repoURL, "git://")
+	case !strings.Contains(repoURL, "://") && strings.Count(repoURL, "/") == 1:
+		// npm's owner/repo shorthand
+		repoURL = "https://github.com/" + repoURL
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+}
+
+// githubOwnerRepo returns the owner and repository name of a GitHub repository URL
+func githubOwnerRepo(repoURL string) (string, string, bool) {
+	path, found := strings.CutPrefix(repoURL, "https://github.com/")
+	if !found {
+		return "", "", false
+	}
+	owner, repo, found := strings.Cut(path, "/")
+	if !found || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", false
+	}
+	return owner, repo, true
+}
+
+// repoSource returns the repository hosting service for a repository URL
+func repoSource(repoURL string) string {
+	host, _, _ := strings.Cut(strings.TrimPrefix(repoURL, "https://"), "/")
+	switch host {
+	case "github.com":
+		return "github"
+	case "gitlab.com":
+		return "gitlab"
+	default:
+		return host
+	}
+}
+
+// buildServerJSON resolves the detected metadata into a server.json, asking the user
+// to fill in gaps and choose between conflicting values
+func buildServerJSON(info *projectInfo, p *prompter) (ServerJSON, error) {
+	var server ServerJSON
+	var err error
+
+	if server.Name, err = p.choose("Server name (e.g. io.github.owner/repo)", info.names); err != nil {
+		return server, err
+	}
+	if server.Description, err = p.choose("Description", info.descriptions); err != nil {
+		return server, err
+	}
+	if server.VersionDetail.Version, err = p.choose("Version", append(info.versions, candidate{"1.0.0", "default"})); err != nil {
+		return server, err
+	}
+	if server.Repository.URL, err = p.ask("Repository URL", info.repoURL); err != nil {
+		return server, err
+	}
+	server.Repository.URL = normalizeRepoURL(server.Repository.URL)
+	server.Repository.Source = repoSource(server.Repository.URL)
+
+	for _, pkg := range info.packages {
+		include, err := p.confirm(fmt.Sprintf("Include %s package %s?", pkg.RegistryName, pkg.Name), true)
+		if err != nil {
+			return server, err
+		}
+		if !include {
+			continue
+		}
+		if pkg.Version == "" {
+			pkg.Version = server.VersionDetail.Version
+		}
+		server.Packages = append(server.Packages, pkg)
+	}
+
+	if info.dockerfile {
+		include, err := p.confirm("Include a docker package for the Dockerfile?", true)
+		if err != nil {
+			return server, err
+		}
+		if include {
+			image := ""
+			if owner, repo, ok := githubOwnerRepo(server.Repository.URL); ok {
+				image = strings.ToLower(owner + "/" + repo)
+			}
+			if image, err = p.ask("Docker image name", image); err != nil {
+				return server, err
+			}
+			if image != "" {
+				server.Packages = append(server.Packages, Package{
+					RegistryName: "docker",
+					Name:         image,
+					Version:      server.VersionDetail.Version,
+					RuntimeHint:  "docker",
+				})
+			}
+		}
+	}
+
+	// Hosted servers cannot be detected from the project, so ask for them
+	for p.interactive {
+		remoteURL, err := p.ask("Remote server URL (leave empty to finish)", "")
+		if err != nil {
+			return server, err
+		}
+		if remoteURL == "" {
+			break
+		}
+		transport := "streamable"
+		if strings.HasSuffix(strings.TrimSuffix(remoteURL, "/"), "/sse") {
+			transport = "sse"
+		}
+		if transport, err = p.ask("Transport type (streamable or sse)", transport); err != nil {
+			return server, err
+		}
+		server.Remotes = append(server.Remotes, Remote{TransportType: transport, URL: remoteURL})
+	}
+
+	if server.Name == "" || server.Description == "" {
+		return server, errors.New("a server name and description are required; run without --yes to enter them")
+	}
+	return server, nil
+}
+
+// prompter asks the user questions, or accepts the defaults when not interactive
+type prompter struct {
+	in          *bufio.Reader
+	out         io.Writer
+	interactive bool
+}
+
+// ask returns the user's answer, or def if they give none
+func (p *prompter) ask(question, def string) (string, error) {
+	if !p.interactive {
+		return def, nil
+	}
+
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+	answer, err := p.in.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("error reading answer: %w", err)
+	}
+	if answer = strings.TrimSpace(answer); answer != "" {
+		return answer, nil
+	}
+	return def, nil
+}
+
+// choose asks the user to pick one of the distinct candidates, or to enter a value if there are none
+func (p *prompter) choose(question string, candidates []candidate) (string, error) {
+	var distinct []candidate
+	for _, c := range candidates {
+		if !slices.ContainsFunc(distinct, func(d candidate) bool { return d.value == c.value }) {
+			distinct = append(distinct, c)
+		}
+	}
+
+	switch {
+	case len(distinct) == 0:
+		return p.ask(question, "")
+	case len(distinct) == 1 || !p.interactive:
+		return p.ask(question, distinct[0].value)
+	}
+
+	fmt.Fprintf(p.out, "%s:\n", question)
+	for i, c := range distinct {
+		fmt.Fprintf(p.out, "  %d) %s (from %s)\n", i+1, c.value, c.source)
+	}
+	for {
+		answer, err := p.ask("Choose a number or enter a value", "1")
+		if err != nil {
+			return "", err
+		}
+		n, err := strconv.Atoi(answer)
+		if err != nil {
+			return answer, nil
+		}
+		if n >= 1 && n <= len(distinct) {
+			return distinct[n-1].value, nil
+		}
+	}
+}
+
+// confirm asks a yes/no question
+func (p *prompter) confirm(question string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	answer, err := p.ask(fmt.Sprintf("%s (%s)", question, hint), "")
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	default:
+		return def, nil
+	}
+}
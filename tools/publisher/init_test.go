@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeProjectFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return dir
+}
+
+func TestDetectProject(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		".git/config": "[core]\n\tbare = false\n[remote \"origin\"]\n\turl = git@github.com:Example/weather.git\n",
+		"package.json": `{"name": "@example/weather", "version": "1.2.0", "description": "Weather forecasts",
+			"repository": {"type": "git", "url": "git+https://github.com/someone/else.git"}}`,
+		"pyproject.toml": "[project]\nname = \"weather-mcp\"\nversion = '1.2.1'\ndependencies = [\n  \"httpx>=0.27\",\n]\n\n[tool.ruff]\nname = \"ignored\"\n",
+		"Weather.csproj": "<Project><PropertyGroup><PackageId>Example.Weather</PackageId></PropertyGroup>" +
+			"<PropertyGroup><Version>1.2.0</Version></PropertyGroup></Project>",
+		"Dockerfile": "FROM node:22\n",
+	})
+
+	info, err := detectProject(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://github.com/Example/weather", info.repoURL)
+	assert.Equal(t, []candidate{{"io.github.Example/weather", "git remote"}}, info.names)
+	assert.Equal(t, []candidate{{"Weather forecasts", "package.json"}}, info.descriptions)
+	assert.Equal(t, []candidate{{"1.2.0", "package.json"}, {"1.2.1", "pyproject.toml"}, {"1.2.0", "Weather.csproj"}}, info.versions)
+	assert.Equal(t, []Package{
+		{RegistryName: "npm", Name: "@example/weather", Version: "1.2.0", RuntimeHint: "npx"},
+		{RegistryName: "pypi", Name: "weather-mcp", Version: "1.2.1", RuntimeHint: "uvx"},
+		{RegistryName: "nuget", Name: "Example.Weather", Version: "1.2.0", RuntimeHint: "dnx"},
+	}, info.packages)
+	assert.True(t, info.dockerfile)
+
+	// Without asking, the first of each conflicting value is used
+	server, err := buildServerJSON(info, &prompter{interactive: false})
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0", server.VersionDetail.Version)
+	assert.Equal(t, Repository{URL: "https://github.com/Example/weather", Source: "github"}, server.Repository)
+	require.Len(t, server.Packages, 4)
+	assert.Equal(t, Package{RegistryName: "docker", Name: "example/weather", Version: "1.2.0", RuntimeHint: "docker"}, server.Packages[3])
+
+	// Interactively, conflicting values are chosen, packages can be skipped and remotes added
+	answers := strings.Join([]string{
+		"",          // name
+		"",          // description
+		"2",         // version: 1.2.1
+		"",          // repository
+		"", "n", "", // npm, pypi and nuget packages
+		"n",                               // docker package
+		"https://weather.example.com/sse", // remote
+		"",                                // transport: sse
+		"",                                // no more remotes
+	}, "\n") + "\n"
+	server, err = buildServerJSON(info, &prompter{in: bufio.NewReader(strings.NewReader(answers)), out: io.Discard, interactive: true})
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.1", server.VersionDetail.Version)
+	require.Len(t, server.Packages, 2)
+	assert.Equal(t, "nuget", server.Packages[1].RegistryName)
+	assert.Equal(t, []Remote{{TransportType: "sse", URL: "https://weather.example.com/sse"}}, server.Remotes)
+}
+
+func TestDetectGitRemote(t *testing.T) {
+	config := "[remote \"origin\"]\n\turl = https://github.com/example/weather.git\n"
+	repo := writeProjectFiles(t, map[string]string{
+		".git/config":                      config,
+		".git/worktrees/feature/commondir": "../..\n",
+		".git/modules/weather/config":      strings.Replace(config, "weather", "weather-module", 1),
+	})
+
+	tests := map[string]struct {
+		files    map[string]string
+		expected string
+	}{
+		"worktree": {
+			files:    map[string]string{".git": "gitdir: " + filepath.Join(repo, ".git", "worktrees", "feature") + "\n"},
+			expected: "https://github.com/example/weather",
+		},
+		"submodule": {
+			files:    map[string]string{".git": "gitdir: " + filepath.Join(repo, ".git", "modules", "weather") + "\n"},
+			expected: "https://github.com/example/weather-module",
+		},
+		"missing git directory": {
+			files: map[string]string{".git": "gitdir: ../nowhere\n"},
+		},
+		"no git directory": {},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			info, err := detectProject(writeProjectFiles(t, tt.files))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, info.repoURL)
+		})
+	}
+}
+
+func TestNormalizeRepoURL(t *testing.T) {
+	for raw, expected := range map[string]string{
+		"git+https://github.com/example/server.git": "https://github.com/example/server",
+		"git@gitlab.com:example/server.git":         "https://gitlab.com/example/server",
+		"ssh://git@github.com/example/server.git":   "https://github.com/example/server",
+		"github:example/server":                     "https://github.com/example/server",
+		"example/server":                            "https://github.com/example/server",
+		"https://github.com/example/server/":        "https://github.com/example/server",
+	} {
+		assert.Equal(t, expected, normalizeRepoURL(raw), raw)
+	}
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/auth/keyalg"
+	"github.com/modelcontextprotocol/registry/tools/publisher/auth"
+)
+
+// wellKnownPath is where the registry looks for the public key for HTTP authentication
+const wellKnownPath = "/.well-known/mcp-registry-auth"
+
+// txtResolver looks up DNS TXT records; *net.Resolver implements it
+type txtResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+func keygenCommand() error {
+	if len(os.Args) > 2 && os.Args[2] == "verify-setup" {
+		return verifySetupCommand()
+	}
+
+	keygenFlags := flag.NewFlagSet("keygen", flag.ExitOnError)
+
+	var domain string
+	var algorithm string
+	var output string
+	var force bool
+	keygenFlags.StringVar(&domain, "domain", "", "domain the key will authenticate (required)")
+	keygenFlags.StringVar(&algorithm, "algorithm", "ed25519", "key algorithm: "+strings.Join(keyalg.Names(), ", "))
+	keygenFlags.StringVar(&output, "output", "mcp-registry.key", "file to write the private key to")
+	keygenFlags.StringVar(&output, "o", "mcp-registry.key", "file to write the private key to (shorthand)")
+	keygenFlags.BoolVar(&force, "force", false, "overwrite the key file if it already exists")
+
+	// Set custom usage function
+	keygenFlags.Usage = func() {
+		fmt.Fprint(os.Stdout, "Usage: mcp-publisher keygen [flags]\n")
+		fmt.Fprint(os.Stdout, "       mcp-publisher keygen verify-setup [flags]\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Generate a key for DNS or HTTP authentication, and print the DNS TXT\n")
+		fmt.Fprint(os.Stdout, "record and well-known file that publish the public key for a domain\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Flags:\n")
+		fmt.Fprint(os.Stdout, "  --domain string             domain the key will authenticate (required)\n")
+		fmt.Fprintf(os.Stdout, "  --algorithm string          key algorithm: %s (default: ed25519)\n", strings.Join(keyalg.Names(), ", "))
+		fmt.Fprint(os.Stdout, "  --output/-o string          file to write the private key to (default: mcp-registry.key)\n")
+		fmt.Fprint(os.Stdout, "  --force                     overwrite the key file if it already exists\n")
+	}
+
+	if err := keygenFlags.Parse(os.Args[2:]); err != nil {
+		log.Fatalf("Error parsing flags: %v", err)
+	}
+
+	if domain == "" {
+		keygenFlags.Usage()
+		return errors.New("domain is required")
+	}
+
+	privateKey, keyFile, err := auth.GenerateKey(algorithm)
+	if err != nil {
+		return err
+	}
+	record, err := keyalg.FormatRecord(privateKey.Public())
+	if err != nil {
+		return err
+	}
+	if err := writeKeyFile(output, keyFile, force); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Private key written to %s. Keep it secret, and out of version control.\n", output)
+	fmt.Fprint(os.Stdout, "\n")
+	fmt.Fprintf(os.Stdout, "For DNS authentication (--auth-method dns), add this TXT record to %s:\n", domain)
+	fmt.Fprintf(os.Stdout, "  Name:  %s\n", domain)
+	fmt.Fprint(os.Stdout, "  Type:  TXT\n")
+	fmt.Fprintf(os.Stdout, "  Value: %s\n", record)
+	fmt.Fprint(os.Stdout, "\n")
+	fmt.Fprint(os.Stdout, "For HTTP authentication (--auth-method http), serve this as text/plain at\n")
+	fmt.Fprintf(os.Stdout, "https://%s%s:\n", domain, wellKnownPath)
+	fmt.Fprintf(os.Stdout, "  %s\n", record)
+	fmt.Fprint(os.Stdout, "\n")
+	fmt.Fprint(os.Stdout, "Once it is in place, check it with:\n")
+	fmt.Fprintf(os.Stdout, "  mcp-publisher keygen verify-setup --domain %s --key-file %s\n", domain, output)
+	fmt.Fprint(os.Stdout, "\n")
+	fmt.Fprint(os.Stdout, "Then publish with:\n")
+	fmt.Fprintf(os.Stdout, "  mcp-publisher publish --auth-method dns --dns-domain %s --private-key-file %s ...\n", domain, output)
+	return nil
+}
+
+// writeKeyFile writes the private key to a file only the current user can read
+func writeKeyFile(path string, data []byte, force bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+
+	file, err := os.OpenFile(path, flags, 0600)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%s already exists; use --force to overwrite it", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create key file: %w", err)
+	}
+
+	// An existing file keeps its mode when truncated, so tighten it explicitly
+	if err := file.Chmod(0600); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to set key file permissions: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write key file: %w", err)
+	}
+	return file.Close()
+}
+
+func verifySetupCommand() error {
+	verifyFlags := flag.NewFlagSet("keygen verify-setup", flag.ExitOnError)
+
+	var domain string
+	var keyFile string
+	var privateKeyHex string
+	var authMethod string
+	verifyFlags.StringVar(&domain, "domain", "", "domain to check (required)")
+	verifyFlags.StringVar(&keyFile, "key-file", "", "file containing the private key (hex seed, PKCS#8 PEM or OpenSSH)")
+	verifyFlags.StringVar(&privateKeyHex, "private-key", "", "64-character hex seed, instead of --key-file")
+	verifyFlags.StringVar(&authMethod, "auth-method", "", "only check this authentication method (dns or http)")
+
+	// Set custom usage function
+	verifyFlags.Usage = func() {
+		fmt.Fprint(os.Stdout, "Usage: mcp-publisher keygen verify-setup [flags]\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Check that a domain's DNS TXT record or well-known file has the public key\n")
+		fmt.Fprint(os.Stdout, "for a private key, the same way the registry does\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Flags:\n")
+		fmt.Fprint(os.Stdout, "  --domain string             domain to check (required)\n")
+		fmt.Fprint(os.Stdout, "  --key-file string           file containing the private key (hex seed, PKCS#8 PEM or OpenSSH)\n")
+		fmt.Fprint(os.Stdout, "  --private-key string        64-character hex seed, instead of --key-file\n")
+		fmt.Fprint(os.Stdout, "  --auth-method string        only check this authentication method (dns or http)\n")
+	}
+
+	if err := verifyFlags.Parse(os.Args[3:]); err != nil {
+		log.Fatalf("Error parsing flags: %v", err)
+	}
+
+	if domain == "" || (keyFile == "") == (privateKeyHex == "") {
+		verifyFlags.Usage()
+		return errors.New("domain and one of key-file or private-key are required")
+	}
+	if authMethod != "" && authMethod != "dns" && authMethod != "http" {
+		return fmt.Errorf("unsupported authentication method for verify-setup: %s", authMethod)
+	}
+
+	var privateKey crypto.Signer
+	var err error
+	if keyFile != "" {
+		privateKey, err = auth.LoadPrivateKeyFile(keyFile)
+	} else {
+		privateKey, err = auth.ParseSeed(privateKeyHex)
+	}
+	if err != nil {
+		return err
+	}
+	publicKey := privateKey.Public()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	checks := []struct {
+		method string
+		label  string
+		check  func() error
+	}{
+		{"dns", "DNS TXT record for " + domain, func() error {
+			return checkDNSSetup(ctx, &net.Resolver{}, domain, publicKey)
+		}},
+		{"http", "https://" + domain + wellKnownPath, func() error {
+			return checkHTTPSetup(ctx, newWellKnownClient(), "https://"+domain+wellKnownPath, publicKey)
+		}},
+	}
+
+	var passed []string
+	for _, c := range checks {
+		if authMethod != "" && c.method != authMethod {
+			continue
+		}
+		if err := c.check(); err != nil {
+			fmt.Fprintf(os.Stdout, "FAIL  %s: %v\n", c.label, err)
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "OK    %s has the public key\n", c.label)
+		passed = append(passed, c.method)
+	}
+
+	if len(passed) == 0 {
+		return fmt.Errorf("%s is not set up for DNS or HTTP authentication with this key", domain)
+	}
+	fmt.Fprintf(os.Stdout, "\nReady to publish with --auth-method %s\n", strings.Join(passed, " or "))
+	return nil
+}
+
+// checkDNSSetup checks that the domain's TXT records include the public key
+func checkDNSSetup(ctx context.Context, resolver txtResolver, domain string, publicKey crypto.PublicKey) error {
+	expected, err := keyalg.FormatRecord(publicKey)
+	if err != nil {
+		return err
+	}
+
+	records, err := resolver.LookupTXT(ctx, domain)
+	if err != nil {
+		return fmt.Errorf("failed to look up TXT records: %w", err)
+	}
+
+	publicKeys := keyalg.ParseRecords(records)
+	if len(publicKeys) == 0 {
+		return fmt.Errorf("no valid v=MCPv1 records found; add a TXT record with: %s", expected)
+	}
+	for _, key := range publicKeys {
+		if key.Equal(publicKey) {
+			return nil
+		}
+	}
+	return fmt.Errorf("found %d v=MCPv1 record(s), but none has the public key for this private key; expected: %s",
+		len(publicKeys), expected)
+}
+
+// newWellKnownClient returns an HTTP client that, like the registry, doesn't follow redirects
+func newWellKnownClient() *http.Client {
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkHTTPSetup checks that the well-known file at url has the public key
+func checkHTTPSetup(ctx context.Context, client *http.Client, url string, publicKey crypto.PublicKey) error {
+	expected, err := keyalg.FormatRecord(publicKey)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		return fmt.Errorf("redirects to %s, but the registry doesn't follow redirects", resp.Header.Get("Location"))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	// The registry reads at most 4 KiB
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4097))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(body) > 4096 {
+		return errors.New("response is larger than the 4096 bytes the registry accepts")
+	}
+
+	// Only the first record counts, as in the registry
+	key, err := keyalg.ParseRecord(strings.TrimSpace(string(body)))
+	if err != nil {
+		return fmt.Errorf("%w; the file should contain: %s", err, expected)
+	}
+	if !key.Equal(publicKey) {
+		return fmt.Errorf("has a different public key; expected: %s", expected)
+	}
+	return nil
+}
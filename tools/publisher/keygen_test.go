@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/auth/keyalg"
+	"github.com/modelcontextprotocol/registry/tools/publisher/auth"
+)
+
+type fakeResolver map[string][]string
+
+func (r fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	records, ok := r[name]
+	if !ok {
+		return nil, fmt.Errorf("no such host")
+	}
+	return records, nil
+}
+
+func generateKey(t *testing.T, algorithm string) crypto.PublicKey {
+	t.Helper()
+	privateKey, _, err := auth.GenerateKey(algorithm)
+	require.NoError(t, err)
+	return privateKey.Public()
+}
+
+func formatRecord(t *testing.T, publicKey crypto.PublicKey) string {
+	t.Helper()
+	record, err := keyalg.FormatRecord(publicKey)
+	require.NoError(t, err)
+	return record
+}
+
+func TestWriteKeyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mcp-registry.key")
+	require.NoError(t, writeKeyFile(path, []byte("abc\n"), false))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// Existing keys are only replaced with --force
+	assert.ErrorContains(t, writeKeyFile(path, []byte("def\n"), false), "already exists")
+	require.NoError(t, writeKeyFile(path, []byte("def\n"), true))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "def\n", string(data))
+}
+
+func TestCheckDNSSetup(t *testing.T) {
+	publicKey := generateKey(t, "ecdsap256")
+	otherKey := generateKey(t, "ed25519")
+	resolver := fakeResolver{
+		"example.com": {"google-site-verification=abc", formatRecord(t, otherKey), formatRecord(t, publicKey)},
+		"other.com":   {formatRecord(t, otherKey)},
+		"empty.com":   {"v=spf1 -all"},
+	}
+
+	assert.NoError(t, checkDNSSetup(context.Background(), resolver, "example.com", publicKey))
+	assert.ErrorContains(t, checkDNSSetup(context.Background(), resolver, "other.com", publicKey), "none has the public key")
+	assert.ErrorContains(t, checkDNSSetup(context.Background(), resolver, "empty.com", publicKey), "no valid v=MCPv1 records")
+	assert.ErrorContains(t, checkDNSSetup(context.Background(), resolver, "missing.com", publicKey), "failed to look up")
+}
+
+func TestCheckHTTPSetup(t *testing.T) {
+	publicKey := generateKey(t, "rsa")
+	record := formatRecord(t, publicKey)
+	otherRecord := formatRecord(t, generateKey(t, "ed25519"))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/good", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, record)
+	})
+	mux.HandleFunc("/other", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, otherRecord)
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/good", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := newWellKnownClient()
+	assert.NoError(t, checkHTTPSetup(context.Background(), client, server.URL+"/good", publicKey))
+	assert.ErrorContains(t, checkHTTPSetup(context.Background(), client, server.URL+"/other", publicKey), "different public key")
+	assert.ErrorContains(t, checkHTTPSetup(context.Background(), client, server.URL+"/redirect", publicKey), "doesn't follow redirects")
+	assert.ErrorContains(t, checkHTTPSetup(context.Background(), client, server.URL+"/missing", publicKey), "HTTP 404")
+}
@@ -0,0 +1,483 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// lifecycleOptions holds the flags shared by the commands that change published servers
+type lifecycleOptions struct {
+	auth    authOptions
+	name    string
+	version string
+	yes     bool
+}
+
+func (o *lifecycleOptions) register(flags *flag.FlagSet) {
+	o.auth.register(flags)
+	flags.StringVar(&o.name, "name", "", "server name (required)")
+	flags.StringVar(&o.version, "version", "", "server version (defaults to the latest version)")
+	flags.BoolVar(&o.yes, "yes", false, "apply the change without asking for confirmation")
+}
+
+func (o *lifecycleOptions) usage() string {
+	return "  --name string               server name (required)\n" +
+		"  --version string            server version (defaults to the latest version)\n" +
+		"  --yes                       apply the change without asking for confirmation\n" +
+		o.auth.usage()
+}
+
+func deprecateCommand() error {
+	return statusCommand("deprecate", "deprecated")
+}
+
+func undeprecateCommand() error {
+	return statusCommand("undeprecate", "active")
+}
+
+// statusCommand changes the status of a published server version
+func statusCommand(command, status string) error {
+	statusFlags := flag.NewFlagSet(command, flag.ExitOnError)
+
+	var opts lifecycleOptions
+	opts.register(statusFlags)
+
+	// Set custom usage function
+	statusFlags.Usage = func() {
+		fmt.Fprintf(os.Stdout, "Usage: mcp-publisher %s [flags]\n", command)
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprintf(os.Stdout, "Mark a published server version as %s\n", status)
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Flags:\n")
+		fmt.Fprint(os.Stdout, opts.usage())
+	}
+
+	if err := statusFlags.Parse(os.Args[2:]); err != nil {
+		log.Fatalf("Error parsing flags: %v", err)
+	}
+
+	if opts.auth.registryURL == "" || opts.name == "" {
+		statusFlags.Usage()
+		return errors.New("registry-url and name are required")
+	}
+
+	return updateServer(context.Background(), opts, func(server map[string]any) {
+		server["status"] = status
+	})
+}
+
+func updateCommand() error {
+	updateFlags := flag.NewFlagSet("update", flag.ExitOnError)
+
+	var opts lifecycleOptions
+	var mcpFilePath string
+	var description string
+
+	opts.register(updateFlags)
+	updateFlags.StringVar(&mcpFilePath, "mcp-file", "", "path to a server.json file with the new server details")
+	updateFlags.StringVar(&description, "description", "", "new server description")
+
+	// Set custom usage function
+	updateFlags.Usage = func() {
+		fmt.Fprint(os.Stdout, "Usage: mcp-publisher update [flags]\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Update the details of a published server version\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Flags:\n")
+		fmt.Fprint(os.Stdout, "  --mcp-file string           path to a server.json file with the new server details\n")
+		fmt.Fprint(os.Stdout, "  --description string        new server description\n")
+		fmt.Fprint(os.Stdout, opts.usage())
+	}
+
+	if err := updateFlags.Parse(os.Args[2:]); err != nil {
+		log.Fatalf("Error parsing flags: %v", err)
+	}
+
+	var replacement map[string]any
+	if mcpFilePath != "" {
+		var err error
+		if replacement, err = readServerFile(mcpFilePath); err != nil {
+			return err
+		}
+		// The server to update defaults to the one the file describes
+		if fileName, _ := replacement["name"].(string); opts.name == "" {
+			opts.name = fileName
+		} else if fileName != opts.name {
+			return fmt.Errorf("%s describes %q, not %q", mcpFilePath, fileName, opts.name)
+		}
+	}
+
+	if opts.auth.registryURL == "" || opts.name == "" {
+		updateFlags.Usage()
+		return errors.New("registry-url and name (or mcp-file) are required")
+	}
+	if replacement == nil && description == "" {
+		updateFlags.Usage()
+		return errors.New("one of mcp-file or description is required")
+	}
+
+	return updateServer(context.Background(), opts, func(server map[string]any) {
+		if replacement != nil {
+			clear(server)
+			for key, value := range replacement {
+				server[key] = value
+			}
+		}
+		if description != "" {
+			server["description"] = description
+		}
+	})
+}
+
+func deleteCommand() error {
+	deleteFlags := flag.NewFlagSet("delete", flag.ExitOnError)
+
+	var opts lifecycleOptions
+	opts.register(deleteFlags)
+
+	// Set custom usage function
+	deleteFlags.Usage = func() {
+		fmt.Fprint(os.Stdout, "Usage: mcp-publisher delete [flags]\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Permanently delete a published server version. This cannot be undone;\n")
+		fmt.Fprint(os.Stdout, "consider 'mcp-publisher deprecate' instead.\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Flags:\n")
+		fmt.Fprint(os.Stdout, strings.Replace(opts.usage(),
+			"server version (defaults to the latest version)", "server version (required)", 1))
+	}
+
+	if err := deleteFlags.Parse(os.Args[2:]); err != nil {
+		log.Fatalf("Error parsing flags: %v", err)
+	}
+
+	// Deleting is permanent, so the version is never implied
+	if opts.auth.registryURL == "" || opts.name == "" || opts.version == "" {
+		deleteFlags.Usage()
+		return errors.New("registry-url, name and version are required")
+	}
+
+	if err := checkConfirmable(opts.yes); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	id, server, err := findServer(ctx, opts.auth.registryURL, opts.name, opts.version)
+	if err != nil {
+		return err
+	}
+
+	printDiff(os.Stdout, server, nil)
+	if err := confirm(fmt.Sprintf("Permanently delete %s %s?", opts.name, opts.version), opts.yes); err != nil {
+		return err
+	}
+
+	token, err := opts.auth.token(ctx)
+	if err != nil {
+		return err
+	}
+	if err := sendServerRequest(ctx, http.MethodDelete, serverURL(opts.auth.registryURL, id), token, nil); err != nil {
+		return fmt.Errorf("failed to delete server: %w", err)
+	}
+
+	log.Printf("Successfully deleted %s %s", opts.name, opts.version)
+	return nil
+}
+
+// updateServer looks up a server version, applies change to a copy of it, and after
+// showing the difference and asking for confirmation, saves it to the registry
+func updateServer(ctx context.Context, opts lifecycleOptions, change func(map[string]any)) error {
+	if err := checkConfirmable(opts.yes); err != nil {
+		return err
+	}
+
+	id, server, err := findServer(ctx, opts.auth.registryURL, opts.name, opts.version)
+	if err != nil {
+		return err
+	}
+
+	updated := make(map[string]any, len(server))
+	for key, value := range server {
+		updated[key] = value
+	}
+	change(updated)
+
+	version := versionOf(server)
+	if !printDiff(os.Stdout, server, updated) {
+		log.Printf("%s %s is already up to date", opts.name, version)
+		return nil
+	}
+	if err := confirm(fmt.Sprintf("Apply these changes to %s %s?", opts.name, version), opts.yes); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(updated)
+	if err != nil {
+		return fmt.Errorf("error serializing request: %w", err)
+	}
+
+	token, err := opts.auth.token(ctx)
+	if err != nil {
+		return err
+	}
+	if err := sendServerRequest(ctx, http.MethodPut, serverURL(opts.auth.registryURL, id), token, body); err != nil {
+		return fmt.Errorf("failed to update server: %w", err)
+	}
+
+	log.Printf("Successfully updated %s %s", opts.name, version)
+	return nil
+}
+
+// findServer returns the registry ID and details of a server version, or of the latest
+// version if version is empty
+func findServer(ctx context.Context, registryURL, name, version string) (string, map[string]any, error) {
+	query := url.Values{"name": {name}}
+	if version != "" {
+		query.Set("version", version)
+	}
+	listURL := strings.TrimSuffix(registryURL, "/") + "/v0/servers?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, listURL, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, fmt.Errorf("error reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("looking up %s failed with status %d: %s", name, resp.StatusCode, body)
+	}
+
+	var list struct {
+		Servers []struct {
+			Server   map[string]any `json:"server"`
+			Registry struct {
+				ID string `json:"id"`
+			} `json:"x-io.modelcontextprotocol.registry"`
+		} `json:"servers"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return "", nil, fmt.Errorf("error parsing response: %w", err)
+	}
+
+	// Only exact matches count, in case the registry ignores the filters
+	var matches []int
+	for i, found := range list.Servers {
+		if foundName, _ := found.Server["name"].(string); foundName != name {
+			continue
+		}
+		if version != "" && versionOf(found.Server) != version {
+			continue
+		}
+		matches = append(matches, i)
+	}
+
+	switch {
+	case len(matches) == 0 && version != "":
+		return "", nil, fmt.Errorf("version %s of server %s not found", version, name)
+	case len(matches) == 0:
+		return "", nil, fmt.Errorf("server %s not found", name)
+	case len(matches) > 1:
+		return "", nil, fmt.Errorf("%d versions of server %s match; pass --version to pick one", len(matches), name)
+	}
+	found := list.Servers[matches[0]]
+	return found.Registry.ID, found.Server, nil
+}
+
+// sendServerRequest sends an authenticated request that modifies a server
+func sendServerRequest(ctx context.Context, method, url, token string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, respBody)
+	}
+	return nil
+}
+
+func serverURL(registryURL, id string) string {
+	return strings.TrimSuffix(registryURL, "/") + "/v0/servers/" + url.PathEscape(id)
+}
+
+// readServerFile reads a server.json file, or a publish request wrapping one
+func readServerFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading MCP file: %w", err)
+	}
+
+	var server map[string]any
+	if err := json.Unmarshal(data, &server); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	if wrapped, ok := server["server"].(map[string]any); ok {
+		server = wrapped
+	}
+	return server, nil
+}
+
+func versionOf(server map[string]any) string {
+	versionDetail, _ := server["version_detail"].(map[string]any)
+	version, _ := versionDetail["version"].(string)
+	return version
+}
+
+// errAborted is returned when the user doesn't confirm a change, so the command fails
+var errAborted = errors.New("aborted; nothing was changed (use --yes to skip confirmation)")
+
+// checkConfirmable refuses to run a command that would ask for confirmation when stdin is
+// not a terminal, so a script that forgot --yes fails before looking anything up
+func checkConfirmable(yes bool) error {
+	if yes {
+		return nil
+	}
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return errors.New("stdin is not a terminal; pass --yes to apply the change without confirmation")
+	}
+	return nil
+}
+
+// confirm asks the user a yes/no question on stdin, defaulting to no, and returns
+// errAborted unless they answer yes
+func confirm(question string, yes bool) error {
+	if yes {
+		return nil
+	}
+	return ask(os.Stdin, os.Stdout, question)
+}
+
+// ask writes question to out and reads the answer from in
+func ask(in io.Reader, out io.Writer, question string) error {
+	fmt.Fprintf(out, "%s [y/N]: ", question)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("error reading confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return errAborted
+	}
+}
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// printDiff writes a line diff of the indented JSON of before and after, and reports
+// whether they differ. A nil after shows everything as removed.
+func printDiff(w io.Writer, before, after map[string]any) bool {
+	beforeLines := jsonLines(before)
+	var afterLines []string
+	if after != nil {
+		afterLines = jsonLines(after)
+	}
+
+	// Longest common subsequence of lines, filled in from the end
+	lcs := make([][]int, len(beforeLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(afterLines)+1)
+	}
+	for i := len(beforeLines) - 1; i >= 0; i-- {
+		for j := len(afterLines) - 1; j >= 0; j-- {
+			if beforeLines[i] == afterLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	type diffLine struct {
+		prefix string
+		text   string
+	}
+	var lines []diffLine
+	changed := false
+	i, j := 0, 0
+	for i < len(beforeLines) || j < len(afterLines) {
+		switch {
+		case i < len(beforeLines) && j < len(afterLines) && beforeLines[i] == afterLines[j]:
+			lines = append(lines, diffLine{" ", beforeLines[i]})
+			i++
+			j++
+		case i < len(beforeLines) && (j == len(afterLines) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{"-", beforeLines[i]})
+			changed = true
+			i++
+		default:
+			lines = append(lines, diffLine{"+", afterLines[j]})
+			changed = true
+			j++
+		}
+	}
+	if !changed {
+		return false
+	}
+
+	// Only show unchanged lines close to a change
+	lastShown := -1
+	for n, line := range lines {
+		near := false
+		for k := max(0, n-diffContext); k <= min(len(lines)-1, n+diffContext); k++ {
+			if lines[k].prefix != " " {
+				near = true
+				break
+			}
+		}
+		if !near {
+			continue
+		}
+		if lastShown >= 0 && n > lastShown+1 {
+			fmt.Fprintln(w, "  ...")
+		}
+		fmt.Fprintf(w, "%s %s\n", line.prefix, line.text)
+		lastShown = n
+	}
+	return true
+}
+
+func jsonLines(value map[string]any) []string {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return []string{fmt.Sprint(value)}
+	}
+	return strings.Split(string(data), "\n")
+}
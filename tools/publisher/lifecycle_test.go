@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/tools/publisher/auth"
+)
+
+// listedServer is a server version as listed by the registry
+type listedServer struct {
+	id       string
+	name     string
+	version  string
+	isLatest bool
+}
+
+// serverRequest is a request the fake registry received to change a server
+type serverRequest struct {
+	method        string
+	path          string
+	authorization string
+	body          map[string]any
+}
+
+// newFakeRegistry serves the servers list, anonymous tokens and server changes, recording the
+// changes. With ignoreFilters, the list has every server version, like registries that don't
+// support the name and version filters.
+func newFakeRegistry(t *testing.T, servers []listedServer, ignoreFilters bool) (*httptest.Server, *[]serverRequest) {
+	t.Helper()
+	var requests []serverRequest
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v0/servers":
+			name, version := r.URL.Query().Get("name"), r.URL.Query().Get("version")
+			list := []map[string]any{}
+			for _, server := range servers {
+				if !ignoreFilters && (server.name != name || version != "" && server.version != version || version == "" && !server.isLatest) {
+					continue
+				}
+				list = append(list, map[string]any{
+					"server": map[string]any{
+						"name":           server.name,
+						"description":    "An example server",
+						"version_detail": map[string]any{"version": server.version},
+					},
+					"x-io.modelcontextprotocol.registry": map[string]any{"id": server.id},
+				})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"servers": list})
+		case r.Method == http.MethodPost && r.URL.Path == "/v0/auth/none":
+			_ = json.NewEncoder(w).Encode(auth.TokenResponse{RegistryToken: "anonymous-token"})
+		default:
+			request := serverRequest{method: r.Method, path: r.URL.Path, authorization: r.Header.Get("Authorization")}
+			if body, _ := io.ReadAll(r.Body); len(body) > 0 {
+				assert.NoError(t, json.Unmarshal(body, &request.body))
+			}
+			requests = append(requests, request)
+		}
+	}))
+	t.Cleanup(registry.Close)
+	return registry, &requests
+}
+
+func TestFindServer(t *testing.T) {
+	servers := []listedServer{
+		{id: "weather-1", name: "io.github.example/weather", version: "1.0.0"},
+		{id: "weather-2", name: "io.github.example/weather", version: "2.0.0", isLatest: true},
+		{id: "maps-1", name: "io.github.example/maps", version: "1.0.0", isLatest: true},
+	}
+
+	tests := []struct {
+		name          string
+		ignoreFilters bool
+		server        string
+		version       string
+		expectedID    string
+		errMsg        string
+	}{
+		{name: "latest version", server: "io.github.example/weather", expectedID: "weather-2"},
+		{name: "given version", server: "io.github.example/weather", version: "1.0.0", expectedID: "weather-1"},
+		{name: "unknown server", server: "io.github.example/unknown", errMsg: "server io.github.example/unknown not found"},
+		{name: "unknown version", server: "io.github.example/weather", version: "3.0.0", errMsg: "version 3.0.0 of server io.github.example/weather not found"},
+		{name: "other servers are ignored", ignoreFilters: true, server: "io.github.example/maps", expectedID: "maps-1"},
+		{name: "other versions are ignored", ignoreFilters: true, server: "io.github.example/weather", version: "1.0.0", expectedID: "weather-1"},
+		{name: "several matches", ignoreFilters: true, server: "io.github.example/weather", errMsg: "2 versions of server io.github.example/weather match"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, _ := newFakeRegistry(t, servers, tt.ignoreFilters)
+
+			id, server, err := findServer(context.Background(), registry.URL+"/", tt.server, tt.version)
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedID, id)
+			assert.Equal(t, tt.server, server["name"])
+		})
+	}
+}
+
+func TestLifecycleCommands(t *testing.T) {
+	t.Setenv(auth.ConfigDirEnv, t.TempDir())
+
+	servers := []listedServer{
+		{id: "weather-1", name: "io.github.example/weather", version: "1.0.0"},
+		{id: "weather-2", name: "io.github.example/weather", version: "2.0.0", isLatest: true},
+	}
+
+	tests := []struct {
+		name     string
+		command  func() error
+		args     []string
+		expected serverRequest
+	}{
+		{
+			name:    "deprecate the latest version",
+			command: deprecateCommand,
+			args:    []string{"deprecate"},
+			expected: serverRequest{method: http.MethodPut, path: "/v0/servers/weather-2", body: map[string]any{
+				"name":           "io.github.example/weather",
+				"description":    "An example server",
+				"status":         "deprecated",
+				"version_detail": map[string]any{"version": "2.0.0"},
+			}},
+		},
+		{
+			name:    "undeprecate a version",
+			command: undeprecateCommand,
+			args:    []string{"undeprecate", "--version", "1.0.0"},
+			expected: serverRequest{method: http.MethodPut, path: "/v0/servers/weather-1", body: map[string]any{
+				"name":           "io.github.example/weather",
+				"description":    "An example server",
+				"status":         "active",
+				"version_detail": map[string]any{"version": "1.0.0"},
+			}},
+		},
+		{
+			name:    "update the description",
+			command: updateCommand,
+			args:    []string{"update", "--description", "A better example server"},
+			expected: serverRequest{method: http.MethodPut, path: "/v0/servers/weather-2", body: map[string]any{
+				"name":           "io.github.example/weather",
+				"description":    "A better example server",
+				"version_detail": map[string]any{"version": "2.0.0"},
+			}},
+		},
+		{
+			name:     "delete a version",
+			command:  deleteCommand,
+			args:     []string{"delete", "--version", "1.0.0"},
+			expected: serverRequest{method: http.MethodDelete, path: "/v0/servers/weather-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, requests := newFakeRegistry(t, servers, false)
+
+			args := os.Args
+			t.Cleanup(func() { os.Args = args })
+			os.Args = append([]string{"mcp-publisher"}, tt.args...)
+			os.Args = append(os.Args, "--registry-url", registry.URL, "--auth-method", "none",
+				"--name", "io.github.example/weather", "--yes")
+
+			require.NoError(t, tt.command())
+
+			tt.expected.authorization = "Bearer anonymous-token"
+			assert.Equal(t, []serverRequest{tt.expected}, *requests)
+		})
+	}
+}
+
+func TestLifecycleCommandsWithoutConfirmation(t *testing.T) {
+	t.Setenv(auth.ConfigDirEnv, t.TempDir())
+	servers := []listedServer{{id: "weather-1", name: "io.github.example/weather", version: "1.0.0", isLatest: true}}
+
+	// A script that forgot --yes fails before anything is changed
+	stdinReader, stdinWriter, err := os.Pipe()
+	require.NoError(t, err)
+	defer stdinReader.Close()
+	require.NoError(t, stdinWriter.Close())
+	stdin := os.Stdin
+	t.Cleanup(func() { os.Stdin = stdin })
+	os.Stdin = stdinReader
+
+	for _, command := range []struct {
+		name string
+		run  func() error
+	}{{"deprecate", deprecateCommand}, {"delete", deleteCommand}} {
+		t.Run(command.name, func(t *testing.T) {
+			registry, requests := newFakeRegistry(t, servers, false)
+
+			args := os.Args
+			t.Cleanup(func() { os.Args = args })
+			os.Args = []string{"mcp-publisher", command.name, "--registry-url", registry.URL, "--auth-method", "none",
+				"--name", "io.github.example/weather", "--version", "1.0.0"}
+
+			err := command.run()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "--yes")
+			assert.Empty(t, *requests)
+		})
+	}
+}
+
+func TestAsk(t *testing.T) {
+	for answer, expected := range map[string]error{
+		"y\n":   nil,
+		"YES\n": nil,
+		"n\n":   errAborted,
+		"\n":    errAborted,
+		"":      errAborted, // end of input
+	} {
+		var out bytes.Buffer
+		assert.Equal(t, expected, ask(strings.NewReader(answer), &out, "Apply?"), "answer %q", answer)
+		assert.Equal(t, "Apply? [y/N]: ", out.String())
+	}
+}
+
+func TestPrintDiff(t *testing.T) {
+	before := map[string]any{
+		"name":           "io.github.example/server",
+		"description":    "An example server",
+		"version_detail": map[string]any{"version": "1.0.0"},
+	}
+
+	var out bytes.Buffer
+	assert.False(t, printDiff(&out, before, before))
+	assert.Empty(t, out.String())
+
+	after := map[string]any{
+		"name":           "io.github.example/server",
+		"description":    "A better example server",
+		"status":         "deprecated",
+		"version_detail": map[string]any{"version": "1.0.0"},
+	}
+	assert.True(t, printDiff(&out, before, after))
+	assert.Equal(t, `  {
+-   "description": "An example server",
++   "description": "A better example server",
+    "name": "io.github.example/server",
++   "status": "deprecated",
+    "version_detail": {
+      "version": "1.0.0"
+    }
+`, out.String())
+}
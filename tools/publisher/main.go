@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
@@ -11,6 +12,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"runtime"
 	"strings"
 
 	"github.com/modelcontextprotocol/registry/tools/publisher/auth"
@@ -51,13 +53,19 @@ type Package struct {
 	EnvironmentVariables []EnvironmentVariable `json:"environment_variables,omitempty"`
 }
 
+type Remote struct {
+	TransportType string `json:"transport_type"`
+	URL           string `json:"url"`
+}
+
 type ServerJSON struct {
 	Name          string        `json:"name"`
 	Description   string        `json:"description"`
 	Status        string        `json:"status,omitempty"`
 	Repository    Repository    `json:"repository"`
 	VersionDetail VersionDetail `json:"version_detail"`
-	Packages      []Package     `json:"packages"`
+	Packages      []Package     `json:"packages,omitempty"`
+	Remotes       []Remote      `json:"remotes,omitempty"`
 }
 
 func main() {
@@ -72,6 +80,26 @@ func main() {
 		err = publishCommand()
 	case "create":
 		err = createCommand()
+	case "init":
+		err = initCommand()
+	case "validate":
+		err = validateCommand()
+	case "update":
+		err = updateCommand()
+	case "deprecate":
+		err = deprecateCommand()
+	case "undeprecate":
+		err = undeprecateCommand()
+	case "delete":
+		err = deleteCommand()
+	case "login":
+		err = loginCommand()
+	case "logout":
+		err = logoutCommand()
+	case "auth":
+		err = authCommand()
+	case "keygen":
+		err = keygenCommand()
 	default:
 		printUsage()
 	}
@@ -85,32 +113,235 @@ func printUsage() {
 	fmt.Fprint(os.Stdout, "\n")
 	fmt.Fprint(os.Stdout, "Usage:\n")
 	fmt.Fprint(os.Stdout, "  mcp-publisher publish [flags]    Publish a server.json file to the registry\n")
+	fmt.Fprint(os.Stdout, "  mcp-publisher init [flags]       Create a server.json file from the project in this directory\n")
 	fmt.Fprint(os.Stdout, "  mcp-publisher create [flags]     Create a new server.json file\n")
+	fmt.Fprint(os.Stdout, "  mcp-publisher validate [file]    Validate a server.json file\n")
+	fmt.Fprint(os.Stdout, "  mcp-publisher update [flags]     Update a published server version\n")
+	fmt.Fprint(os.Stdout, "  mcp-publisher deprecate [flags]  Mark a published server version as deprecated\n")
+	fmt.Fprint(os.Stdout, "  mcp-publisher undeprecate [flags] Mark a deprecated server version as active again\n")
+	fmt.Fprint(os.Stdout, "  mcp-publisher delete [flags]     Permanently delete a published server version\n")
+	fmt.Fprint(os.Stdout, "  mcp-publisher login [flags]      Log in to a registry and store the credentials\n")
+	fmt.Fprint(os.Stdout, "  mcp-publisher logout [flags]     Remove the stored credentials for a registry\n")
+	fmt.Fprint(os.Stdout, "  mcp-publisher auth status        Show the stored credentials\n")
+	fmt.Fprint(os.Stdout, "  mcp-publisher keygen [flags]     Generate a key for DNS or HTTP authentication\n")
 	fmt.Fprint(os.Stdout, "\n")
 	fmt.Fprint(os.Stdout, "Use 'mcp-publisher <command> --help' for more information about a command.\n")
 }
 
+// authOptions holds the flags shared by every command that authenticates with the registry
+type authOptions struct {
+	registryURL    string
+	forceLogin     bool
+	method         string
+	dnsDomain      string
+	dnsPrivateKey  string
+	httpDomain     string
+	httpPrivateKey string
+	privateKeyFile string
+	privateKeyEnv  string
+	signerCommand  string
+	githubHost     string
+	githubOAuthURL string
+	githubClientID string
+}
+
+// privateKeyEnvVar holds the DNS or HTTP signing key when no other key source is given
+const privateKeyEnvVar = "MCP_PUBLISHER_PRIVATE_KEY"
+
+// register adds the registry and authentication flags to a command's flag set
+func (o *authOptions) register(flags *flag.FlagSet) {
+	flags.StringVar(&o.registryURL, "registry-url", "", "URL of the registry (required)")
+	flags.BoolVar(&o.forceLogin, "login", false, "force a new login even if a token exists")
+	flags.StringVar(&o.method, "auth-method", "github-at", "authentication method (default: github-at)")
+	flags.StringVar(&o.dnsDomain, "dns-domain", "", "domain name for DNS authentication (required for dns auth method)")
+	flags.StringVar(&o.dnsPrivateKey, "dns-private-key", "", "64-character hex seed for DNS authentication (prefer --private-key-file)")
+	flags.StringVar(&o.httpDomain, "http-domain", "", "domain name for HTTP authentication (required for http auth method)")
+	flags.StringVar(&o.httpPrivateKey, "http-private-key", "", "64-character hex seed for HTTP authentication (prefer --private-key-file)")
+	flags.StringVar(&o.privateKeyFile, "private-key-file", "", "file with the private key for DNS or HTTP authentication (hex seed, PEM or OpenSSH)")
+	flags.StringVar(&o.privateKeyEnv, "private-key-env", "", "environment variable with the private key for DNS or HTTP authentication")
+	flags.StringVar(&o.signerCommand, "signer-command", "", "command that signs for DNS or HTTP authentication, reading the message on stdin")
+	flags.StringVar(&o.githubHost, "github-host", auth.GitHubHost, "GitHub host to log in to with github-at, such as a GitHub Enterprise Server")
+	flags.StringVar(&o.githubOAuthURL, "github-oauth-url", "", "base URL of the GitHub OAuth endpoints (default: from the registry)")
+	flags.StringVar(&o.githubClientID, "github-client-id", "", "client ID of the GitHub app to log in with (default: from the registry)")
+}
+
+// usage is the help text for the flags added by register
+func (o *authOptions) usage() string {
+	return "  --registry-url string       URL of the registry (required)\n" +
+		"  --login                     force a new login even if a token exists\n" +
+		"  --auth-method string        authentication method (default: github-at)\n" +
+		"  --dns-domain string         domain name for DNS authentication\n" +
+		"  --dns-private-key string    64-character hex seed for DNS authentication (prefer --private-key-file)\n" +
+		"  --http-domain string        domain name for HTTP authentication\n" +
+		"  --http-private-key string   64-character hex seed for HTTP authentication (prefer --private-key-file)\n" +
+		"  --private-key-file string   file with the private key for DNS or HTTP authentication\n" +
+		"                              (hex seed, PEM or OpenSSH)\n" +
+		"  --private-key-env string    environment variable with the private key for DNS or HTTP authentication\n" +
+		"                              (default: " + privateKeyEnvVar + ")\n" +
+		"  --signer-command string     command that signs for DNS or HTTP authentication: it reads the message\n" +
+		"                              on stdin and prints the signature in hex or base64\n" +
+		"  --github-host string        GitHub host to log in to with github-at, such as a GitHub Enterprise Server\n" +
+		"                              (default: github.com)\n" +
+		"  --github-oauth-url string   base URL of the GitHub OAuth endpoints (default: from the registry)\n" +
+		"  --github-client-id string   client ID of the GitHub app to log in with (default: from the registry)\n"
+}
+
+// provider returns the selected authentication provider, keeping its tokens in the
+// credential store under the user's config directory
+//
+//nolint:ireturn // auth.Provider is the expected interface type for providers
+func (o *authOptions) provider() (auth.Provider, error) {
+	store, err := auth.DefaultCredentialStore()
+	if err != nil {
+		return nil, err
+	}
+
+	var authProvider auth.Provider // Determine the authentication method
+	storeMethod := o.method
+	switch o.method {
+	case "github-at":
+		log.Println("Using GitHub Access Token for authentication")
+		warnLegacyTokenFiles()
+		authProvider = auth.NewGitHubATProvider(o.forceLogin, o.registryURL, auth.GitHubInstance{
+			Host:     strings.ToLower(o.githubHost),
+			OAuthURL: o.githubOAuthURL,
+			ClientID: o.githubClientID,
+		}, store)
+		storeMethod = auth.GitHubATMethod(strings.ToLower(o.githubHost))
+	case "github-oidc":
+		// Registry tokens from OIDC are bound to the repository and workflow that ran, so they are
+		// never stored where another workflow on a shared runner could reuse them
+		log.Println("Using GitHub Actions OIDC for authentication")
+		return auth.NewGitHubOIDCProvider(o.registryURL), nil
+	case "dns":
+		log.Println("Using DNS-based authentication")
+		signer, err := o.signer("--dns-private-key", o.dnsPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		authProvider = auth.NewDNSProvider(o.registryURL, o.dnsDomain, signer)
+		storeMethod = auth.DomainMethod(o.method, o.dnsDomain)
+	case "http":
+		log.Println("Using HTTP-based authentication")
+		signer, err := o.signer("--http-private-key", o.httpPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		authProvider = auth.NewHTTPProvider(o.registryURL, o.httpDomain, signer)
+		storeMethod = auth.DomainMethod(o.method, o.httpDomain)
+	case "none":
+		// Anonymous tokens are free to get, so there is nothing worth storing
+		log.Println("Using anonymous authentication")
+		return auth.NewNoneProvider(o.registryURL), nil
+	default:
+		return nil, fmt.Errorf("unsupported authentication method: %s", o.method)
+	}
+
+	return auth.NewStoredProvider(authProvider, store, o.registryURL, storeMethod, o.forceLogin), nil
+}
+
+// signer returns the signer for DNS or HTTP authentication from whichever key source was given:
+// a signer command, a key file, an environment variable or the hex seed flag
+//
+//nolint:ireturn // auth.Signer is the expected interface type for signers
+func (o *authOptions) signer(hexSeedFlag, hexSeed string) (auth.Signer, error) {
+	sources := 0
+	for _, source := range []string{o.signerCommand, o.privateKeyFile, o.privateKeyEnv, hexSeed} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return nil, fmt.Errorf("use only one of --signer-command, --private-key-file, --private-key-env and %s", hexSeedFlag)
+	}
+
+	switch {
+	case o.signerCommand != "":
+		return auth.NewCommandSigner(o.signerCommand)
+	case o.privateKeyFile != "":
+		warnReadableKeyFile(o.privateKeyFile)
+		privateKey, err := auth.LoadPrivateKeyFile(o.privateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		return auth.NewKeySigner(privateKey), nil
+	case hexSeed != "":
+		log.Printf("Warning: %s leaves the private key in shell history and process listings; prefer --private-key-file, --private-key-env or --signer-command", hexSeedFlag)
+		privateKey, err := auth.ParseSeed(hexSeed)
+		if err != nil {
+			return nil, err
+		}
+		return auth.NewKeySigner(privateKey), nil
+	}
+
+	envVar := cmp.Or(o.privateKeyEnv, privateKeyEnvVar)
+	value := os.Getenv(envVar)
+	if value == "" {
+		if o.privateKeyEnv != "" {
+			return nil, fmt.Errorf("environment variable %s is not set", envVar)
+		}
+		return nil, fmt.Errorf("%s authentication needs a private key: use --private-key-file, --private-key-env, --signer-command or set %s",
+			o.method, privateKeyEnvVar)
+	}
+	privateKey, err := auth.ParsePrivateKey([]byte(value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key in %s: %w", envVar, err)
+	}
+	return auth.NewKeySigner(privateKey), nil
+}
+
+// warnReadableKeyFile points out private key files that other users can read
+func warnReadableKeyFile(path string) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+	if info, err := os.Stat(path); err == nil && info.Mode().Perm()&0077 != 0 {
+		log.Printf("Warning: %s can be read by other users; restrict it with 'chmod 600 %s'", path, path)
+	}
+}
+
+// token logs in with the selected authentication method if needed and returns a registry token
+func (o *authOptions) token(ctx context.Context) (string, error) {
+	authProvider, err := o.provider()
+	if err != nil {
+		return "", err
+	}
+
+	// Check if login is needed and perform authentication
+	if o.forceLogin || authProvider.NeedsLogin() {
+		err := authProvider.Login(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to authenticate with %s: %w", authProvider.Name(), err)
+		}
+	}
+
+	// Get the token
+	token, err := authProvider.GetToken(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error getting token from %s: %w", authProvider.Name(), err)
+	}
+	return token, nil
+}
+
+// warnLegacyTokenFiles points out token files written to the working directory by older
+// versions, which are no longer used and should not be committed
+func warnLegacyTokenFiles() {
+	for _, name := range []string{".mcpregistry_github_token", ".mcpregistry_registry_token"} {
+		if _, err := os.Stat(name); err == nil {
+			log.Printf("Warning: %s is no longer used, as tokens are kept in the credential store; delete it", name)
+		}
+	}
+}
+
 func publishCommand() error {
 	publishFlags := flag.NewFlagSet("publish", flag.ExitOnError)
 
-	var registryURL string
+	var authOpts authOptions
 	var mcpFilePath string
-	var forceLogin bool
-	var authMethod string
-	var dnsDomain string
-	var dnsPrivateKey string
-	var httpDomain string
-	var httpPrivateKey string
 
 	// Command-line flags for configuration
-	publishFlags.StringVar(&registryURL, "registry-url", "", "URL of the registry (required)")
+	authOpts.register(publishFlags)
 	publishFlags.StringVar(&mcpFilePath, "mcp-file", "", "path to the MCP file (required)")
-	publishFlags.BoolVar(&forceLogin, "login", false, "force a new login even if a token exists")
-	publishFlags.StringVar(&authMethod, "auth-method", "github-at", "authentication method (default: github-at)")
-	publishFlags.StringVar(&dnsDomain, "dns-domain", "", "domain name for DNS authentication (required for dns auth method)")
-	publishFlags.StringVar(&dnsPrivateKey, "dns-private-key", "", "64-character hex seed for DNS authentication (required for dns auth method)")
-	publishFlags.StringVar(&httpDomain, "http-domain", "", "domain name for HTTP authentication (required for http auth method)")
-	publishFlags.StringVar(&httpPrivateKey, "http-private-key", "", "64-character hex seed for HTTP authentication (required for http auth method)")
 
 	// Set custom usage function
 	publishFlags.Usage = func() {
@@ -119,21 +350,15 @@ func publishCommand() error {
 		fmt.Fprint(os.Stdout, "Publish a server.json file to the registry\n")
 		fmt.Fprint(os.Stdout, "\n")
 		fmt.Fprint(os.Stdout, "Flags:\n")
-		fmt.Fprint(os.Stdout, "  --registry-url string       URL of the registry (required)\n")
 		fmt.Fprint(os.Stdout, "  --mcp-file string           path to the MCP file (required)\n")
-		fmt.Fprint(os.Stdout, "  --login                     force a new login even if a token exists\n")
-		fmt.Fprint(os.Stdout, "  --auth-method string        authentication method (default: github-at)\n")
-		fmt.Fprint(os.Stdout, "  --dns-domain string         domain name for DNS authentication\n")
-		fmt.Fprint(os.Stdout, "  --dns-private-key string    64-character hex seed for DNS authentication\n")
-		fmt.Fprint(os.Stdout, "  --http-domain string        domain name for HTTP authentication\n")
-		fmt.Fprint(os.Stdout, "  --http-private-key string   64-character hex seed for HTTP authentication\n")
+		fmt.Fprint(os.Stdout, authOpts.usage())
 	}
 
 	if err := publishFlags.Parse(os.Args[2:]); err != nil {
 		log.Fatalf("Error parsing flags: %v", err)
 	}
 
-	if registryURL == "" || mcpFilePath == "" {
+	if authOpts.registryURL == "" || mcpFilePath == "" {
 		publishFlags.Usage()
 		return errors.New("registry-url and mcp-file are required")
 	}
@@ -144,44 +369,13 @@ func publishCommand() error {
 		return fmt.Errorf("error reading MCP file: %w", err)
 	}
 
-	var authProvider auth.Provider // Determine the authentication method
-	switch authMethod {
-	case "github-at":
-		log.Println("Using GitHub Access Token for authentication")
-		authProvider = auth.NewGitHubATProvider(forceLogin, registryURL)
-	case "github-oidc":
-		log.Println("Using GitHub Actions OIDC for authentication")
-		authProvider = auth.NewGitHubOIDCProvider(registryURL)
-	case "dns":
-		log.Println("Using DNS-based authentication")
-		authProvider = auth.NewDNSProvider(registryURL, dnsDomain, dnsPrivateKey)
-	case "http":
-		log.Println("Using HTTP-based authentication")
-		authProvider = auth.NewHTTPProvider(registryURL, httpDomain, httpPrivateKey)
-	case "none":
-		log.Println("Using anonymous authentication")
-		authProvider = auth.NewNoneProvider(registryURL)
-	default:
-		return fmt.Errorf("unsupported authentication method: %s", authMethod)
-	}
-
-	// Check if login is needed and perform authentication
-	ctx := context.Background()
-	if authProvider.NeedsLogin() {
-		err := authProvider.Login(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to authenticate with %s: %w", authProvider.Name(), err)
-		}
-	}
-
-	// Get the token
-	token, err := authProvider.GetToken(ctx)
+	token, err := authOpts.token(context.Background())
 	if err != nil {
-		return fmt.Errorf("error getting token from %s: %w", authProvider.Name(), err)
+		return err
 	}
 
 	// Publish to registry
-	err = publishToRegistry(registryURL, mcpData, token)
+	err = publishToRegistry(authOpts.registryURL, mcpData, token)
 	if err != nil {
 		return fmt.Errorf("failed to publish to registry: %w", err)
 	}
@@ -0,0 +1,397 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	jsonschema "github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// The schemas are copies of docs/server-json, kept in sync by tools/validate-schemas
+//
+//go:embed schemas/schema.json schemas/registry-schema.json
+var schemaFS embed.FS
+
+const (
+	serverSchemaURL   = "https://modelcontextprotocol.io/schemas/draft/2025-07-09/server.json"
+	registrySchemaURL = "https://modelcontextprotocol.io/schemas/draft/2025-07-09/registry-server.json"
+)
+
+// Severity of a validation issue
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is a single problem found in a server.json file
+type Issue struct {
+	Severity Severity
+	Pointer  string // JSON pointer to the offending value
+	Line     int
+	Column   int
+	Message  string
+}
+
+func validateCommand() error {
+	validateFlags := flag.NewFlagSet("validate", flag.ExitOnError)
+
+	var strict bool
+	validateFlags.BoolVar(&strict, "strict", false, "treat warnings as errors")
+
+	// Set custom usage function
+	validateFlags.Usage = func() {
+		fmt.Fprint(os.Stdout, "Usage: mcp-publisher validate [flags] [file]\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Validate a server.json file against the server.json and registry schemas\n")
+		fmt.Fprint(os.Stdout, "and check it for common mistakes. The file defaults to server.json.\n")
+		fmt.Fprint(os.Stdout, "\n")
+		fmt.Fprint(os.Stdout, "Flags:\n")
+		fmt.Fprint(os.Stdout, "  --strict                    treat warnings as errors\n")
+	}
+
+	if err := validateFlags.Parse(os.Args[2:]); err != nil {
+		return fmt.Errorf("error parsing flags: %w", err)
+	}
+
+	path := "server.json"
+	switch validateFlags.NArg() {
+	case 0:
+	case 1:
+		path = validateFlags.Arg(0)
+	default:
+		validateFlags.Usage()
+		return errors.New("validate accepts at most one file")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	issues, err := validateServerJSON(data)
+	if err != nil {
+		return err
+	}
+
+	var errorCount, warningCount int
+	for _, issue := range issues {
+		if issue.Severity == SeverityError || strict {
+			errorCount++
+		} else {
+			warningCount++
+		}
+		fmt.Fprintf(os.Stdout, "%s:%d:%d: %s: %s", path, issue.Line, issue.Column, issue.Severity, issue.Message)
+		if issue.Pointer != "" {
+			fmt.Fprintf(os.Stdout, " (at %s)", issue.Pointer)
+		}
+		fmt.Fprint(os.Stdout, "\n")
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("%s is invalid: %d error(s), %d warning(s)", path, errorCount, warningCount)
+	}
+	fmt.Fprintf(os.Stdout, "%s is valid (%d warning(s))\n", path, warningCount)
+	return nil
+}
+
+// validateServerJSON checks a server.json file, or a publish request wrapping one, and
+// returns the issues found sorted by position. Errors are only returned if the
+// embedded schemas cannot be loaded.
+func validateServerJSON(data []byte) ([]Issue, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return []Issue{syntaxIssue(data, err)}, nil
+	}
+	offsets := locateValues(data)
+
+	var issues []Issue
+	report := func(severity Severity, pointer, message string) {
+		line, column := position(data, offsets[pointer])
+		issues = append(issues, Issue{
+			Severity: severity,
+			Pointer:  pointer,
+			Line:     line,
+			Column:   column,
+			Message:  message,
+		})
+	}
+
+	// Like the publish endpoint, accept both a bare server.json and a publish request
+	server, prefix := doc, ""
+	if object, ok := doc.(map[string]any); ok {
+		if wrapped, exists := object["server"]; exists {
+			server, prefix = wrapped, "/server"
+			for key := range object {
+				if key != "server" && key != "x-publisher" {
+					report(SeverityError, "/"+escapePointer(key),
+						fmt.Sprintf("unexpected property %q: only 'server' and 'x-publisher' are allowed", key))
+				}
+			}
+		}
+	}
+
+	schema, err := compileRegistrySchema()
+	if err != nil {
+		return nil, err
+	}
+	if err := schema.Validate(server); err != nil {
+		var validationErr *jsonschema.ValidationError
+		if !errors.As(err, &validationErr) {
+			return nil, fmt.Errorf("error validating against schema: %w", err)
+		}
+		seen := make(map[string]bool)
+		for _, leaf := range schemaLeaves(validationErr) {
+			pointer := prefix + leaf.InstanceLocation
+			if key := pointer + "\x00" + leaf.Message; !seen[key] {
+				seen[key] = true
+				report(SeverityError, pointer, leaf.Message)
+			}
+		}
+	}
+
+	if object, ok := server.(map[string]any); ok {
+		lintServer(object, prefix, report)
+	}
+
+	slices.SortStableFunc(issues, func(a, b Issue) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return a.Column - b.Column
+	})
+	return issues, nil
+}
+
+func compileRegistrySchema() (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+
+	for url, name := range map[string]string{
+		serverSchemaURL:   "schemas/schema.json",
+		registrySchemaURL: "schemas/registry-schema.json",
+	} {
+		schemaData, err := schemaFS.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("error reading embedded schema: %w", err)
+		}
+		if err := compiler.AddResource(url, bytes.NewReader(schemaData)); err != nil {
+			return nil, fmt.Errorf("error loading embedded schema %s: %w", name, err)
+		}
+	}
+
+	return compiler.Compile(registrySchemaURL)
+}
+
+// schemaLeaves flattens a validation error into the errors that caused it
+func schemaLeaves(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		leaves = append(leaves, schemaLeaves(cause)...)
+	}
+	return leaves
+}
+
+// lintServer runs checks the schemas cannot express
+func lintServer(server map[string]any, prefix string, report func(Severity, string, string)) {
+	name, _ := server["name"].(string)
+	if name != "" && !strings.Contains(name, "/") {
+		report(SeverityError, prefix+"/name", "server name must be in the form namespace/name, e.g. io.github.owner/repo")
+	}
+
+	if versionDetail, ok := server["version_detail"].(map[string]any); ok {
+		if version, _ := versionDetail["version"].(string); version == "latest" {
+			report(SeverityError, prefix+"/version_detail/version", "server version must be a specific version, not 'latest'")
+		}
+	}
+
+	// A GitHub namespace should match the repository it points to
+	if owner, found := strings.CutPrefix(name, "io.github."); found {
+		owner, _, _ = strings.Cut(owner, "/")
+		if repository, ok := server["repository"].(map[string]any); ok {
+			url, _ := repository["url"].(string)
+			if url != "" && !strings.HasPrefix(strings.ToLower(url), "https://github.com/"+strings.ToLower(owner)+"/") {
+				report(SeverityWarning, prefix+"/repository/url",
+					fmt.Sprintf("repository is not owned by %q, the owner in the server name", owner))
+			}
+		}
+	}
+
+	packages, _ := server["packages"].([]any)
+	remotes, _ := server["remotes"].([]any)
+	if len(packages) == 0 && len(remotes) == 0 {
+		report(SeverityWarning, prefix, "server has no packages or remotes, so clients cannot run it")
+	}
+
+	for i, item := range packages {
+		pkg, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		pointer := prefix + "/packages/" + strconv.Itoa(i)
+		if version, _ := pkg["version"].(string); version == "latest" {
+			report(SeverityWarning, pointer+"/version", "package version 'latest' is not reproducible; pin a specific version")
+		}
+		lintInputs(pkg, pointer, "runtime_arguments", "runtime argument", report)
+		lintInputs(pkg, pointer, "package_arguments", "package argument", report)
+		lintInputs(pkg, pointer, "environment_variables", "environment variable", report)
+	}
+
+	for i, item := range remotes {
+		if remote, ok := item.(map[string]any); ok {
+			lintInputs(remote, prefix+"/remotes/"+strconv.Itoa(i), "headers", "header", report)
+		}
+	}
+}
+
+// lintInputs checks every input in the named list of parent, including their variables
+func lintInputs(parent map[string]any, pointer, field, kind string, report func(Severity, string, string)) {
+	inputs, _ := parent[field].([]any)
+	names := make(map[string]bool)
+	for i, item := range inputs {
+		input, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		inputPointer := pointer + "/" + field + "/" + strconv.Itoa(i)
+		label := kind
+		if name, _ := input["name"].(string); name != "" {
+			label = fmt.Sprintf("%s %q", kind, name)
+			// Named arguments may be repeated, but variables and headers only make sense once
+			if field == "environment_variables" || field == "headers" {
+				if names[name] {
+					report(SeverityError, inputPointer+"/name", fmt.Sprintf("duplicate %s", label))
+				}
+				names[name] = true
+			}
+		}
+		lintInput(input, inputPointer, label, report)
+
+		variables, _ := input["variables"].(map[string]any)
+		for _, variableName := range slices.Sorted(maps.Keys(variables)) {
+			if variable, ok := variables[variableName].(map[string]any); ok {
+				lintInput(variable, inputPointer+"/variables/"+escapePointer(variableName),
+					fmt.Sprintf("variable %q of %s", variableName, label), report)
+			}
+		}
+	}
+}
+
+func lintInput(input map[string]any, pointer, label string, report func(Severity, string, string)) {
+	isRequired, _ := input["is_required"].(bool)
+	isSecret, _ := input["is_secret"].(bool)
+	description, _ := input["description"].(string)
+	defaultValue, hasDefault := input["default"].(string)
+	value, hasValue := input["value"].(string)
+
+	if isRequired && strings.TrimSpace(description) == "" {
+		report(SeverityError, pointer, fmt.Sprintf("required %s has no description", label))
+	}
+	if isSecret && hasDefault && defaultValue != "" {
+		report(SeverityError, pointer+"/default", fmt.Sprintf("secret %s must not have a default", label))
+	}
+	if isSecret && hasValue && value != "" && !strings.Contains(value, "{") {
+		report(SeverityWarning, pointer+"/value", fmt.Sprintf("secret %s has a fixed value that will be published", label))
+	}
+	if choices, ok := input["choices"].([]any); ok && len(choices) > 0 && hasDefault && !slices.Contains(choices, any(defaultValue)) {
+		report(SeverityError, pointer+"/default", fmt.Sprintf("default of %s is not one of its choices", label))
+	}
+}
+
+// syntaxIssue converts a JSON decoding error to an issue at the offending byte
+func syntaxIssue(data []byte, err error) Issue {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		// The offset is just after the offending character
+		offset = syntaxErr.Offset - 1
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		offset = int64(len(data))
+	}
+	line, column := position(data, int(offset))
+	return Issue{
+		Severity: SeverityError,
+		Line:     line,
+		Column:   column,
+		Message:  "invalid JSON: " + err.Error(),
+	}
+}
+
+// locateValues maps the JSON pointer of every value in a valid JSON document to the
+// byte offset at which it starts
+func locateValues(data []byte) map[string]int {
+	offsets := make(map[string]int)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	// start skips the whitespace and separators between the last token and the next value
+	start := func() int {
+		offset := int(decoder.InputOffset())
+		for offset < len(data) && strings.IndexByte(" \t\r\n:,", data[offset]) >= 0 {
+			offset++
+		}
+		return offset
+	}
+
+	var walk func(pointer string) error
+	walk = func(pointer string) error {
+		offsets[pointer] = start()
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case json.Delim('{'):
+			for decoder.More() {
+				key, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+				if err := walk(pointer + "/" + escapePointer(key.(string))); err != nil {
+					return err
+				}
+			}
+			_, err = decoder.Token()
+		case json.Delim('['):
+			for i := 0; decoder.More(); i++ {
+				if err := walk(pointer + "/" + strconv.Itoa(i)); err != nil {
+					return err
+				}
+			}
+			_, err = decoder.Token()
+		}
+		return err
+	}
+
+	// The document has already been decoded, so walking it cannot fail
+	_ = walk("")
+	return offsets
+}
+
+// position converts a byte offset to a 1-based line and column
+func position(data []byte, offset int) (int, int) {
+	offset = min(max(offset, 0), len(data))
+	line := 1 + bytes.Count(data[:offset], []byte("\n"))
+	column := 1 + offset - (bytes.LastIndexByte(data[:offset], '\n') + 1)
+	return line, column
+}
+
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateServerJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		expected []Issue
+	}{
+		{
+			name: "valid server",
+			data: `{
+  "name": "io.github.example/server",
+  "description": "An example server",
+  "repository": {"url": "https://github.com/example/server", "source": "github"},
+  "version_detail": {"version": "1.0.0"},
+  "packages": [{"registry_name": "npm", "name": "@example/server", "version": "1.0.0"}]
+}`,
+		},
+		{
+			name: "invalid JSON",
+			data: "{\n  \"name\": ,\n}",
+			expected: []Issue{{
+				Severity: SeverityError, Line: 2, Column: 11,
+				Message: "invalid JSON: invalid character ',' looking for beginning of value",
+			}},
+		},
+		{
+			name: "schema and semantic errors",
+			data: `{
+  "server": {
+    "name": "io.github.example/server",
+    "description": "An example server",
+    "repository": {"url": "https://github.com/example/server", "source": "gitlab"},
+    "version_detail": {"version": "1.0.0"},
+    "packages": [{
+      "registry_name": "npm", "name": "@example/server", "version": "1.0.0",
+      "environment_variables": [
+        {"name": "API_KEY", "is_required": true, "is_secret": true, "default": "changeme"}
+      ]
+    }]
+  }
+}`,
+			expected: []Issue{
+				{
+					Severity: SeverityError, Pointer: "/server/repository/source", Line: 5, Column: 74,
+					Message: `value must be "github"`,
+				},
+				{
+					Severity: SeverityError, Pointer: "/server/packages/0/environment_variables/0", Line: 10, Column: 9,
+					Message: `required environment variable "API_KEY" has no description`,
+				},
+				{
+					Severity: SeverityError, Pointer: "/server/packages/0/environment_variables/0/default", Line: 10, Column: 80,
+					Message: `secret environment variable "API_KEY" must not have a default`,
+				},
+			},
+		},
+		{
+			name: "warnings",
+			data: `{
+  "name": "io.github.example/server",
+  "description": "An example server",
+  "repository": {"url": "https://github.com/someone-else/server", "source": "github"},
+  "version_detail": {"version": "1.0.0"}
+}`,
+			expected: []Issue{
+				{
+					Severity: SeverityWarning, Pointer: "", Line: 1, Column: 1,
+					Message: "server has no packages or remotes, so clients cannot run it",
+				},
+				{
+					Severity: SeverityWarning, Pointer: "/repository/url", Line: 4, Column: 25,
+					Message: `repository is not owned by "example", the owner in the server name`,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			issues, err := validateServerJSON([]byte(tc.data))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, issues)
+		})
+	}
+}
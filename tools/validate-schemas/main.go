@@ -54,6 +54,16 @@ func runValidation() error {
 			expectedSchemaCount, validatedCount)
 	}
 
+	// The publisher embeds copies of the schemas for its validate command
+	for _, schemaFile := range schemas {
+		embeddedPath := filepath.Join("tools", "publisher", "schemas", schemaFile.name)
+		log.Printf("Checking %s is in sync...", embeddedPath)
+
+		if err := compareFiles(schemaFile.path, embeddedPath); err != nil {
+			return fmt.Errorf("%s is out of sync with %s (copy it over): %w", embeddedPath, schemaFile.path, err)
+		}
+	}
+
 	log.Printf("\nSuccessfully validated all %d schemas!", validatedCount)
 	return nil
 }
@@ -92,3 +102,18 @@ func validateSchema(path string) error {
 
 	return nil
 }
+
+func compareFiles(path, otherPath string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	otherData, err := os.ReadFile(otherPath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	if !bytes.Equal(data, otherData) {
+		return fmt.Errorf("contents differ")
+	}
+	return nil
+}